@@ -0,0 +1,104 @@
+package aviatrix
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// segmentationGraph is the graph of the security domains of an AWS TGW, the
+// connections between them and the VPCs attached to them. Everything in it
+// is sorted by name, so that renderings of the same segmentation are equal.
+type segmentationGraph struct {
+	Domains     []segmentationDomain `json:"security_domains"`
+	Connections [][2]string          `json:"domain_connections"`
+}
+
+type segmentationDomain struct {
+	Name         string   `json:"security_domain_name"`
+	AttachedVPCs []string `json:"attached_vpcs"`
+}
+
+// newSegmentationGraph returns the graph of the given security domains. Each
+// connection is listed once, with the domain which sorts first first, even
+// if only one of its domains reports it.
+func newSegmentationGraph(routeDomainDetails []goaviatrix.RouteDomainDetail) *segmentationGraph {
+	g := &segmentationGraph{
+		Domains:     make([]segmentationDomain, 0, len(routeDomainDetails)),
+		Connections: make([][2]string, 0),
+	}
+	connections := make(map[[2]string]bool)
+	for _, routeDomainDetail := range routeDomainDetails {
+		domain := segmentationDomain{
+			Name:         routeDomainDetail.Name,
+			AttachedVPCs: make([]string, 0, len(routeDomainDetail.AttachedVPC)),
+		}
+		for _, attachedVPC := range routeDomainDetail.AttachedVPC {
+			domain.AttachedVPCs = append(domain.AttachedVPCs, attachedVPC.VPCId)
+		}
+		sort.Strings(domain.AttachedVPCs)
+		g.Domains = append(g.Domains, domain)
+
+		for _, connectedDomain := range routeDomainDetail.ConnectedRouteDomain {
+			conn := [2]string{routeDomainDetail.Name, connectedDomain}
+			if conn[0] == conn[1] {
+				continue
+			}
+			if conn[0] > conn[1] {
+				conn[0], conn[1] = conn[1], conn[0]
+			}
+			if !connections[conn] {
+				connections[conn] = true
+				g.Connections = append(g.Connections, conn)
+			}
+		}
+	}
+	sort.Slice(g.Domains, func(i, j int) bool {
+		return g.Domains[i].Name < g.Domains[j].Name
+	})
+	sort.Slice(g.Connections, func(i, j int) bool {
+		if g.Connections[i][0] != g.Connections[j][0] {
+			return g.Connections[i][0] < g.Connections[j][0]
+		}
+		return g.Connections[i][1] < g.Connections[j][1]
+	})
+	return g
+}
+
+// JSON renders the graph as indented JSON.
+func (g *segmentationGraph) JSON() (string, error) {
+	b, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+// DOT renders the graph in the DOT language of Graphviz. Security domains are
+// boxes, attached VPCs are ellipses linked to their domain by dashed edges.
+func (g *segmentationGraph) DOT(name string) string {
+	var b strings.Builder
+	b.WriteString("graph " + dotQuote(name) + " {\n")
+	for _, domain := range g.Domains {
+		b.WriteString("  " + dotQuote(domain.Name) + " [shape=box];\n")
+	}
+	for _, domain := range g.Domains {
+		for _, vpcID := range domain.AttachedVPCs {
+			b.WriteString("  " + dotQuote(vpcID) + " [shape=ellipse];\n")
+			b.WriteString("  " + dotQuote(domain.Name) + " -- " + dotQuote(vpcID) + " [style=dashed];\n")
+		}
+	}
+	for _, conn := range g.Connections {
+		b.WriteString("  " + dotQuote(conn[0]) + " -- " + dotQuote(conn[1]) + ";\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package aviatrix
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestSegmentationGraph(t *testing.T) {
+	g := newSegmentationGraph([]goaviatrix.RouteDomainDetail{
+		{
+			Name:                 "prod",
+			ConnectedRouteDomain: []string{"Shared_Service_Domain"},
+			AttachedVPC:          []goaviatrix.AttachedVPCDetail{{VPCId: "vpc-2"}, {VPCId: "vpc-1"}},
+		},
+		{
+			Name:                 "Shared_Service_Domain",
+			ConnectedRouteDomain: []string{"prod"},
+		},
+		{
+			Name: `odd"name`,
+		},
+	})
+
+	wantDOT := `graph "tgw" {
+  "Shared_Service_Domain" [shape=box];
+  "odd\"name" [shape=box];
+  "prod" [shape=box];
+  "vpc-1" [shape=ellipse];
+  "prod" -- "vpc-1" [style=dashed];
+  "vpc-2" [shape=ellipse];
+  "prod" -- "vpc-2" [style=dashed];
+  "Shared_Service_Domain" -- "prod";
+}
+`
+	if got := g.DOT("tgw"); got != wantDOT {
+		t.Errorf("unexpected DOT:\n%s", got)
+	}
+
+	wantJSON := `{
+  "security_domains": [
+    {
+      "security_domain_name": "Shared_Service_Domain",
+      "attached_vpcs": []
+    },
+    {
+      "security_domain_name": "odd\"name",
+      "attached_vpcs": []
+    },
+    {
+      "security_domain_name": "prod",
+      "attached_vpcs": [
+        "vpc-1",
+        "vpc-2"
+      ]
+    }
+  ],
+  "domain_connections": [
+    [
+      "Shared_Service_Domain",
+      "prod"
+    ]
+  ]
+}
+`
+	got, err := g.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if got != wantJSON {
+		t.Errorf("unexpected JSON:\n%s", got)
+	}
+}
+
+func TestSegmentationGraphConnections(t *testing.T) {
+	g := newSegmentationGraph([]goaviatrix.RouteDomainDetail{
+		// Only b reports its connection to a.
+		{Name: "a", ConnectedRouteDomain: []string{"c"}},
+		{Name: "b", ConnectedRouteDomain: []string{"a"}},
+		{Name: "c", ConnectedRouteDomain: []string{"a", "c", "a"}},
+	})
+
+	want := [][2]string{{"a", "b"}, {"a", "c"}}
+	if !reflect.DeepEqual(g.Connections, want) {
+		t.Errorf("expected connections %v, got %v", want, g.Connections)
+	}
+}
@@ -0,0 +1,76 @@
+package aviatrix
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// capability is a resource, or an attribute of a resource, which needs a
+// controller version later than the oldest one in supportedVersions.
+type capability struct {
+	// attribute is empty for the resource itself.
+	attribute  string
+	minVersion string
+}
+
+// controllerCapabilities lists the capabilities of each resource. They are
+// checked when planning to create the resource or to set the attribute, so
+// that a configuration the controller would reject fails before any change
+// is made.
+var controllerCapabilities = map[string][]capability{
+	"aviatrix_saml_endpoint": {
+		{minVersion: "4.7.585"},
+	},
+	"aviatrix_spoke_gateway": {
+		{minVersion: "4.7.520"},
+		{attribute: "eip", minVersion: "4.7.585"},
+		{attribute: "ha_eip", minVersion: "4.7.585"},
+	},
+	"aviatrix_transit_gateway": {
+		{minVersion: "4.7.520"},
+		{attribute: "eip", minVersion: "4.7.585"},
+		{attribute: "ha_eip", minVersion: "4.7.585"},
+	},
+}
+
+// addCapabilityChecks makes the resources with capabilities check them
+// while planning.
+func addCapabilityChecks(resources map[string]*schema.Resource) {
+	for name, capabilities := range controllerCapabilities {
+		r := resources[name]
+		r.CustomizeDiff = checkCapabilities(name, capabilities, r.CustomizeDiff)
+	}
+}
+
+// checkCapabilities returns a CustomizeDiffFunc failing if the diff creates
+// the resource or sets an attribute that the controller does not support,
+// before calling next if not nil.
+func checkCapabilities(resource string, capabilities []capability, next schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*goaviatrix.Client)
+		if ok {
+			for _, c := range capabilities {
+				feature := resource
+				if c.attribute == "" {
+					if d.Id() != "" {
+						continue
+					}
+				} else {
+					if _, set := d.GetOk(c.attribute); !set || !(d.Id() == "" || d.HasChange(c.attribute)) {
+						continue
+					}
+					feature = fmt.Sprintf("%q of %s", c.attribute, resource)
+				}
+				if err := client.RequireVersion(feature, c.minVersion); err != nil {
+					return err
+				}
+			}
+		}
+		if next != nil {
+			return next(d, meta)
+		}
+		return nil
+	}
+}
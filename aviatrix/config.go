@@ -1,19 +1,41 @@
 package aviatrix
 
 import (
-	"crypto/tls"
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
 )
 
 // Config contains the configuration for the Aviatrix provider
-// (Username, Password, and Controller IP)
+// (Username, Password, Controller IP, TLS verification, retry, concurrency,
+// read cache settings and default tags)
 type Config struct {
-	Username     string
-	Password     string
-	ControllerIP string
+	Username                string
+	Password                string
+	Profile                 string
+	ConfigFile              string
+	ControllerIP            string
+	ControllerIPs           []string
+	CAFile                  string
+	CAPEM                   string
+	CertFingerprints        []string
+	Insecure                bool
+	RetryMaxAttempts        int
+	RetryMinBackoff         time.Duration
+	RetryMaxBackoff         time.Duration
+	RetryOnReasons          []string
+	MaxConcurrentOperations int
+	DisableReadCache        bool
+	DefaultTags             map[string]string
+	Context                 context.Context
+	// WrapTransport, when set, wraps the transport of the client, e.g. to
+	// record or replay its traffic in tests.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 // Client gets the Aviatrix client to access the Controller
@@ -23,15 +45,142 @@ type Config struct {
 //    the aviatrix client (from goaviatrix)
 //    error (if any)
 func (c *Config) Client() (*goaviatrix.Client, error) {
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	ctx := c.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := c.applyProfile(ctx); err != nil {
+		log.Printf("[ERROR] unable to configure the Aviatrix client: %s", err)
+		return nil, err
+	}
+
+	tr, err := goaviatrix.NewTransport(&goaviatrix.TLSOptions{
+		CAFile:           c.CAFile,
+		CAPEM:            c.CAPEM,
+		CertFingerprints: c.CertFingerprints,
+		Insecure:         c.Insecure,
+	})
+	if err != nil {
+		log.Printf("[ERROR] unable to configure TLS for the controller: %s", err)
+		return nil, err
+	}
+	if c.Insecure {
+		log.Printf("[WARN] TLS certificate verification of the Aviatrix Controller is disabled")
 	}
-	client, err := goaviatrix.NewClient(c.Username, c.Password, c.ControllerIP, &http.Client{Transport: tr})
 
-	log.Printf("[INFO] Aviatrix Client configured for use")
+	var transport http.RoundTripper = tr
+	if c.WrapTransport != nil {
+		transport = c.WrapTransport(tr)
+	}
+	client, err := goaviatrix.NewClientEndpointsContext(ctx, c.Username, c.Password, c.endpoints(),
+		&http.Client{Transport: transport})
+	if client != nil {
+		client.SetRetryPolicy(c.retryPolicy())
+		client.SetMaxConcurrentOperations(c.MaxConcurrentOperations)
+		client.SetDefaultTags(c.DefaultTags)
+		if !c.DisableReadCache {
+			client.SetReadCacheTTL(goaviatrix.DefaultReadCacheTTL)
+		}
+	}
 
 	if client == nil || err != nil {
 		log.Printf("[ERROR] unable to create client: %s", err)
+	} else {
+		log.Printf("[INFO] Aviatrix Client configured for use with controller %s", client.Endpoint())
 	}
 	return client, err
 }
+
+// applyProfile takes the settings missing from the provider configuration
+// and environment from the selected profile of the shared config file. The
+// password comes from the credential process of the profile if it sets none.
+// Without a selected profile, the default one is used if it exists. The
+// shared config file is only read when a profile or config file is given,
+// or when the controller, username or password is missing.
+func (c *Config) applyProfile(ctx context.Context) error {
+	path := c.ConfigFile
+	if path == "" {
+		path = goaviatrix.DefaultSharedConfigFile()
+	}
+	selected := c.Profile != "" || c.ConfigFile != "" || os.Getenv("AVIATRIX_CONFIG_FILE") != ""
+	if path != "" && (selected || c.missingSettings()) {
+		profile, err := goaviatrix.LoadSharedProfile(path, c.Profile)
+		if err == nil {
+			log.Printf("[INFO] Using profile %s of %s", profile.Name, path)
+			if err = c.mergeProfile(ctx, profile); err != nil {
+				return err
+			}
+		} else if c.Profile != "" || !(os.IsNotExist(err) || errors.Is(err, goaviatrix.ErrNotFound)) {
+			return err
+		}
+	}
+
+	switch {
+	case c.ControllerIP == "" && len(c.ControllerIPs) == 0:
+		return errors.New("controller_ip is not set in the provider configuration, AVIATRIX_CONTROLLER_IP or a profile")
+	case c.Username == "":
+		return errors.New("username is not set in the provider configuration, AVIATRIX_USERNAME or a profile")
+	case c.Password == "":
+		return errors.New("password is not set in the provider configuration, AVIATRIX_PASSWORD or a profile")
+	}
+	return nil
+}
+
+// missingSettings reports whether the controller, username or password is
+// not set.
+func (c *Config) missingSettings() bool {
+	return (c.ControllerIP == "" && len(c.ControllerIPs) == 0) || c.Username == "" || c.Password == ""
+}
+
+// mergeProfile sets the settings missing from c to those of profile. Of the
+// TLS settings, profiles only provide ca_file; ca_pem, cert_fingerprints and
+// insecure must be set in the provider configuration.
+func (c *Config) mergeProfile(ctx context.Context, profile *goaviatrix.SharedProfile) error {
+	if c.ControllerIP == "" && len(c.ControllerIPs) == 0 {
+		c.ControllerIP = profile.ControllerIP
+		c.ControllerIPs = profile.ControllerIPs
+	}
+	if c.CAFile == "" {
+		c.CAFile = profile.CAFile
+	}
+	if c.Password == "" {
+		if profile.Password == "" {
+			if err := profile.RunCredentialProcess(ctx); err != nil {
+				return err
+			}
+		}
+		c.Password = profile.Password
+	}
+	if c.Username == "" {
+		c.Username = profile.Username
+	}
+	return nil
+}
+
+// endpoints returns the controllers to use, ControllerIPs taking precedence
+// over ControllerIP.
+func (c *Config) endpoints() []string {
+	if len(c.ControllerIPs) != 0 {
+		return c.ControllerIPs
+	}
+	return []string{c.ControllerIP}
+}
+
+// retryPolicy returns the default retry policy adjusted by the provider
+// arguments that are set.
+func (c *Config) retryPolicy() *goaviatrix.RetryPolicy {
+	policy := goaviatrix.DefaultRetryPolicy()
+	if c.RetryMaxAttempts > 0 {
+		policy.MaxAttempts = c.RetryMaxAttempts
+	}
+	if c.RetryMinBackoff > 0 {
+		policy.MinBackoff = c.RetryMinBackoff
+	}
+	if c.RetryMaxBackoff > 0 {
+		policy.MaxBackoff = c.RetryMaxBackoff
+	}
+	for _, reason := range c.RetryOnReasons {
+		policy.Rules = append(policy.Rules, goaviatrix.RetryRule{Reason: reason})
+	}
+	return policy
+}
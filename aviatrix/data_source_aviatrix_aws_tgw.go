@@ -0,0 +1,258 @@
+package aviatrix
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func dataSourceAviatrixAwsTgw() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAviatrixAwsTgwRead,
+
+		Schema: map[string]*schema.Schema{
+			"tgw_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the AWS TGW.",
+			},
+			"account_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the Cloud-Account of the AWS TGW.",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Region of the AWS TGW.",
+			},
+			"aws_side_as_number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "BGP Local ASN (Autonomous System Number) of the AWS TGW.",
+			},
+			"security_domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Security domains of the AWS TGW.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"security_domain_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the security domain.",
+						},
+						"route_table_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the TGW route table of the security domain.",
+						},
+						"connected_domains": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Names of the security domains connected to the security domain.",
+						},
+						"attached_vpc": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "VPCs attached to the security domain.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"vpc_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "ID of the VPC.",
+									},
+									"vpc_account_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Name of the Cloud-Account of the VPC.",
+									},
+									"vpc_region": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Region of the VPC.",
+									},
+									"attachment_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "ID of the TGW attachment of the VPC.",
+									},
+									"vpc_cidrs": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "CIDRs of the VPC.",
+									},
+								},
+							},
+						},
+						"routes": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Entries of the TGW route table of the security domain.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"cidr_block": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Destination CIDR of the route.",
+									},
+									"vpc_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "ID of the VPC the route leads to.",
+									},
+									"tgw_attachment_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "ID of the TGW attachment the route leads to.",
+									},
+									"type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Type of the route, such as 'propagated' or 'static'.",
+									},
+									"state": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "State of the route, such as 'active' or 'blackhole'.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"domain_connections": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Connections between the security domains of the AWS TGW, each listed once.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain_name1": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the security domain that sorts first.",
+						},
+						"domain_name2": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the other security domain.",
+						},
+					},
+				},
+			},
+			"attached_aviatrix_transit_gateway": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the Aviatrix transit gateways attached to the AWS TGW.",
+			},
+			"segmentation_dot": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Graph of the security domains, their connections and attached VPCs in the DOT language.",
+			},
+			"segmentation_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Graph of the security domains, their connections and attached VPCs as JSON.",
+			},
+		},
+	}
+}
+
+func dataSourceAviatrixAwsTgwRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	awsTgw, err := client.ListTgwDetails(&goaviatrix.AWSTgw{
+		Name: d.Get("tgw_name").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't find AWS TGW %s: %s", d.Get("tgw_name").(string), err)
+	}
+
+	log.Printf("[INFO] Reading AWS TGW %s", awsTgw.Name)
+
+	routeDomainDetails, err := client.ListRouteDomainDetails(awsTgw)
+	if err != nil {
+		return fmt.Errorf("couldn't read security domains of AWS TGW %s: %s", awsTgw.Name, err)
+	}
+
+	var securityDomains []map[string]interface{}
+	attachedGWs := make([]string, 0)
+	for _, routeDomainDetail := range routeDomainDetails {
+		var attachedVPCs []map[string]interface{}
+		for _, attachedVPC := range routeDomainDetail.AttachedVPC {
+			if routeDomainDetail.Name == "Aviatrix_Edge_Domain" {
+				gateway, err := client.GetTransitGwFromVpcID(&goaviatrix.Gateway{VpcID: attachedVPC.VPCId})
+				if err != nil {
+					return fmt.Errorf("couldn't find transit gateway of VPC %s: %s", attachedVPC.VPCId, err)
+				}
+				attachedGWs = append(attachedGWs, gateway.GwName)
+			}
+			attachedVPCs = append(attachedVPCs, map[string]interface{}{
+				"vpc_id":           attachedVPC.VPCId,
+				"vpc_account_name": attachedVPC.AccountName,
+				"vpc_region":       attachedVPC.Region,
+				"attachment_id":    attachedVPC.AttachmentId,
+				"vpc_cidrs":        attachedVPC.VPCCidr,
+			})
+		}
+
+		var routes []map[string]interface{}
+		for _, route := range routeDomainDetail.RoutesInRouteTable {
+			routes = append(routes, map[string]interface{}{
+				"cidr_block":        route.CidrBlock,
+				"vpc_id":            route.VPCId,
+				"tgw_attachment_id": route.TgwAttachmentId,
+				"type":              route.Type,
+				"state":             route.State,
+			})
+		}
+
+		securityDomains = append(securityDomains, map[string]interface{}{
+			"security_domain_name": routeDomainDetail.Name,
+			"route_table_id":       routeDomainDetail.RouteTableId,
+			"connected_domains":    routeDomainDetail.ConnectedRouteDomain,
+			"attached_vpc":         attachedVPCs,
+			"routes":               routes,
+		})
+	}
+
+	graph := newSegmentationGraph(routeDomainDetails)
+	var domainConnections []map[string]interface{}
+	for _, conn := range graph.Connections {
+		domainConnections = append(domainConnections, map[string]interface{}{
+			"domain_name1": conn[0],
+			"domain_name2": conn[1],
+		})
+	}
+	segmentationJSON, err := graph.JSON()
+	if err != nil {
+		return fmt.Errorf("couldn't render segmentation of AWS TGW %s: %s", awsTgw.Name, err)
+	}
+
+	d.Set("tgw_name", awsTgw.Name)
+	d.Set("account_name", awsTgw.AccountName)
+	d.Set("region", awsTgw.Region)
+	d.Set("aws_side_as_number", awsTgw.AwsSideAsNumber)
+	if err := d.Set("security_domains", securityDomains); err != nil {
+		log.Printf("[WARN] Error setting security_domains for (%s): %s", awsTgw.Name, err)
+	}
+	if err := d.Set("domain_connections", domainConnections); err != nil {
+		log.Printf("[WARN] Error setting domain_connections for (%s): %s", awsTgw.Name, err)
+	}
+	if err := d.Set("attached_aviatrix_transit_gateway", attachedGWs); err != nil {
+		log.Printf("[WARN] Error setting attached_aviatrix_transit_gateway for (%s): %s", awsTgw.Name, err)
+	}
+	d.Set("segmentation_dot", graph.DOT(awsTgw.Name))
+	d.Set("segmentation_json", segmentationJSON)
+
+	d.SetId(awsTgw.Name)
+	return nil
+}
@@ -0,0 +1,153 @@
+package aviatrix
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceAviatrixAwsTgw_offline(t *testing.T) {
+	testFakeController(t)
+	rName := acctest.RandString(5)
+	resourceName := "data.aviatrix_aws_tgw.foo"
+	appDomain := "security_domains.3."
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAviatrixAwsTgwConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tgw_name", fmt.Sprintf("tft-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "account_name", fmt.Sprintf("tfa-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "region", os.Getenv("AWS_REGION")),
+					resource.TestCheckResourceAttr(resourceName, "aws_side_as_number", "64512"),
+					resource.TestCheckResourceAttr(resourceName, "security_domains.#", "4"),
+					resource.TestCheckResourceAttr(resourceName, "security_domains.0.security_domain_name",
+						"Aviatrix_Edge_Domain"),
+					resource.TestCheckResourceAttr(resourceName, appDomain+"security_domain_name",
+						fmt.Sprintf("app-%s", rName)),
+					resource.TestCheckResourceAttrSet(resourceName, appDomain+"route_table_id"),
+					resource.TestCheckResourceAttr(resourceName, appDomain+"connected_domains.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, appDomain+"connected_domains.0",
+						"Shared_Service_Domain"),
+					resource.TestCheckResourceAttr(resourceName, appDomain+"attached_vpc.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, appDomain+"attached_vpc.0.vpc_id",
+						"vpc-0fedcba9876543210"),
+					resource.TestCheckResourceAttr(resourceName, appDomain+"attached_vpc.0.vpc_account_name",
+						fmt.Sprintf("tfa-%s", rName)),
+					resource.TestCheckResourceAttrSet(resourceName, appDomain+"attached_vpc.0.attachment_id"),
+					resource.TestCheckResourceAttr(resourceName, appDomain+"routes.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, appDomain+"routes.0.vpc_id",
+						"vpc-0fedcba9876543210"),
+					resource.TestCheckResourceAttr(resourceName, "domain_connections.#", "4"),
+					resource.TestCheckResourceAttr(resourceName, "domain_connections.3.domain_name1",
+						"Shared_Service_Domain"),
+					resource.TestCheckResourceAttr(resourceName, "domain_connections.3.domain_name2",
+						fmt.Sprintf("app-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "attached_aviatrix_transit_gateway.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "attached_aviatrix_transit_gateway.0",
+						fmt.Sprintf("tfg-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "segmentation_dot",
+						testAccDataSourceAviatrixAwsTgwSegmentationDOT(rName)),
+					resource.TestMatchResourceAttr(resourceName, "segmentation_json",
+						regexp.MustCompile(`"security_domain_name": "app-`+rName+`",\s+"attached_vpcs": \[\s+"vpc-0fedcba9876543210"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAviatrixAwsTgwConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_account" {
+	account_name       = "tfa-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+
+resource "aviatrix_transit_gateway" "test_transit_gateway" {
+	cloud_type   = 1
+	account_name = aviatrix_account.test_account.account_name
+	gw_name      = "tfg-%[1]s"
+	vpc_id       = "%[5]s"
+	vpc_reg      = "%[6]s"
+	gw_size      = "t2.micro"
+	subnet       = "%[7]s"
+}
+
+resource "aviatrix_aws_tgw" "test_aws_tgw" {
+	account_name                      = aviatrix_account.test_account.account_name
+	attached_aviatrix_transit_gateway = [aviatrix_transit_gateway.test_transit_gateway.gw_name]
+	aws_side_as_number                = "64512"
+	manage_vpc_attachment             = true
+	region                            = "%[6]s"
+	tgw_name                          = "tft-%[1]s"
+
+	security_domains {
+		connected_domains    = [
+			"Default_Domain",
+			"Shared_Service_Domain"
+		]
+		security_domain_name = "Aviatrix_Edge_Domain"
+	}
+	security_domains {
+		connected_domains    = [
+			"Aviatrix_Edge_Domain",
+			"Shared_Service_Domain"
+		]
+		security_domain_name = "Default_Domain"
+	}
+	security_domains {
+		connected_domains    = [
+			"Aviatrix_Edge_Domain",
+			"Default_Domain",
+			"app-%[1]s"
+		]
+		security_domain_name = "Shared_Service_Domain"
+	}
+	security_domains {
+		connected_domains    = [
+			"Shared_Service_Domain"
+		]
+		security_domain_name = "app-%[1]s"
+
+		attached_vpc {
+			vpc_account_name = aviatrix_account.test_account.account_name
+			vpc_id           = "vpc-0fedcba9876543210"
+			vpc_region       = "%[6]s"
+		}
+	}
+}
+
+data "aviatrix_aws_tgw" "foo" {
+	tgw_name = aviatrix_aws_tgw.test_aws_tgw.id
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET"))
+}
+
+func testAccDataSourceAviatrixAwsTgwSegmentationDOT(rName string) string {
+	return fmt.Sprintf(`graph "tft-%[1]s" {
+  "Aviatrix_Edge_Domain" [shape=box];
+  "Default_Domain" [shape=box];
+  "Shared_Service_Domain" [shape=box];
+  "app-%[1]s" [shape=box];
+  "%[2]s" [shape=ellipse];
+  "Aviatrix_Edge_Domain" -- "%[2]s" [style=dashed];
+  "vpc-0fedcba9876543210" [shape=ellipse];
+  "app-%[1]s" -- "vpc-0fedcba9876543210" [style=dashed];
+  "Aviatrix_Edge_Domain" -- "Default_Domain";
+  "Aviatrix_Edge_Domain" -- "Shared_Service_Domain";
+  "Default_Domain" -- "Shared_Service_Domain";
+  "Shared_Service_Domain" -- "app-%[1]s";
+}
+`, rName, os.Getenv("AWS_VPC_ID"))
+}
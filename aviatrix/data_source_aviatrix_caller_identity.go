@@ -18,6 +18,11 @@ func dataSourceAviatrixCallerIdentity() *schema.Resource {
 				Computed:    true,
 				Description: "Aviatrix caller identity.",
 			},
+			"controller_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Controller the provider is logged in to.",
+			},
 		},
 	}
 }
@@ -25,9 +30,10 @@ func dataSourceAviatrixCallerIdentity() *schema.Resource {
 func dataSourceAviatrixCallerIdentityRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
-	log.Printf("[DEBUG] CID is '%s'", client.CID)
+	log.Printf("[DEBUG] Reading caller identity from controller %s", client.Endpoint())
 
 	d.SetId(time.Now().UTC().String())
-	d.Set("cid", client.CID)
+	d.Set("cid", client.CID())
+	d.Set("controller_ip", client.Endpoint())
 	return nil
 }
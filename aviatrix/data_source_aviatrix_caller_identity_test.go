@@ -53,7 +53,9 @@ func testAccDataSourceAviatrixCallerIdentity(name string) resource.TestCheckFunc
 		}
 
 		client := testAccProvider.Meta().(*goaviatrix.Client)
-		client.CID = rs.Primary.Attributes["cid"]
+		if rs.Primary.Attributes["cid"] != client.CID() {
+			return fmt.Errorf("CID of the data source does not match the CID of the provider")
+		}
 
 		version, _, err := client.GetCurrentVersion()
 		if err != nil {
@@ -2,75 +2,185 @@ package aviatrix
 
 import (
 	"errors"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
 )
 
-const supportedVersion = "4.7"
+// supportedVersions is the range of controller versions the provider works
+// with. Features needing a later build are listed in controllerCapabilities.
+const supportedVersions = "4.7 - 5.x"
 
 // Provider returns a schema.Provider for Aviatrix.
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"controller_ip": {
-				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: envDefaultFunc("AVIATRIX_CONTROLLER_IP"),
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   envDefaultFunc("AVIATRIX_CONTROLLER_IP"),
+				ConflictsWith: []string{"controller_ips"},
+			},
+			"controller_ips": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Controllers to use in order of preference, e.g. a primary and a standby restored from its backup.",
 			},
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: envDefaultFunc("AVIATRIX_USERNAME"),
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: envDefaultFunc("AVIATRIX_PASSWORD"),
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("AVIATRIX_PROFILE"),
+				Description: "Profile of the shared config file providing the settings not set otherwise.",
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of the shared config file. Defaults to AVIATRIX_CONFIG_FILE or ~/.aviatrix/config.",
+			},
 			"skip_version_validation": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"ca_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("AVIATRIX_CA_FILE"),
+				Description: "Path to a PEM encoded CA bundle used to verify the controller certificate.",
+			},
+			"ca_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded CA bundle used to verify the controller certificate.",
+			},
+			"cert_fingerprints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "SHA-256 fingerprints of the controller certificate to pin.",
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip verification of the controller certificate chain and hostname.",
+			},
+			"retry_max_attempts": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     4,
+				Description: "Maximum number of attempts for a controller operation that can be retried.",
+			},
+			"retry_min_backoff": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     5,
+				Description: "Seconds to wait before the first retry. The wait doubles on every further retry.",
+			},
+			"retry_max_backoff": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     60,
+				Description: "Maximum number of seconds to wait between retries.",
+			},
+			"retry_on_reasons": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Additional fragments of controller error reasons for which operations are retried.",
+			},
+			"max_concurrent_operations": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of mutating operations sent to the controller at the same time. 0 means unlimited.",
+			},
+			"disable_read_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Download the inventory of gateways, VPCs, accounts and FQDN tags for every resource read instead of once per run.",
+			},
+			"default_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:         schema.TypeMap,
+							Optional:     true,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							ValidateFunc: validateTags,
+							Description:  "Tags added to the tags of every AWS gateway, unless the gateway sets the same key.",
+						},
+					},
+				},
+				Description: "Tags added to every AWS gateway of aviatrix_gateway, aviatrix_spoke_gateway, aviatrix_spoke_vpc, aviatrix_transit_gateway and aviatrix_transit_vpc. Not added by aviatrix_resource_tags.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"aviatrix_account":                 resourceAviatrixAccount(),
-			"aviatrix_account_user":            resourceAviatrixAccountUser(),
-			"aviatrix_arm_peer":                resourceAviatrixARMPeer(),
-			"aviatrix_aws_peer":                resourceAviatrixAWSPeer(),
-			"aviatrix_aws_tgw":                 resourceAviatrixAWSTgw(),
-			"aviatrix_aws_tgw_vpc_attachment":  resourceAviatrixAwsTgwVpcAttachment(),
-			"aviatrix_aws_tgw_vpn_conn":        resourceAviatrixAwsTgwVpnConn(),
-			"aviatrix_controller_config":       resourceAviatrixControllerConfig(),
-			"aviatrix_firewall":                resourceAviatrixFirewall(),
-			"aviatrix_firewall_tag":            resourceAviatrixFirewallTag(),
-			"aviatrix_fqdn":                    resourceAviatrixFQDN(),
-			"aviatrix_gateway":                 resourceAviatrixGateway(),
-			"aviatrix_saml_endpoint":           resourceAviatrixSamlEndpoint(),
-			"aviatrix_site2cloud":              resourceAviatrixSite2Cloud(),
-			"aviatrix_spoke_gateway":           resourceAviatrixSpokeGateway(),
-			"aviatrix_spoke_vpc":               resourceAviatrixSpokeVpc(),
-			"aviatrix_trans_peer":              resourceAviatrixTransPeer(),
-			"aviatrix_transit_gateway":         resourceAviatrixTransitGateway(),
-			"aviatrix_transit_gateway_peering": resourceAviatrixTransitGatewayPeering(),
-			"aviatrix_transit_vpc":             resourceAviatrixTransitVpc(),
-			"aviatrix_tunnel":                  resourceAviatrixTunnel(),
-			"aviatrix_vgw_conn":                resourceAviatrixVGWConn(),
-			"aviatrix_vpc":                     resourceAviatrixVpc(),
-			"aviatrix_vpn_profile":             resourceAviatrixProfile(),
-			"aviatrix_vpn_user":                resourceAviatrixVPNUser(),
-			"aviatrix_vpn_user_accelerator":    resourceAviatrixVPNUserAccelerator(),
+			"aviatrix_account":                            resourceAviatrixAccount(),
+			"aviatrix_account_user":                       resourceAviatrixAccountUser(),
+			"aviatrix_arm_peer":                           resourceAviatrixARMPeer(),
+			"aviatrix_aws_peer":                           resourceAviatrixAWSPeer(),
+			"aviatrix_aws_tgw":                            resourceAviatrixAWSTgw(),
+			"aviatrix_aws_tgw_security_domain":            resourceAviatrixAwsTgwSecurityDomain(),
+			"aviatrix_aws_tgw_security_domain_connection": resourceAviatrixAwsTgwSecurityDomainConnection(),
+			"aviatrix_aws_tgw_transit_gateway_attachment": resourceAviatrixAwsTgwTransitGatewayAttachment(),
+			"aviatrix_aws_tgw_vpc_attachment":             resourceAviatrixAwsTgwVpcAttachment(),
+			"aviatrix_aws_tgw_vpn_conn":                   resourceAviatrixAwsTgwVpnConn(),
+			"aviatrix_controller_config":                  resourceAviatrixControllerConfig(),
+			"aviatrix_firewall":                           resourceAviatrixFirewall(),
+			"aviatrix_firewall_tag":                       resourceAviatrixFirewallTag(),
+			"aviatrix_fqdn":                               resourceAviatrixFQDN(),
+			"aviatrix_gateway":                            resourceAviatrixGateway(),
+			"aviatrix_resource_tags":                      resourceAviatrixResourceTags(),
+			"aviatrix_saml_endpoint":                      resourceAviatrixSamlEndpoint(),
+			"aviatrix_site2cloud":                         resourceAviatrixSite2Cloud(),
+			"aviatrix_spoke_gateway":                      resourceAviatrixSpokeGateway(),
+			"aviatrix_spoke_vpc":                          resourceAviatrixSpokeVpc(),
+			"aviatrix_trans_peer":                         resourceAviatrixTransPeer(),
+			"aviatrix_transit_gateway":                    resourceAviatrixTransitGateway(),
+			"aviatrix_transit_gateway_peering":            resourceAviatrixTransitGatewayPeering(),
+			"aviatrix_transit_vpc":                        resourceAviatrixTransitVpc(),
+			"aviatrix_tunnel":                             resourceAviatrixTunnel(),
+			"aviatrix_vgw_conn":                           resourceAviatrixVGWConn(),
+			"aviatrix_vpc":                                resourceAviatrixVpc(),
+			"aviatrix_vpn_profile":                        resourceAviatrixProfile(),
+			"aviatrix_vpn_user":                           resourceAviatrixVPNUser(),
+			"aviatrix_vpn_user_accelerator":               resourceAviatrixVPNUserAccelerator(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"aviatrix_caller_identity": dataSourceAviatrixCallerIdentity(),
 			"aviatrix_account":         dataSourceAviatrixAccount(),
+			"aviatrix_aws_tgw":         dataSourceAviatrixAwsTgw(),
 			"aviatrix_gateway":         dataSourceAviatrixGateway(),
 		},
-		ConfigureFunc: aviatrixConfigure,
 	}
+	addCapabilityChecks(provider.ResourcesMap)
+	addReplacementGuards(provider.ResourcesMap)
+	provider.ConfigureFunc = aviatrixConfigure(provider)
+
+	return provider
 }
 
 func envDefaultFunc(k string) schema.SchemaDefaultFunc {
@@ -83,37 +193,97 @@ func envDefaultFunc(k string) schema.SchemaDefaultFunc {
 	}
 }
 
-func aviatrixConfigure(d *schema.ResourceData) (interface{}, error) {
-	config := Config{
-		ControllerIP: d.Get("controller_ip").(string),
-		Username:     d.Get("username").(string),
-		Password:     d.Get("password").(string),
+// controllerIPs returns the controller_ips argument, or the comma separated
+// list in AVIATRIX_CONTROLLER_IPS if it is not set.
+func controllerIPs(d *schema.ResourceData) []string {
+	if ips := goaviatrix.ExpandStringList(d.Get("controller_ips").([]interface{})); len(ips) != 0 {
+		return ips
 	}
-
-	skipVersionValidation := d.Get("skip_version_validation").(bool)
-	if skipVersionValidation {
-		return config.Client()
+	var ips []string
+	for _, ip := range strings.Split(os.Getenv("AVIATRIX_CONTROLLER_IPS"), ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			ips = append(ips, ip)
+		}
 	}
+	return ips
+}
 
-	client, err := config.Client()
-	if err != nil {
-		return nil, err
+func providerConfig(d *schema.ResourceData) Config {
+	return Config{
+		ControllerIP:            d.Get("controller_ip").(string),
+		ControllerIPs:           controllerIPs(d),
+		Username:                d.Get("username").(string),
+		Password:                d.Get("password").(string),
+		Profile:                 d.Get("profile").(string),
+		ConfigFile:              d.Get("config_file").(string),
+		CAFile:                  d.Get("ca_file").(string),
+		CAPEM:                   d.Get("ca_pem").(string),
+		CertFingerprints:        goaviatrix.ExpandStringList(d.Get("cert_fingerprints").([]interface{})),
+		Insecure:                d.Get("insecure").(bool),
+		RetryMaxAttempts:        d.Get("retry_max_attempts").(int),
+		RetryMinBackoff:         time.Duration(d.Get("retry_min_backoff").(int)) * time.Second,
+		RetryMaxBackoff:         time.Duration(d.Get("retry_max_backoff").(int)) * time.Second,
+		RetryOnReasons:          goaviatrix.ExpandStringList(d.Get("retry_on_reasons").([]interface{})),
+		MaxConcurrentOperations: d.Get("max_concurrent_operations").(int),
+		DisableReadCache:        d.Get("disable_read_cache").(bool),
+		DefaultTags:             defaultTags(d),
 	}
+}
 
-	err = client.ControllerVersionValidation(supportedVersion)
-	if err != nil {
-		return nil, errors.New("controller version validation failed: " + err.Error())
+// defaultTags returns the tags of the default_tags block.
+func defaultTags(d *schema.ResourceData) map[string]string {
+	tags := map[string]string{}
+	if v, ok := d.GetOk("default_tags.0.tags"); ok {
+		for k, value := range v.(map[string]interface{}) {
+			tags[k] = value.(string)
+		}
 	}
+	return tags
+}
 
-	return client, nil
+// aviatrixConfigure returns the provider's ConfigureFunc. The client it
+// creates is bound to the provider's stop context, so interrupting Terraform
+// aborts in-flight controller requests. Credentials are masked in the log
+// output from then on.
+func aviatrixConfigure(p *schema.Provider) schema.ConfigureFunc {
+	return aviatrixConfigureTransport(p, nil)
 }
 
-func aviatrixConfigureWithoutVersionValidation(d *schema.ResourceData) (interface{}, error) {
-	config := Config{
-		ControllerIP: d.Get("controller_ip").(string),
-		Username:     d.Get("username").(string),
-		Password:     d.Get("password").(string),
+// aviatrixConfigureTransport is the same as aviatrixConfigure but wraps the
+// transport of the client with wrap, if not nil.
+func aviatrixConfigureTransport(p *schema.Provider, wrap func(http.RoundTripper) http.RoundTripper) schema.ConfigureFunc {
+	return func(d *schema.ResourceData) (interface{}, error) {
+		goaviatrix.RedactLogOutput()
+		config := providerConfig(d)
+		config.Context = p.StopContext()
+		config.WrapTransport = wrap
+
+		client, err := config.Client()
+		if err != nil {
+			return nil, err
+		}
+
+		skipVersionValidation := d.Get("skip_version_validation").(bool)
+		if skipVersionValidation {
+			client.SkipVersionChecks(true)
+			return client, nil
+		}
+
+		err = client.ControllerVersionValidation(supportedVersions)
+		if err != nil {
+			return nil, errors.New("controller version validation failed: " + err.Error())
+		}
+
+		return client, nil
 	}
+}
+
+func aviatrixConfigureWithoutVersionValidation(p *schema.Provider) schema.ConfigureFunc {
+	return func(d *schema.ResourceData) (interface{}, error) {
+		goaviatrix.RedactLogOutput()
+		config := providerConfig(d)
+		config.Context = p.StopContext()
 
-	return config.Client()
+		return config.Client()
+	}
 }
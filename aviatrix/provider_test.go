@@ -1,11 +1,18 @@
 package aviatrix
 
 import (
+	"io/ioutil"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/fakecontroller"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -21,7 +28,7 @@ func init() {
 	}
 
 	testAccProviderVersionValidation = Provider().(*schema.Provider)
-	testAccProviderVersionValidation.ConfigureFunc = aviatrixConfigureWithoutVersionValidation
+	testAccProviderVersionValidation.ConfigureFunc = aviatrixConfigureWithoutVersionValidation(testAccProviderVersionValidation)
 	testAccProvidersVersionValidation = map[string]terraform.ResourceProvider{
 		"aviatrix": testAccProviderVersionValidation,
 	}
@@ -37,6 +44,76 @@ func TestProvider_impl(t *testing.T) {
 	var _ = Provider()
 }
 
+func TestProvider_supportedVersions(t *testing.T) {
+	for version, supported := range map[string]bool{
+		"UserConnect-4.6.100":  false,
+		"UserConnect-4.7.520":  true,
+		"UserConnect-5.0.2000": true,
+		"UserConnect-5.3.100":  true,
+		"UserConnect-6.0.10":   false,
+	} {
+		t.Run(version, func(t *testing.T) {
+			srv := testFakeController(t)
+			srv.Version = version
+
+			p := Provider().(*schema.Provider)
+			_, err := p.ConfigureFunc(schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{}))
+			if supported && err != nil {
+				t.Errorf("expected controller version %s to be supported, got %v", version, err)
+			}
+			if !supported && (err == nil || !strings.Contains(err.Error(), "controller version validation failed")) {
+				t.Errorf("expected controller version %s to be rejected, got %v", version, err)
+			}
+		})
+	}
+}
+
+func TestProvider_profile(t *testing.T) {
+	srv := testFakeController(t)
+	os.Unsetenv("AVIATRIX_CONTROLLER_IP")
+	os.Unsetenv("AVIATRIX_PASSWORD")
+	path := filepath.Join(t.TempDir(), "config")
+	profile := "[default]\ncontroller_ip = 192.0.2.1\n\n[profile fake]\ncontroller_ip = " + srv.Host() +
+		"\ncredential_process = echo " + srv.Password + "\n"
+	if err := ioutil.WriteFile(path, []byte(profile), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := Config{Username: srv.Username, Profile: "fake", ConfigFile: path, CAFile: os.Getenv("AVIATRIX_CA_FILE")}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("failed to log in with the profile: %v", err)
+	}
+	if client.ControllerIP != srv.Host() {
+		t.Errorf("expected controller %s from the profile, got %s", srv.Host(), client.ControllerIP)
+	}
+
+	config = Config{Username: srv.Username, Profile: "missing", ConfigFile: path}
+	if _, err := config.Client(); err == nil {
+		t.Errorf("expected an error for a missing profile")
+	}
+	config = Config{Username: srv.Username, ConfigFile: filepath.Join(t.TempDir(), "none")}
+	if _, err := config.Client(); err == nil || err.Error() != "controller_ip is not set in the provider configuration, AVIATRIX_CONTROLLER_IP or a profile" {
+		t.Errorf("expected an error for the missing controller, got %v", err)
+	}
+
+	// The default config file is only read for missing settings.
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".aviatrix", "config"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", home)
+	t.Setenv("AVIATRIX_CONFIG_FILE", "")
+	config = Config{Username: srv.Username, Password: srv.Password, ControllerIP: srv.Host(), CAFile: os.Getenv("AVIATRIX_CA_FILE")}
+	if _, err := config.Client(); err != nil {
+		t.Errorf("expected the unreadable default config file to be ignored, got %v", err)
+	}
+	config = Config{Username: srv.Username, ControllerIP: srv.Host(), CAFile: os.Getenv("AVIATRIX_CA_FILE")}
+	if _, err := config.Client(); err == nil {
+		t.Errorf("expected an error reading the default config file for the missing password")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("AVIATRIX_CONTROLLER_IP"); v == "" {
 		t.Fatal("AVIATRIX_CONTROLLER_IP must be set for acceptance tests.")
@@ -48,3 +125,98 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("AVIATRIX_PASSWORD must be set for acceptance tests.")
 	}
 }
+
+// testFakeController starts a fake controller for the duration of the test
+// and points the provider and the acceptance test configurations at it, so
+// they can run with resource.UnitTest without a controller or cloud account.
+func testFakeController(t *testing.T) *fakecontroller.Server {
+	srv := fakecontroller.New()
+	t.Cleanup(srv.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(caFile, []byte(srv.CAPEM()), 0600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+	env := map[string]string{
+		"AVIATRIX_CONTROLLER_IP": srv.Host(),
+		"AVIATRIX_USERNAME":      srv.Username,
+		"AVIATRIX_PASSWORD":      srv.Password,
+		"AVIATRIX_CA_FILE":       caFile,
+		"AWS_ACCOUNT_NUMBER":     "123456789012",
+		"AWS_ACCESS_KEY":         "AKIAFAKEACCESSKEY",
+		"AWS_SECRET_KEY":         "fake-secret-key",
+		"AWS_VPC_ID":             "vpc-0123456789abcdef0",
+		"AWS_REGION":             "us-east-1",
+		"AWS_SUBNET":             "10.0.0.0/24",
+	}
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+	return srv
+}
+
+// testAccSecretEnv are the environment variables holding secrets. Their
+// values are not saved in cassettes and are replaced on replay.
+var testAccSecretEnv = map[string]bool{
+	"AVIATRIX_PASSWORD":   true,
+	"AWS_SECRET_KEY":      true,
+	"ARM_APPLICATION_KEY": true,
+}
+
+// testAccCassette records the controller traffic of an acceptance test to
+// testdata/cassettes/<test name>.json when AVIATRIX_CASSETTE is "record", and
+// replays it from there when it is "replay", so that the test runs without a
+// controller or cloud account. env lists the environment variables the test
+// reads: their values are saved with the recording and set again on replay.
+// It returns nil when AVIATRIX_CASSETTE is not set. Values the test draws at
+// random must go through Var of the returned Recorder.
+func testAccCassette(t *testing.T, env ...string) *goaviatrix.Recorder {
+	var mode goaviatrix.RecorderMode
+	switch m := os.Getenv("AVIATRIX_CASSETTE"); m {
+	case "":
+		return nil
+	case "record":
+		mode = goaviatrix.RecorderModeRecord
+	case "replay":
+		mode = goaviatrix.RecorderModeReplay
+	default:
+		t.Fatalf("AVIATRIX_CASSETTE must be record or replay, got %q", m)
+	}
+	rec, err := goaviatrix.NewRecorder(filepath.Join("testdata", "cassettes", t.Name()+".json"), mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, k := range append([]string{"AVIATRIX_USERNAME", "AVIATRIX_PASSWORD"}, env...) {
+		v := os.Getenv(k)
+		if testAccSecretEnv[k] {
+			v = "replayed-secret"
+		} else {
+			v = rec.Var("env:"+k, v)
+		}
+		if mode == goaviatrix.RecorderModeReplay {
+			t.Setenv(k, v)
+		}
+	}
+	if mode == goaviatrix.RecorderModeReplay {
+		t.Setenv("AVIATRIX_CONTROLLER_IP", "controller.invalid")
+		t.Setenv("AVIATRIX_CONTROLLER_IPS", "")
+		t.Setenv(resource.TestEnvVar, "1")
+	}
+
+	configure := testAccProvider.ConfigureFunc
+	testAccProvider.ConfigureFunc = aviatrixConfigureTransport(testAccProvider,
+		func(tr http.RoundTripper) http.RoundTripper {
+			rec.Transport = tr
+			return rec
+		})
+	t.Cleanup(func() {
+		testAccProvider.ConfigureFunc = configure
+		if !t.Failed() {
+			if err := rec.Save(); err != nil {
+				t.Errorf("failed to save cassette: %v", err)
+			}
+		}
+	})
+	return rec
+}
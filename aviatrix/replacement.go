@@ -0,0 +1,51 @@
+package aviatrix
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// addReplacementGuards makes the resources with an allow_replacement
+// attribute refuse to plan their replacement unless it is set.
+func addReplacementGuards(resources map[string]*schema.Resource) {
+	for name, r := range resources {
+		if _, ok := r.Schema["allow_replacement"]; !ok {
+			continue
+		}
+		var forceNew []string
+		for k, s := range r.Schema {
+			if s.ForceNew {
+				forceNew = append(forceNew, k)
+			}
+		}
+		sort.Strings(forceNew)
+		r.CustomizeDiff = guardReplacement(name, forceNew, r.CustomizeDiff)
+	}
+}
+
+// guardReplacement returns a CustomizeDiffFunc failing if the diff changes
+// one of the forceNew attributes of an existing resource while
+// allow_replacement is not set, before calling next if not nil.
+func guardReplacement(resource string, forceNew []string, next schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" && !d.Get("allow_replacement").(bool) {
+			var changed []string
+			for _, k := range forceNew {
+				if d.HasChange(k) {
+					changed = append(changed, k)
+				}
+			}
+			if len(changed) != 0 {
+				return fmt.Errorf("changing %s of %s %q replaces it, set allow_replacement to true to allow it",
+					strings.Join(changed, ", "), resource, d.Id())
+			}
+		}
+		if next != nil {
+			return next(d, meta)
+		}
+		return nil
+	}
+}
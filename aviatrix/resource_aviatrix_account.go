@@ -18,17 +18,29 @@ func resourceAviatrixAccount() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(
+			requireIf(equals("cloud_type", 1), "aws_account_number", "aws account number is needed for aws cloud"),
+			requireIf(equals("cloud_type", 4), "gcloud_project_credentials_filepath",
+				"gcloud project credentials local filepath needed to upload file to controller"),
+			requireIf(equals("cloud_type", 8), "arm_subscription_id", "arm subscription id needed for azure arm cloud"),
+			requireIf(equals("cloud_type", 8), "arm_directory_id", "arm directory id needed for azure arm cloud"),
+			requireIf(equals("cloud_type", 8), "arm_application_id", "arm application id needed for azure arm cloud"),
+			requireIf(equals("cloud_type", 8), "arm_application_key", "arm application key needed for azure arm cloud"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Account name. This can be used for logging in to CloudN console or UserConnect controller.",
 			},
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCloudType(1, 4, 8),
+				Description:  "Type of cloud service provider.",
 			},
 			"aws_account_number": {
 				Type:        schema.TypeString,
@@ -284,14 +296,6 @@ func resourceAviatrixAccountUpdate(d *schema.ResourceData, meta interface{}) err
 
 	d.Partial(true)
 
-	if d.HasChange("cloud_type") {
-		return fmt.Errorf("update cloud_type is not allowed")
-	}
-
-	if d.HasChange("account_name") {
-		return fmt.Errorf("update account name is not allowed")
-	}
-
 	if account.CloudType == 1 {
 		if d.HasChange("aws_account_number") || d.HasChange("aws_access_key") ||
 			d.HasChange("aws_secret_key") || d.HasChange("aws_iam") ||
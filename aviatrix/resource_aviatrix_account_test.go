@@ -158,6 +158,37 @@ func TestAccAviatrixAccount_basic(t *testing.T) {
 	}
 }
 
+func TestAviatrixAccount_offline(t *testing.T) {
+	var account goaviatrix.Account
+
+	testFakeController(t)
+	rInt := acctest.RandInt()
+	resourceName := "aviatrix_account.aws"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountConfigAWS(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccountExists(resourceName, &account),
+					resource.TestCheckResourceAttr(resourceName, "account_name", fmt.Sprintf("tf-testing-aws-%d", rInt)),
+					resource.TestCheckResourceAttr(resourceName, "aws_account_number", os.Getenv("AWS_ACCOUNT_NUMBER")),
+					resource.TestCheckResourceAttr(resourceName, "aws_iam", "false"),
+					resource.TestCheckResourceAttr(resourceName, "aws_access_key", os.Getenv("AWS_ACCESS_KEY")),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"aws_secret_key"},
+			},
+		},
+	})
+}
+
 func testAccAccountConfigAWS(rInt int) string {
 	return fmt.Sprintf(`
 resource "aviatrix_account" "aws" {
@@ -22,6 +22,7 @@ func resourceAviatrixAccountUser() *schema.Resource {
 			"account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Cloud account name of user to be created.",
 			},
 			"password": {
@@ -39,6 +40,7 @@ func resourceAviatrixAccountUser() *schema.Resource {
 			"username": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of account user to be created.",
 			},
 		},
@@ -119,14 +121,6 @@ func resourceAviatrixAccountUserUpdate(d *schema.ResourceData, meta interface{})
 
 	log.Printf("[INFO] Updating Aviatrix account user: %#v", user)
 
-	if d.HasChange("username") {
-		return fmt.Errorf("update username is not allowed")
-	}
-
-	if d.HasChange("account_name") {
-		return fmt.Errorf("change account name for an existing user is not allowed")
-	}
-
 	if d.HasChange("email") {
 		_, n := d.GetChange("email")
 		if n == nil {
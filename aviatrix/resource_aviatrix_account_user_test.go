@@ -48,6 +48,36 @@ func TestAccAviatrixAccountUser_basic(t *testing.T) {
 	})
 }
 
+func TestAviatrixAccountUser_offline(t *testing.T) {
+	var account goaviatrix.AccountUser
+
+	testFakeController(t)
+	rInt := acctest.RandInt()
+	resourceName := "aviatrix_account_user.foo"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAccountUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountUserConfigBasic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccountUserExists(resourceName, &account),
+					resource.TestCheckResourceAttr(resourceName, "username", fmt.Sprintf("tf-testing-%d", rInt)),
+					resource.TestCheckResourceAttr(resourceName, "email", "abc@xyz.com"),
+					resource.TestCheckResourceAttr(resourceName, "account_name", "admin"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
 func testAccAccountUserConfigBasic(rInt int) string {
 	return fmt.Sprintf(`
 resource "aviatrix_account_user" "foo" {
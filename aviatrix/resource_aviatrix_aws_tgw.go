@@ -1,8 +1,10 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -17,30 +19,41 @@ func resourceAviatrixAWSTgw() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(validateAWSTgwDomains),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 
-		SchemaVersion: 1,
+		SchemaVersion: 3,
 		MigrateState:  resourceAviatrixAWSTgwMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"tgw_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the AWS TGW which is going to be created.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "This parameter represents the name of a Cloud-Account in Aviatrix controller.",
 			},
 			"region": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Region of cloud provider.",
 			},
 			"aws_side_as_number": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "BGP Local ASN (Autonomous System Number), Integer between 1-65535.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateASN,
+				Description:  "BGP Local ASN (Autonomous System Number), Integer between 1-65535.",
 			},
 			"security_domains": {
 				Type:        schema.TypeList,
@@ -101,13 +114,95 @@ func resourceAviatrixAWSTgw() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"manage_security_domain": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether security domains of the AWS TGW not listed in security_domains, and their connections, are removed. Must be false when aviatrix_aws_tgw_security_domain_connection manages connections of the AWS TGW.",
+			},
+			"manage_transit_gateway_attachment": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether attached_aviatrix_transit_gateway lists the transit gateways attached to the AWS TGW.",
+			},
+			"allow_replacement": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow Terraform to replace the AWS TGW when an attribute which cannot be updated changes.",
+			},
 		},
 	}
 }
 
+// validateAWSTgwDomains checks the security domains and the attached
+// gateways of the configuration.
+func validateAWSTgwDomains(d *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range []string{"region", "manage_vpc_attachment", "manage_transit_gateway_attachment", "security_domains",
+		"attached_aviatrix_transit_gateway"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+	region := d.Get("region").(string)
+	manageVpcAttachment := d.Get("manage_vpc_attachment").(bool)
+
+	var domainsAll []string
+	for i, domain := range d.Get("security_domains").([]interface{}) {
+		dn := domain.(map[string]interface{})
+		key := fmt.Sprintf("security_domains.%d", i)
+		if !d.NewValueKnown(key + ".security_domain_name") {
+			return nil
+		}
+		name := dn["security_domain_name"].(string)
+		domainsAll = append(domainsAll, name)
+
+		for j, attachedVPCs := range dn["attached_vpc"].([]interface{}) {
+			attachedVPC := attachedVPCs.(map[string]interface{})
+			if !manageVpcAttachment {
+				return fmt.Errorf("manage_vpc_attachment is set to false. 'attached_vpc' should be empty")
+			}
+			if name == "Aviatrix_Edge_Domain" {
+				return fmt.Errorf("validation of source file failed: no VPCs should be attached to 'Aviatrix_Edge_Domain'")
+			}
+			vpcKey := fmt.Sprintf("%s.attached_vpc.%d.", key, j)
+			if d.NewValueKnown(vpcKey+"vpc_region") && attachedVPC["vpc_region"].(string) != region {
+				return fmt.Errorf("validation of source file failed: region of VPC (ID: %v) is different than "+
+					"AWS_TGW", attachedVPC["vpc_id"])
+			}
+		}
+	}
+
+	defaultDomainsWithCreation := []string{"Aviatrix_Edge_Domain", "Default_Domain", "Shared_Service_Domain"}
+	if len(goaviatrix.Difference(defaultDomainsWithCreation, domainsAll)) != 0 {
+		return fmt.Errorf("one or more of the three default domains are missing")
+	}
+
+	attachedGWs := make(map[string]bool)
+	for i, attachedGW := range d.Get("attached_aviatrix_transit_gateway").([]interface{}) {
+		if !d.Get("manage_transit_gateway_attachment").(bool) {
+			return fmt.Errorf("manage_transit_gateway_attachment is set to false. 'attached_aviatrix_transit_gateway' " +
+				"should be empty")
+		}
+		if !d.NewValueKnown(fmt.Sprintf("attached_aviatrix_transit_gateway.%d", i)) {
+			continue
+		}
+		if attachedGWs[attachedGW.(string)] {
+			return fmt.Errorf("validation of source file failed: duplicate transit gateways (ID: %v) to attach",
+				attachedGW)
+		}
+		attachedGWs[attachedGW.(string)] = true
+	}
+	return nil
+}
+
 func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
 	awsTgw := &goaviatrix.AWSTgw{
 		Name:                      d.Get("tgw_name").(string),
 		AccountName:               d.Get("account_name").(string),
@@ -222,7 +317,7 @@ func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("validation of source file failed: %v", err)
 	}
 
-	err1 := client.CreateAWSTgw(awsTgw)
+	err1 := client.CreateAWSTgwContext(ctx, awsTgw)
 	if err1 != nil {
 		return fmt.Errorf("failed to create AWS TGW: %s", err1)
 	}
@@ -238,7 +333,7 @@ func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) erro
 			Region:      d.Get("region").(string),
 			AwsTgwName:  d.Get("tgw_name").(string),
 		}
-		err := client.CreateSecurityDomain(securityDomain)
+		err := client.CreateSecurityDomainContext(ctx, securityDomain)
 		if err != nil {
 			return fmt.Errorf("failed to create Security Domain: %s", err)
 		}
@@ -246,7 +341,7 @@ func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) erro
 
 	for i := range domainConnPolicy {
 		if len(domainConnPolicy[i]) == 2 {
-			err := client.CreateDomainConnection(awsTgw, domainConnPolicy[i][0], domainConnPolicy[i][1])
+			err := client.CreateDomainConnectionContext(ctx, awsTgw, domainConnPolicy[i][0], domainConnPolicy[i][1])
 			if err != nil {
 				return fmt.Errorf("failed to create security domain connection: %s", err)
 			}
@@ -255,7 +350,7 @@ func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) erro
 
 	for i := range domainConnRemove {
 		if len(domainConnRemove[i]) == 2 {
-			err := client.DeleteDomainConnection(awsTgw, domainConnRemove[i][0], domainConnRemove[i][1])
+			err := client.DeleteDomainConnectionContext(ctx, awsTgw, domainConnRemove[i][0], domainConnRemove[i][1])
 			if err != nil {
 				return fmt.Errorf("failed to delete domain connection: %s", err)
 			}
@@ -266,7 +361,7 @@ func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) erro
 		gateway := &goaviatrix.Gateway{
 			GwName: attachedGWAll[i],
 		}
-		err := client.AttachAviatrixTransitGWToAWSTgw(awsTgw, gateway, "Aviatrix_Edge_Domain")
+		err := client.AttachAviatrixTransitGWToAWSTgwContext(ctx, awsTgw, gateway, "Aviatrix_Edge_Domain")
 		if err != nil {
 			return fmt.Errorf("failed to attach transit GW: %s", err)
 		}
@@ -279,7 +374,7 @@ func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) erro
 				AccountName: attachedVPCAll[i][2],
 				VpcID:       attachedVPCAll[i][1],
 			}
-			err := client.AttachVpcToAWSTgw(awsTgw, vpcSolo, attachedVPCAll[i][0])
+			err := client.AttachVpcToAWSTgwContext(ctx, awsTgw, vpcSolo, attachedVPCAll[i][0])
 			if err != nil {
 				return fmt.Errorf("failed to attach VPC: %s", err)
 			}
@@ -306,6 +401,9 @@ func resourceAviatrixAWSTgwRead(d *schema.ResourceData, meta interface{}) error
 		log.Printf("[DEBUG] Looks like an import, no aws tgw name received. Import Id is %s", id)
 		d.Set("tgw_name", id)
 		d.Set("manage_vpc_attachment", true)
+		d.Set("manage_security_domain", true)
+		d.Set("manage_transit_gateway_attachment", true)
+		d.Set("allow_replacement", false)
 		d.SetId(id)
 	}
 
@@ -328,16 +426,19 @@ func resourceAviatrixAWSTgwRead(d *schema.ResourceData, meta interface{}) error
 
 	awsTgw, err2 := client.GetAWSTgw(awsTgw)
 	if err2 != nil {
-		return fmt.Errorf("couldn't find AWS TGW: %s", awsTgw.Name)
+		return fmt.Errorf("couldn't find AWS TGW %s: %s", d.Get("tgw_name").(string), err2)
 	}
 
 	d.Set("aws_side_as_number", awsTgw.AwsSideAsNumber)
 
-	if err := d.Set("attached_aviatrix_transit_gateway", awsTgw.AttachedAviatrixTransitGW); err != nil {
-		log.Printf("[WARN] Error setting attached_aviatrix_transit_gateway for (%s): %s", d.Id(), err)
+	if d.Get("manage_transit_gateway_attachment").(bool) {
+		if err := d.Set("attached_aviatrix_transit_gateway", awsTgw.AttachedAviatrixTransitGW); err != nil {
+			log.Printf("[WARN] Error setting attached_aviatrix_transit_gateway for (%s): %s", d.Id(), err)
+		}
 	}
 
 	manageVpcAttachment := d.Get("manage_vpc_attachment").(bool)
+	manageSecurityDomain := d.Get("manage_security_domain").(bool)
 
 	mSecurityDomain := make(map[string]map[string]interface{})
 	for _, sd := range awsTgw.SecurityDomains {
@@ -363,18 +464,20 @@ func resourceAviatrixAWSTgwRead(d *schema.ResourceData, meta interface{}) error
 	var securityDomains []map[string]interface{}
 	domains := d.Get("security_domains").([]interface{})
 	mOld := make(map[string]bool)
+	for _, domain := range domains {
+		mOld[domain.(map[string]interface{})["security_domain_name"].(string)] = true
+	}
 	for _, domain := range domains {
 		dn := domain.(map[string]interface{})
 
-		mOld[dn["security_domain_name"].(string)] = true
-
 		if mSecurityDomain[dn["security_domain_name"].(string)] != nil {
 			mADm := make(map[string]bool)
 			aDmNew := make([]string, 0)
 			attachedDomains := mSecurityDomain[dn["security_domain_name"].(string)]["connected_domains"].([]string)
 
 			for i := 0; i < len(attachedDomains); i++ {
-				mADm[attachedDomains[i]] = true
+				// Connections to domains managed elsewhere are managed there as well.
+				mADm[attachedDomains[i]] = manageSecurityDomain || mOld[attachedDomains[i]]
 			}
 			attachedDomains1 := dn["connected_domains"].([]interface{})
 
@@ -430,9 +533,11 @@ func resourceAviatrixAWSTgwRead(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	for _, dn := range awsTgw.SecurityDomains {
-		if !mOld[dn.Name] {
-			securityDomains = append(securityDomains, mSecurityDomain[dn.Name])
+	if manageSecurityDomain {
+		for _, dn := range awsTgw.SecurityDomains {
+			if !mOld[dn.Name] {
+				securityDomains = append(securityDomains, mSecurityDomain[dn.Name])
+			}
 		}
 	}
 
@@ -447,6 +552,10 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 	log.Printf("[INFO] Updating AWS TGW")
 
 	client := meta.(*goaviatrix.Client)
+
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
 	awsTgw := &goaviatrix.AWSTgw{
 		Name:        d.Get("tgw_name").(string),
 		AccountName: d.Get("account_name").(string),
@@ -464,19 +573,6 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 
 	d.Partial(true)
 
-	if d.HasChange("tgw_name") {
-		return fmt.Errorf("updating tgw_name is not allowed")
-	}
-	if d.HasChange("account_name") {
-		return fmt.Errorf("updating account_name is not allowed")
-	}
-	if d.HasChange("region") {
-		return fmt.Errorf("updating region is not allowed")
-	}
-	if d.HasChange("aws_side_as_number") {
-		return fmt.Errorf("updating aws_side_as_number is not allowed")
-	}
-
 	manageVpcAttachment := d.Get("manage_vpc_attachment").(bool)
 
 	if d.HasChange("manage_vpc_attachment") {
@@ -491,7 +587,9 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 
 	mAttachedGWNew := make(map[string]int)
 
-	if d.HasChange("attached_aviatrix_transit_gateway") {
+	// Transit gateways left out when manage_transit_gateway_attachment is
+	// turned off stay attached and are managed elsewhere from now on.
+	if d.HasChange("attached_aviatrix_transit_gateway") && d.Get("manage_transit_gateway_attachment").(bool) {
 		oldAGW, newAGW := d.GetChange("attached_aviatrix_transit_gateway")
 
 		if oldAGW == nil {
@@ -658,6 +756,27 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 				domainConnRemove = append(domainConnRemove, domainConnRemove1[i])
 			}
 		}
+
+		if d.HasChange("manage_security_domain") && !d.Get("manage_security_domain").(bool) {
+			// The domains left out when manage_security_domain is turned off,
+			// e.g. after an import, are managed elsewhere from now on.
+			released := goaviatrix.Difference(domainsOld, domainsNew)
+			domainsToRemove = goaviatrix.Difference(domainsToRemove, released)
+			var keepConnRemove [][]string
+			for _, conn := range domainConnRemove {
+				if !goaviatrix.Contains(released, conn[0]) && !goaviatrix.Contains(released, conn[1]) {
+					keepConnRemove = append(keepConnRemove, conn)
+				}
+			}
+			domainConnRemove = keepConnRemove
+			var keepDetachVPCs [][]string
+			for _, vpc := range toDetachVPCs {
+				if !goaviatrix.Contains(released, vpc[0]) {
+					keepDetachVPCs = append(keepDetachVPCs, vpc)
+				}
+			}
+			toDetachVPCs = keepDetachVPCs
+		}
 	}
 
 	for i := range toDetachGWs {
@@ -665,7 +784,7 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 			GwName: toDetachGWs[i],
 		}
 
-		err := client.DetachAviatrixTransitGWFromAWSTgw(awsTgw, gateway, "Aviatrix_Edge_Domain")
+		err := client.DetachAviatrixTransitGWFromAWSTgwContext(ctx, awsTgw, gateway, "Aviatrix_Edge_Domain")
 		if err != nil {
 			resourceAviatrixAWSTgwRead(d, meta)
 			return fmt.Errorf("failed to detach transit GW: %s", err)
@@ -680,7 +799,7 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 			AwsTgwName:  d.Get("tgw_name").(string),
 		}
 
-		err := client.CreateSecurityDomain(securityDomain)
+		err := client.CreateSecurityDomainContext(ctx, securityDomain)
 		if err != nil {
 			resourceAviatrixAWSTgwRead(d, meta)
 			return fmt.Errorf("failed to create Security Domain: %s", err)
@@ -689,7 +808,7 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 
 	for i := range domainConnPolicy {
 		if len(domainConnPolicy[i]) == 2 {
-			err := client.CreateDomainConnection(awsTgw, domainConnPolicy[i][0], domainConnPolicy[i][1])
+			err := client.CreateDomainConnectionContext(ctx, awsTgw, domainConnPolicy[i][0], domainConnPolicy[i][1])
 			if err != nil {
 				resourceAviatrixAWSTgwRead(d, meta)
 				return fmt.Errorf("failed to create security domain connection: %s", err)
@@ -699,7 +818,7 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 
 	for i := range domainConnRemove {
 		if len(domainConnRemove[i]) == 2 {
-			err := client.DeleteDomainConnection(awsTgw, domainConnRemove[i][0], domainConnRemove[i][1])
+			err := client.DeleteDomainConnectionContext(ctx, awsTgw, domainConnRemove[i][0], domainConnRemove[i][1])
 			if err != nil {
 				resourceAviatrixAWSTgwRead(d, meta)
 				return fmt.Errorf("failed to delete domain connection: %s", err)
@@ -710,7 +829,7 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 	if manageVpcAttachment {
 		for i := range toDetachVPCs {
 			if len(toDetachVPCs[i]) == 4 {
-				err := client.DetachVpcFromAWSTgw(awsTgw, toDetachVPCs[i][1])
+				err := client.DetachVpcFromAWSTgwContext(ctx, awsTgw, toDetachVPCs[i][1])
 				if err != nil {
 					resourceAviatrixAWSTgwRead(d, meta)
 					return fmt.Errorf("failed to detach VPC: %s", err)
@@ -724,7 +843,7 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 			GwName: toAttachGWs[i],
 		}
 
-		err := client.AttachAviatrixTransitGWToAWSTgw(awsTgw, gateway, "Aviatrix_Edge_Domain")
+		err := client.AttachAviatrixTransitGWToAWSTgwContext(ctx, awsTgw, gateway, "Aviatrix_Edge_Domain")
 		if err != nil {
 			resourceAviatrixAWSTgwRead(d, meta)
 			return fmt.Errorf("failed to attach transit GW: %s", err)
@@ -740,9 +859,9 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 					VpcID:       toAttachVPCs[i][1],
 				}
 
-				res, _ := client.IsVpcAttachedToTgw(awsTgw, &vpcSolo)
+				res, _ := client.IsVpcAttachedToTgwContext(ctx, awsTgw, &vpcSolo)
 				if !res {
-					err := client.AttachVpcToAWSTgw(awsTgw, vpcSolo, toAttachVPCs[i][0])
+					err := client.AttachVpcToAWSTgwContext(ctx, awsTgw, vpcSolo, toAttachVPCs[i][0])
 					if err != nil {
 						resourceAviatrixAWSTgwRead(d, meta)
 						return fmt.Errorf("failed to attach VPC: %s", err)
@@ -760,7 +879,7 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 			AwsTgwName:  d.Get("tgw_name").(string),
 		}
 
-		err := client.DeleteSecurityDomain(securityDomain)
+		err := client.DeleteSecurityDomainContext(ctx, securityDomain)
 		if err != nil {
 			resourceAviatrixAWSTgwRead(d, meta)
 			return fmt.Errorf("failed to delete Security Domain: %s", err)
@@ -774,6 +893,10 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 
 func resourceAviatrixAWSTgwDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
+
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
 	awsTgw := &goaviatrix.AWSTgw{
 		Name:                      d.Get("tgw_name").(string),
 		AccountName:               d.Get("account_name").(string),
@@ -802,7 +925,7 @@ func resourceAviatrixAWSTgwDelete(d *schema.ResourceData, meta interface{}) erro
 		}
 		for i := range attachedVPCs {
 			if len(attachedVPCs[i]) == 4 {
-				err := client.DetachVpcFromAWSTgw(awsTgw, attachedVPCs[i][1])
+				err := client.DetachVpcFromAWSTgwContext(ctx, awsTgw, attachedVPCs[i][1])
 				if err != nil {
 					resourceAviatrixAWSTgwRead(d, meta)
 					return fmt.Errorf("failed to detach VPC: %s", err)
@@ -821,14 +944,14 @@ func resourceAviatrixAWSTgwDelete(d *schema.ResourceData, meta interface{}) erro
 			GwName: attachedGWs[i],
 		}
 
-		err := client.DetachAviatrixTransitGWFromAWSTgw(awsTgw, gateway, "Aviatrix_Edge_Domain")
+		err := client.DetachAviatrixTransitGWFromAWSTgwContext(ctx, awsTgw, gateway, "Aviatrix_Edge_Domain")
 		if err != nil {
 			resourceAviatrixAWSTgwRead(d, meta)
 			return fmt.Errorf("failed to detach transit GW: %s", err)
 		}
 	}
 
-	err := client.DeleteAWSTgw(awsTgw)
+	err := client.DeleteAWSTgwContext(ctx, awsTgw)
 	if err != nil {
 		if err == goaviatrix.ErrNotFound {
 			d.SetId("")
@@ -11,8 +11,26 @@ func resourceAviatrixAWSTgwMigrateState(
 	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
 	switch v {
 	case 0:
-		log.Println("[INFO] Found AVIATRIX AWS TGW State v0; migrating to v1")
-		return migrateAWSTgwStateV0toV1(is)
+		log.Println("[INFO] Found AVIATRIX AWS TGW State v0; migrating to v3")
+		is, err := migrateAWSTgwStateV0toV1(is)
+		if err != nil {
+			return is, err
+		}
+		is, err = migrateAWSTgwStateV1toV2(is)
+		if err != nil {
+			return is, err
+		}
+		return migrateAWSTgwStateV2toV3(is)
+	case 1:
+		log.Println("[INFO] Found AVIATRIX AWS TGW State v1; migrating to v3")
+		is, err := migrateAWSTgwStateV1toV2(is)
+		if err != nil {
+			return is, err
+		}
+		return migrateAWSTgwStateV2toV3(is)
+	case 2:
+		log.Println("[INFO] Found AVIATRIX AWS TGW State v2; migrating to v3")
+		return migrateAWSTgwStateV2toV3(is)
 	default:
 		return is, fmt.Errorf("unexpected schema version: %d", v)
 	}
@@ -30,3 +48,29 @@ func migrateAWSTgwStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceS
 	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
 	return is, nil
 }
+
+func migrateAWSTgwStateV1toV2(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() || is.Attributes == nil {
+		log.Println("[DEBUG] Empty AWS TGW State; nothing to migrate.")
+		return is, nil
+	}
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	is.Attributes["manage_security_domain"] = "true"
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
+
+func migrateAWSTgwStateV2toV3(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() || is.Attributes == nil {
+		log.Println("[DEBUG] Empty AWS TGW State; nothing to migrate.")
+		return is, nil
+	}
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	is.Attributes["manage_transit_gateway_attachment"] = "true"
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
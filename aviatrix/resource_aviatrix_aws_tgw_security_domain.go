@@ -0,0 +1,136 @@
+package aviatrix
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func resourceAviatrixAwsTgwSecurityDomain() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAviatrixAwsTgwSecurityDomainCreate,
+		Read:   resourceAviatrixAwsTgwSecurityDomainRead,
+		Delete: resourceAviatrixAwsTgwSecurityDomainDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"tgw_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the AWS TGW.",
+			},
+			"security_domain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validateStringNotIn("Aviatrix_Edge_Domain", "Default_Domain",
+					"Shared_Service_Domain"),
+				Description: "Name of the security domain.",
+			},
+		},
+	}
+}
+
+func resourceAviatrixAwsTgwSecurityDomainCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	awsTgw, err := client.ListTgwDetails(&goaviatrix.AWSTgw{
+		Name: d.Get("tgw_name").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't find AWS TGW %s: %s", d.Get("tgw_name").(string), err)
+	}
+
+	securityDomain := &goaviatrix.SecurityDomain{
+		Name:        d.Get("security_domain_name").(string),
+		AccountName: awsTgw.AccountName,
+		Region:      awsTgw.Region,
+		AwsTgwName:  awsTgw.Name,
+	}
+
+	log.Printf("[INFO] Creating security domain %s of AWS TGW %s", securityDomain.Name, securityDomain.AwsTgwName)
+
+	err = client.CreateSecurityDomain(securityDomain)
+	if err != nil {
+		return fmt.Errorf("failed to create Security Domain: %s", err)
+	}
+
+	d.SetId(securityDomain.AwsTgwName + "~" + securityDomain.Name)
+	return resourceAviatrixAwsTgwSecurityDomainRead(d, meta)
+}
+
+func resourceAviatrixAwsTgwSecurityDomainRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	tgwName := d.Get("tgw_name").(string)
+	securityDomainName := d.Get("security_domain_name").(string)
+
+	if tgwName == "" || securityDomainName == "" {
+		id := d.Id()
+		log.Printf("[DEBUG] Looks like an import. Import Id is %s", id)
+
+		parts := strings.Split(id, "~")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid security domain id %q, expected tgw_name~security_domain_name", id)
+		}
+		d.Set("tgw_name", parts[0])
+		d.Set("security_domain_name", parts[1])
+	}
+
+	securityDomain := &goaviatrix.SecurityDomain{
+		Name:       d.Get("security_domain_name").(string),
+		AwsTgwName: d.Get("tgw_name").(string),
+	}
+
+	_, err := client.GetSecurityDomain(securityDomain)
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("couldn't find Security Domain %s: %s", securityDomain.Name, err)
+	}
+
+	d.SetId(securityDomain.AwsTgwName + "~" + securityDomain.Name)
+	return nil
+}
+
+func resourceAviatrixAwsTgwSecurityDomainDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	awsTgw, err := client.ListTgwDetails(&goaviatrix.AWSTgw{
+		Name: d.Get("tgw_name").(string),
+	})
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("couldn't find AWS TGW %s: %s", d.Get("tgw_name").(string), err)
+	}
+
+	securityDomain := &goaviatrix.SecurityDomain{
+		Name:        d.Get("security_domain_name").(string),
+		AccountName: awsTgw.AccountName,
+		Region:      awsTgw.Region,
+		AwsTgwName:  awsTgw.Name,
+	}
+
+	log.Printf("[INFO] Deleting security domain %s of AWS TGW %s", securityDomain.Name, securityDomain.AwsTgwName)
+
+	err = client.DeleteSecurityDomain(securityDomain)
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete Security Domain: %s", err)
+	}
+
+	return nil
+}
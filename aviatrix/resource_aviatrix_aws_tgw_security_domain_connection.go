@@ -0,0 +1,150 @@
+package aviatrix
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func resourceAviatrixAwsTgwSecurityDomainConnection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAviatrixAwsTgwSecurityDomainConnectionCreate,
+		Read:   resourceAviatrixAwsTgwSecurityDomainConnectionRead,
+		Delete: resourceAviatrixAwsTgwSecurityDomainConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: validateDiff(
+			forbid(sameAs("domain_name1", "domain_name2"), "domain_name1 and domain_name2 must be different domains"),
+		),
+
+		Schema: map[string]*schema.Schema{
+			"tgw_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the AWS TGW.",
+			},
+			"domain_name1": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressSwappedDomainNames,
+				Description:      "Name of one of the two connected security domains. At least one of the two must not be listed in the security_domains of the AWS TGW.",
+			},
+			"domain_name2": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressSwappedDomainNames,
+				Description:      "Name of the other connected security domain.",
+			},
+		},
+	}
+}
+
+// suppressSwappedDomainNames suppresses the diff of swapping domain_name1 and
+// domain_name2, since the connection between A and B is the connection
+// between B and A.
+func suppressSwappedDomainNames(k, old, new string, d *schema.ResourceData) bool {
+	old1, new1 := d.GetChange("domain_name1")
+	old2, new2 := d.GetChange("domain_name2")
+	return old1.(string) != "" && old1.(string) == new2.(string) && old2.(string) == new1.(string)
+}
+
+func resourceAviatrixAwsTgwSecurityDomainConnectionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	domainName1 := d.Get("domain_name1").(string)
+	domainName2 := d.Get("domain_name2").(string)
+	if domainName1 == domainName2 {
+		return fmt.Errorf("connection between same domains (name: %v)", domainName1)
+	}
+
+	awsTgw, err := client.ListTgwDetails(&goaviatrix.AWSTgw{
+		Name: d.Get("tgw_name").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't find AWS TGW %s: %s", d.Get("tgw_name").(string), err)
+	}
+
+	log.Printf("[INFO] Creating connection between security domains %s and %s of AWS TGW %s", domainName1,
+		domainName2, awsTgw.Name)
+
+	err = client.CreateDomainConnection(awsTgw, domainName1, domainName2)
+	if err != nil {
+		return fmt.Errorf("failed to create security domain connection: %s", err)
+	}
+
+	d.SetId(awsTgw.Name + "~" + domainName1 + "~" + domainName2)
+	return resourceAviatrixAwsTgwSecurityDomainConnectionRead(d, meta)
+}
+
+func resourceAviatrixAwsTgwSecurityDomainConnectionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	tgwName := d.Get("tgw_name").(string)
+	domainName1 := d.Get("domain_name1").(string)
+	domainName2 := d.Get("domain_name2").(string)
+
+	if tgwName == "" || domainName1 == "" || domainName2 == "" {
+		id := d.Id()
+		log.Printf("[DEBUG] Looks like an import. Import Id is %s", id)
+
+		parts := strings.Split(id, "~")
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid security domain connection id %q, expected tgw_name~domain_name1~domain_name2", id)
+		}
+		tgwName, domainName1, domainName2 = parts[0], parts[1], parts[2]
+		d.Set("tgw_name", tgwName)
+		d.Set("domain_name1", domainName1)
+		d.Set("domain_name2", domainName2)
+	}
+
+	err := client.GetDomainConnection(&goaviatrix.AWSTgw{Name: tgwName}, domainName1, domainName2)
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("couldn't find connection between security domains %s and %s: %s", domainName1,
+			domainName2, err)
+	}
+
+	d.SetId(tgwName + "~" + domainName1 + "~" + domainName2)
+	return nil
+}
+
+func resourceAviatrixAwsTgwSecurityDomainConnectionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	awsTgw, err := client.ListTgwDetails(&goaviatrix.AWSTgw{
+		Name: d.Get("tgw_name").(string),
+	})
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("couldn't find AWS TGW %s: %s", d.Get("tgw_name").(string), err)
+	}
+
+	domainName1 := d.Get("domain_name1").(string)
+	domainName2 := d.Get("domain_name2").(string)
+
+	log.Printf("[INFO] Deleting connection between security domains %s and %s of AWS TGW %s", domainName1,
+		domainName2, awsTgw.Name)
+
+	err = client.DeleteDomainConnection(awsTgw, domainName1, domainName2)
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete security domain connection: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package aviatrix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestAviatrixAwsTgwSecurityDomainConnection_offline(t *testing.T) {
+	testFakeController(t)
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_aws_tgw_security_domain_connection.test"
+	id := fmt.Sprintf("tft-%s~app-%s~Shared_Service_Domain", rName, rName)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsTgwSecurityDomainConnectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				// A domain can't be connected to itself.
+				Config: `
+resource "aviatrix_aws_tgw_security_domain_connection" "test" {
+	tgw_name     = "tgw"
+	domain_name1 = "Shared_Service_Domain"
+	domain_name2 = "Shared_Service_Domain"
+}
+`,
+				ExpectError: regexp.MustCompile("domain_name1 and domain_name2 must be different domains"),
+			},
+			{
+				Config: testAccAwsTgwSecurityDomainConnectionConfigBasic(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsTgwSecurityDomainConnectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "domain_name1", fmt.Sprintf("app-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "domain_name2", "Shared_Service_Domain"),
+				),
+			},
+			{
+				// Swapping the domains is the same connection.
+				Config: testAccAwsTgwSecurityDomainConnectionConfigBasic(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsTgwSecurityDomainConnectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAwsTgwSecurityDomainConnectionConfigBasic(rName string, swapped bool) string {
+	domainName1 := "aviatrix_aws_tgw_security_domain.test.security_domain_name"
+	domainName2 := `"Shared_Service_Domain"`
+	if swapped {
+		domainName1, domainName2 = domainName2, domainName1
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_account" {
+	account_name       = "tfa-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+
+resource "aviatrix_aws_tgw" "test_aws_tgw" {
+	account_name           = aviatrix_account.test_account.account_name
+	aws_side_as_number     = "64512"
+	manage_vpc_attachment  = false
+	manage_security_domain = false
+	region                 = "%s"
+	tgw_name               = "tft-%[1]s"
+
+	security_domains {
+		connected_domains    = [
+			"Default_Domain",
+			"Shared_Service_Domain"
+		]
+		security_domain_name = "Aviatrix_Edge_Domain"
+	}
+	security_domains {
+		connected_domains    = [
+			"Aviatrix_Edge_Domain",
+			"Shared_Service_Domain"
+		]
+		security_domain_name = "Default_Domain"
+	}
+	security_domains {
+		connected_domains    = [
+			"Aviatrix_Edge_Domain",
+			"Default_Domain"
+		]
+		security_domain_name = "Shared_Service_Domain"
+	}
+}
+
+resource "aviatrix_aws_tgw_security_domain" "test" {
+	tgw_name             = aviatrix_aws_tgw.test_aws_tgw.tgw_name
+	security_domain_name = "app-%[1]s"
+}
+
+resource "aviatrix_aws_tgw_security_domain_connection" "test" {
+	tgw_name     = aviatrix_aws_tgw.test_aws_tgw.tgw_name
+	domain_name1 = %[6]s
+	domain_name2 = %[7]s
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_REGION"), domainName1, domainName2)
+}
+
+func testAccCheckAwsTgwSecurityDomainConnectionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("security domain connection Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no security domain connection ID is set")
+		}
+
+		client := testAccProvider.Meta().(*goaviatrix.Client)
+
+		return client.GetDomainConnection(&goaviatrix.AWSTgw{Name: rs.Primary.Attributes["tgw_name"]},
+			rs.Primary.Attributes["domain_name1"], rs.Primary.Attributes["domain_name2"])
+	}
+}
+
+func testAccCheckAwsTgwSecurityDomainConnectionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*goaviatrix.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aviatrix_aws_tgw_security_domain_connection" {
+			continue
+		}
+
+		err := client.GetDomainConnection(&goaviatrix.AWSTgw{Name: rs.Primary.Attributes["tgw_name"]},
+			rs.Primary.Attributes["domain_name1"], rs.Primary.Attributes["domain_name2"])
+		if !errors.Is(err, goaviatrix.ErrNotFound) {
+			return fmt.Errorf("security domain connection still exists")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package aviatrix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestAviatrixAwsTgwSecurityDomain_offline(t *testing.T) {
+	testFakeController(t)
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_aws_tgw_security_domain.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsTgwSecurityDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsTgwSecurityDomainConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsTgwSecurityDomainExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("tft-%s~app-%s", rName, rName)),
+					resource.TestCheckResourceAttr(resourceName, "tgw_name", fmt.Sprintf("tft-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "security_domain_name", fmt.Sprintf("app-%s", rName)),
+					// The domain is left out of the TGW's security_domains.
+					resource.TestCheckResourceAttr("aviatrix_aws_tgw.test_aws_tgw", "security_domains.#", "3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAwsTgwSecurityDomainConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_account" {
+	account_name       = "tfa-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+
+resource "aviatrix_aws_tgw" "test_aws_tgw" {
+	account_name           = aviatrix_account.test_account.account_name
+	aws_side_as_number     = "64512"
+	manage_vpc_attachment  = false
+	manage_security_domain = false
+	region                 = "%s"
+	tgw_name               = "tft-%[1]s"
+
+	security_domains {
+		connected_domains    = [
+			"Default_Domain",
+			"Shared_Service_Domain"
+		]
+		security_domain_name = "Aviatrix_Edge_Domain"
+	}
+	security_domains {
+		connected_domains    = [
+			"Aviatrix_Edge_Domain",
+			"Shared_Service_Domain"
+		]
+		security_domain_name = "Default_Domain"
+	}
+	security_domains {
+		connected_domains    = [
+			"Aviatrix_Edge_Domain",
+			"Default_Domain"
+		]
+		security_domain_name = "Shared_Service_Domain"
+	}
+}
+
+resource "aviatrix_aws_tgw_security_domain" "test" {
+	tgw_name             = aviatrix_aws_tgw.test_aws_tgw.tgw_name
+	security_domain_name = "app-%[1]s"
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_REGION"))
+}
+
+func testAccCheckAwsTgwSecurityDomainExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("security domain Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no security domain ID is set")
+		}
+
+		client := testAccProvider.Meta().(*goaviatrix.Client)
+
+		_, err := client.GetSecurityDomain(&goaviatrix.SecurityDomain{
+			Name:       rs.Primary.Attributes["security_domain_name"],
+			AwsTgwName: rs.Primary.Attributes["tgw_name"],
+		})
+		return err
+	}
+}
+
+func testAccCheckAwsTgwSecurityDomainDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*goaviatrix.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aviatrix_aws_tgw_security_domain" {
+			continue
+		}
+
+		_, err := client.GetSecurityDomain(&goaviatrix.SecurityDomain{
+			Name:       rs.Primary.Attributes["security_domain_name"],
+			AwsTgwName: rs.Primary.Attributes["tgw_name"],
+		})
+		if !errors.Is(err, goaviatrix.ErrNotFound) {
+			return fmt.Errorf("security domain still exists")
+		}
+	}
+
+	return nil
+}
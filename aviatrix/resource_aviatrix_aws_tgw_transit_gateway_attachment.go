@@ -0,0 +1,139 @@
+package aviatrix
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func resourceAviatrixAwsTgwTransitGatewayAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAviatrixAwsTgwTransitGatewayAttachmentCreate,
+		Read:   resourceAviatrixAwsTgwTransitGatewayAttachmentRead,
+		Delete: resourceAviatrixAwsTgwTransitGatewayAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"tgw_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the AWS TGW.",
+			},
+			"transit_gateway_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Aviatrix transit gateway to attach to the AWS TGW.",
+			},
+			"security_domain_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "Aviatrix_Edge_Domain",
+				Description: "Name of the security domain the transit gateway is attached to.",
+			},
+		},
+	}
+}
+
+func resourceAviatrixAwsTgwTransitGatewayAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	awsTgw, err := client.ListTgwDetails(&goaviatrix.AWSTgw{
+		Name: d.Get("tgw_name").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't find AWS TGW %s: %s", d.Get("tgw_name").(string), err)
+	}
+
+	gateway := &goaviatrix.Gateway{
+		GwName: d.Get("transit_gateway_name").(string),
+	}
+	securityDomainName := d.Get("security_domain_name").(string)
+
+	log.Printf("[INFO] Attaching transit gateway %s to security domain %s of AWS TGW %s", gateway.GwName,
+		securityDomainName, awsTgw.Name)
+
+	err = client.AttachAviatrixTransitGWToAWSTgw(awsTgw, gateway, securityDomainName)
+	if err != nil {
+		return fmt.Errorf("failed to attach transit GW: %s", err)
+	}
+
+	d.SetId(awsTgw.Name + "~" + securityDomainName + "~" + gateway.GwName)
+	return resourceAviatrixAwsTgwTransitGatewayAttachmentRead(d, meta)
+}
+
+func resourceAviatrixAwsTgwTransitGatewayAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	tgwName := d.Get("tgw_name").(string)
+	securityDomainName := d.Get("security_domain_name").(string)
+	transitGwName := d.Get("transit_gateway_name").(string)
+
+	if tgwName == "" || transitGwName == "" {
+		id := d.Id()
+		log.Printf("[DEBUG] Looks like an import. Import Id is %s", id)
+
+		parts := strings.Split(id, "~")
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid transit gateway attachment id %q, expected "+
+				"tgw_name~security_domain_name~transit_gateway_name", id)
+		}
+		tgwName, securityDomainName, transitGwName = parts[0], parts[1], parts[2]
+		d.Set("tgw_name", tgwName)
+		d.Set("security_domain_name", securityDomainName)
+		d.Set("transit_gateway_name", transitGwName)
+	}
+
+	err := client.GetAviatrixTransitGWAttachment(&goaviatrix.AWSTgw{Name: tgwName},
+		&goaviatrix.Gateway{GwName: transitGwName}, securityDomainName)
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("couldn't find attachment of transit gateway %s: %s", transitGwName, err)
+	}
+
+	d.SetId(tgwName + "~" + securityDomainName + "~" + transitGwName)
+	return nil
+}
+
+func resourceAviatrixAwsTgwTransitGatewayAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	awsTgw, err := client.ListTgwDetails(&goaviatrix.AWSTgw{
+		Name: d.Get("tgw_name").(string),
+	})
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("couldn't find AWS TGW %s: %s", d.Get("tgw_name").(string), err)
+	}
+
+	gateway := &goaviatrix.Gateway{
+		GwName: d.Get("transit_gateway_name").(string),
+	}
+	securityDomainName := d.Get("security_domain_name").(string)
+
+	log.Printf("[INFO] Detaching transit gateway %s from security domain %s of AWS TGW %s", gateway.GwName,
+		securityDomainName, awsTgw.Name)
+
+	err = client.DetachAviatrixTransitGWFromAWSTgw(awsTgw, gateway, securityDomainName)
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to detach transit GW: %s", err)
+	}
+
+	return nil
+}
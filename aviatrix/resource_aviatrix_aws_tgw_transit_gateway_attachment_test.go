@@ -0,0 +1,140 @@
+package aviatrix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestAviatrixAwsTgwTransitGatewayAttachment_offline(t *testing.T) {
+	testFakeController(t)
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_aws_tgw_transit_gateway_attachment.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsTgwTransitGatewayAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsTgwTransitGatewayAttachmentConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsTgwTransitGatewayAttachmentExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id",
+						fmt.Sprintf("tft-%s~Aviatrix_Edge_Domain~tfg-%s", rName, rName)),
+					resource.TestCheckResourceAttr(resourceName, "security_domain_name", "Aviatrix_Edge_Domain"),
+					// The attachment is left out of the TGW's attached_aviatrix_transit_gateway.
+					resource.TestCheckResourceAttr("aviatrix_aws_tgw.test_aws_tgw",
+						"attached_aviatrix_transit_gateway.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAwsTgwTransitGatewayAttachmentConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_account" {
+	account_name       = "tfa-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+
+resource "aviatrix_transit_gateway" "test_transit_gateway" {
+	cloud_type   = 1
+	account_name = aviatrix_account.test_account.account_name
+	gw_name      = "tfg-%[1]s"
+	vpc_id       = "%[5]s"
+	vpc_reg      = "%[6]s"
+	gw_size      = "t2.micro"
+	subnet       = "%[7]s"
+}
+
+resource "aviatrix_aws_tgw" "test_aws_tgw" {
+	account_name                      = aviatrix_account.test_account.account_name
+	aws_side_as_number                = "64512"
+	manage_vpc_attachment             = false
+	manage_transit_gateway_attachment = false
+	region                            = "%[6]s"
+	tgw_name                          = "tft-%[1]s"
+
+	security_domains {
+		connected_domains    = [
+			"Default_Domain",
+			"Shared_Service_Domain"
+		]
+		security_domain_name = "Aviatrix_Edge_Domain"
+	}
+	security_domains {
+		connected_domains    = [
+			"Aviatrix_Edge_Domain",
+			"Shared_Service_Domain"
+		]
+		security_domain_name = "Default_Domain"
+	}
+	security_domains {
+		connected_domains    = [
+			"Aviatrix_Edge_Domain",
+			"Default_Domain"
+		]
+		security_domain_name = "Shared_Service_Domain"
+	}
+}
+
+resource "aviatrix_aws_tgw_transit_gateway_attachment" "test" {
+	tgw_name             = aviatrix_aws_tgw.test_aws_tgw.tgw_name
+	transit_gateway_name = aviatrix_transit_gateway.test_transit_gateway.gw_name
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET"))
+}
+
+func testAccCheckAwsTgwTransitGatewayAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("transit gateway attachment Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no transit gateway attachment ID is set")
+		}
+
+		client := testAccProvider.Meta().(*goaviatrix.Client)
+
+		return client.GetAviatrixTransitGWAttachment(&goaviatrix.AWSTgw{Name: rs.Primary.Attributes["tgw_name"]},
+			&goaviatrix.Gateway{GwName: rs.Primary.Attributes["transit_gateway_name"]},
+			rs.Primary.Attributes["security_domain_name"])
+	}
+}
+
+func testAccCheckAwsTgwTransitGatewayAttachmentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*goaviatrix.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aviatrix_aws_tgw_transit_gateway_attachment" {
+			continue
+		}
+
+		err := client.GetAviatrixTransitGWAttachment(&goaviatrix.AWSTgw{Name: rs.Primary.Attributes["tgw_name"]},
+			&goaviatrix.Gateway{GwName: rs.Primary.Attributes["transit_gateway_name"]},
+			rs.Primary.Attributes["security_domain_name"])
+		if !errors.Is(err, goaviatrix.ErrNotFound) {
+			return fmt.Errorf("transit gateway attachment still exists")
+		}
+	}
+
+	return nil
+}
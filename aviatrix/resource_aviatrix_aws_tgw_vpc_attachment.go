@@ -13,7 +13,6 @@ func resourceAviatrixAwsTgwVpcAttachment() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAviatrixAwsTgwVpcAttachmentCreate,
 		Read:   resourceAviatrixAwsTgwVpcAttachmentRead,
-		Update: resourceAviatrixAwsTgwVpcAttachmentUpdate,
 		Delete: resourceAviatrixAwsTgwVpcAttachmentDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -23,11 +22,13 @@ func resourceAviatrixAwsTgwVpcAttachment() *schema.Resource {
 			"tgw_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the AWS TGW.",
 			},
 			"region": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Region of cloud provider.",
 			},
 			"security_domain_name": {
@@ -39,11 +40,13 @@ func resourceAviatrixAwsTgwVpcAttachment() *schema.Resource {
 			"vpc_account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "This parameter represents the name of a Cloud-Account in Aviatrix controller.",
 			},
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "This parameter represents the ID of the VPC.",
 			},
 		},
@@ -113,25 +116,6 @@ func resourceAviatrixAwsTgwVpcAttachmentRead(d *schema.ResourceData, meta interf
 	return fmt.Errorf("no Aviatrix Aws Tgw Vpc Attach found")
 }
 
-func resourceAviatrixAwsTgwVpcAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
-	d.Partial(true)
-	if d.HasChange("tgw_name") {
-		return fmt.Errorf("updating tgw_name is not allowed")
-	}
-	if d.HasChange("region") {
-		return fmt.Errorf("updating region is not allowed")
-	}
-	if d.HasChange("vpc_account_name") {
-		return fmt.Errorf("updating vpc_account_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-
-	d.Partial(false)
-	return resourceAviatrixAwsTgwVpcAttachmentRead(d, meta)
-}
-
 func resourceAviatrixAwsTgwVpcAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
@@ -52,6 +52,36 @@ func TestAccAviatrixAwsTgwVpcAttachment_basic(t *testing.T) {
 	})
 }
 
+func TestAviatrixAwsTgwVpcAttachment_offline(t *testing.T) {
+	var awsTgwVpcAttachment goaviatrix.AwsTgwVpcAttachment
+
+	testFakeController(t)
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_aws_tgw_vpc_attachment.test"
+	sDm := "mySdn"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsTgwVpcAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsTgwVpcAttachmentConfigBasic(rName, "64512", sDm),
+				Check: resource.ComposeTestCheckFunc(
+					tesAccCheckAwsTgwVpcAttachmentExists(resourceName, &awsTgwVpcAttachment),
+					resource.TestCheckResourceAttr(resourceName, "tgw_name", fmt.Sprintf("tft-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "security_domain_name", sDm),
+					resource.TestCheckResourceAttr(resourceName, "vpc_id", os.Getenv("AWS_VPC_ID")),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccAwsTgwVpcAttachmentConfigBasic(rName string, awsSideAsNumber string, sDm string) string {
 	return fmt.Sprintf(`
 resource "aviatrix_account" "test_account" {
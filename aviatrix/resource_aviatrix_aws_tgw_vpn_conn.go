@@ -1,6 +1,8 @@
 package aviatrix
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -18,6 +20,9 @@ func resourceAviatrixAwsTgwVpnConn() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"tgw_name": {
@@ -27,10 +32,11 @@ func resourceAviatrixAwsTgwVpnConn() *schema.Resource {
 				Description: "This parameter represents the name of an AWS TGW.",
 			},
 			"route_domain_name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The name of a route domain, to which the vpn will be attached.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringIn("Default_Domain"),
+				Description:  "The name of a route domain, to which the vpn will be attached.",
 			},
 			"connection_name": {
 				Type:        schema.TypeString,
@@ -39,28 +45,34 @@ func resourceAviatrixAwsTgwVpnConn() *schema.Resource {
 				Description: "Unique name of the connection.",
 			},
 			"public_ip": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Public IP address. Example: '40.0.0.0'.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIPAddress,
+				Description:  "Public IP address. Example: '40.0.0.0'.",
 			},
 			"remote_as_number": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "AWS side as a number. Integer between 1-65535. Example: '12'.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"remote_cidr"},
+				ValidateFunc:  validateASN,
+				Description:   "AWS side as a number. Integer between 1-65535. Example: '12'.",
 			},
 			"remote_cidr": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Remote CIDRs joined as a string with ','.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"remote_as_number"},
+				ValidateFunc:  validateCIDRList,
+				Description:   "Remote CIDRs joined as a string with ','.",
 			},
 			"inside_ip_cidr_tun_1": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Inside IP CIDR for Tunnel 1. A /30 CIDR in 169.254.0.0/16.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Inside IP CIDR for Tunnel 1. A /30 CIDR in 169.254.0.0/16.",
 			},
 			"pre_shared_key_tun_1": {
 				Type:      schema.TypeString,
@@ -71,10 +83,11 @@ func resourceAviatrixAwsTgwVpnConn() *schema.Resource {
 					"underscore(_) and dot(.). It cannot start with 0",
 			},
 			"inside_ip_cidr_tun_2": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Inside IP CIDR for Tunnel 2. A /30 CIDR in 169.254.0.0/16.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Inside IP CIDR for Tunnel 2. A /30 CIDR in 169.254.0.0/16.",
 			},
 			"pre_shared_key_tun_2": {
 				Type:      schema.TypeString,
@@ -189,6 +202,10 @@ func resourceAviatrixAwsTgwVpnConnRead(d *schema.ResourceData, meta interface{})
 
 func resourceAviatrixAwsTgwVpnConnDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
+
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
 	awsTgwVpnConn := &goaviatrix.AwsTgwVpnConn{
 		TgwName: d.Get("tgw_name").(string),
 		VpnID:   d.Get("vpn_id").(string),
@@ -196,17 +213,26 @@ func resourceAviatrixAwsTgwVpnConnDelete(d *schema.ResourceData, meta interface{
 
 	log.Printf("[INFO] Deleting Aviatrix aws_tgw_vpn_conn: %#v", awsTgwVpnConn)
 
-	err := client.DeleteAwsTgwVpnConn(awsTgwVpnConn)
-
-	time.Sleep(40 * time.Second)
-
+	err := client.DeleteAwsTgwVpnConnContext(ctx, awsTgwVpnConn)
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
 			return nil
 		}
 
 		return fmt.Errorf("failed to delete Aviatrix AwsTgwVpnConn: %s", err)
 	}
 
+	// AWS tears the VPN connection down asynchronously, wait until it is gone
+	err = client.PollContext(ctx, "aws tgw vpn connection "+awsTgwVpnConn.VpnID+" to be deleted", func() (bool, error) {
+		_, err := client.GetAwsTgwVpnConnContext(ctx, awsTgwVpnConn)
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for deletion of Aviatrix AwsTgwVpnConn: %s", err)
+	}
+
 	return nil
 }
@@ -1,6 +1,7 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -19,6 +20,11 @@ func resourceAviatrixControllerConfig() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"sg_management_account_name": {
@@ -65,24 +71,31 @@ func resourceAviatrixControllerConfigCreate(d *schema.ResourceData, meta interfa
 
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
 	log.Printf("[INFO] Configuring Aviatrix controller : %#v", d)
 
 	httpAccess := d.Get("http_access").(bool)
 	if httpAccess {
-		curStatus, _ := client.GetHttpAccessEnabled()
+		curStatus, _ := client.GetHttpAccessEnabledContext(ctx)
 		if curStatus == "True" {
 			log.Printf("[INFO] Http Access is already enabled")
 		} else {
-			err = client.EnableHttpAccess()
-			time.Sleep(10 * time.Second)
+			err = client.EnableHttpAccessContext(ctx)
+			if err == nil {
+				err = client.WaitForHttpAccessContext(ctx, true)
+			}
 		}
 	} else {
-		curStatus, _ := client.GetHttpAccessEnabled()
+		curStatus, _ := client.GetHttpAccessEnabledContext(ctx)
 		if curStatus == "False" {
 			log.Printf("[INFO] Http Access is already disabled")
 		} else {
-			err = client.DisableHttpAccess()
-			time.Sleep(10 * time.Second)
+			err = client.DisableHttpAccessContext(ctx)
+			if err == nil {
+				err = client.WaitForHttpAccessContext(ctx, false)
+			}
 		}
 	}
 	if err != nil {
@@ -91,18 +104,18 @@ func resourceAviatrixControllerConfigCreate(d *schema.ResourceData, meta interfa
 
 	fqdnExceptionRule := d.Get("fqdn_exception_rule").(bool)
 	if fqdnExceptionRule {
-		curStatus, _ := client.GetExceptionRuleStatus()
+		curStatus, _ := client.GetExceptionRuleStatusContext(ctx)
 		if curStatus {
 			log.Printf("[INFO] FQDN Exception Rule is already enabled")
 		} else {
-			err = client.EnableExceptionRule()
+			err = client.EnableExceptionRuleContext(ctx)
 		}
 	} else {
-		curStatus, _ := client.GetExceptionRuleStatus()
+		curStatus, _ := client.GetExceptionRuleStatusContext(ctx)
 		if !curStatus {
 			log.Printf("[INFO] FQDN Exception Rule is already disabled")
 		} else {
-			err = client.DisableExceptionRule()
+			err = client.DisableExceptionRuleContext(ctx)
 		}
 	}
 	if err != nil {
@@ -111,18 +124,18 @@ func resourceAviatrixControllerConfigCreate(d *schema.ResourceData, meta interfa
 
 	securityGroupManagement := d.Get("security_group_management").(bool)
 	if securityGroupManagement {
-		curStatus, _ := client.GetSecurityGroupManagementStatus()
+		curStatus, _ := client.GetSecurityGroupManagementStatusContext(ctx)
 		if curStatus.State == "Enabled" {
 			log.Printf("[INFO] Security Group Management is already enabled")
 		} else {
-			err = client.EnableSecurityGroupManagement(account)
+			err = client.EnableSecurityGroupManagementContext(ctx, account)
 		}
 	} else {
-		curStatus, _ := client.GetSecurityGroupManagementStatus()
+		curStatus, _ := client.GetSecurityGroupManagementStatusContext(ctx)
 		if curStatus.State == "Disabled" {
 			log.Printf("[INFO] Security Group Management is already disabled")
 		} else {
-			err = client.DisableSecurityGroupManagement()
+			err = client.DisableSecurityGroupManagementContext(ctx)
 		}
 	}
 	if err != nil {
@@ -133,12 +146,15 @@ func resourceAviatrixControllerConfigCreate(d *schema.ResourceData, meta interfa
 		Version: d.Get("target_version").(string),
 	}
 	if version.Version != "" {
-		err := client.Upgrade(version)
+		err := client.UpgradeContext(ctx, version)
 		if err != nil {
 			return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
 		}
 
-		newCurrent, _, _ := client.GetCurrentVersion()
+		newCurrent, err := client.WaitForControllerContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
+		}
 		log.Printf("Upgrade complete (now %s)", newCurrent)
 	}
 
@@ -213,6 +229,10 @@ func resourceAviatrixControllerConfigRead(d *schema.ResourceData, meta interface
 
 func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
+
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
 	account := d.Get("sg_management_account_name").(string)
 
 	log.Printf("[INFO] Updating Controller configuration: %#v", d)
@@ -221,19 +241,23 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 	if d.HasChange("http_access") {
 		httpAccess := d.Get("http_access").(bool)
 		if httpAccess {
-			err := client.EnableHttpAccess()
-			time.Sleep(10 * time.Second)
+			err := client.EnableHttpAccessContext(ctx)
 			if err != nil {
 				log.Printf("[ERROR] Failed to enable http access on controller %s", d.Id())
 				return err
 			}
+			if err := client.WaitForHttpAccessContext(ctx, true); err != nil {
+				return err
+			}
 		} else {
-			err := client.DisableHttpAccess()
-			time.Sleep(10 * time.Second)
+			err := client.DisableHttpAccessContext(ctx)
 			if err != nil {
 				log.Printf("[ERROR] Failed to disable http access on controller %s", d.Id())
 				return err
 			}
+			if err := client.WaitForHttpAccessContext(ctx, false); err != nil {
+				return err
+			}
 		}
 		d.SetPartial("http_access")
 	}
@@ -241,13 +265,13 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 	if d.HasChange("fqdn_exception_rule") {
 		fqdnExceptionRule := d.Get("fqdn_exception_rule").(bool)
 		if fqdnExceptionRule {
-			err := client.EnableExceptionRule()
+			err := client.EnableExceptionRuleContext(ctx)
 			if err != nil {
 				log.Printf("[ERROR] Failed to enable exception rule on controller %s", d.Id())
 				return err
 			}
 		} else {
-			err := client.DisableExceptionRule()
+			err := client.DisableExceptionRuleContext(ctx)
 			if err != nil {
 				log.Printf("[ERROR] Failed to disable exception rule on controller %s", d.Id())
 				return err
@@ -259,13 +283,13 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 	if d.HasChange("security_group_management") {
 		securityGroupManagement := d.Get("security_group_management").(bool)
 		if securityGroupManagement {
-			err := client.EnableSecurityGroupManagement(account)
+			err := client.EnableSecurityGroupManagementContext(ctx, account)
 			if err != nil {
 				log.Printf("[ERROR] Failed to enable Security Group Management on controller %s", d.Id())
 				return err
 			}
 		} else {
-			err := client.DisableSecurityGroupManagement()
+			err := client.DisableSecurityGroupManagementContext(ctx)
 			if err != nil {
 				log.Printf("[ERROR] Failed to disable Security Group Management on controller %s", d.Id())
 				return err
@@ -277,7 +301,7 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 	if d.HasChange("target_version") {
 		curVersion := d.Get("version").(string)
 		cur := strings.Split(curVersion, ".")
-		latestVersion, _ := client.GetLatestVersion()
+		latestVersion, _ := client.GetLatestVersionContext(ctx)
 		latest := strings.Split(latestVersion, ".")
 		version := &goaviatrix.Version{
 			Version: d.Get("target_version").(string),
@@ -288,7 +312,7 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 			if latestVersion != "" {
 				for i := range cur {
 					if cur[i] != latest[i] {
-						err := client.Upgrade(version)
+						err := client.UpgradeContext(ctx, version)
 						if err != nil {
 							return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
 						}
@@ -297,11 +321,16 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 				}
 			}
 		} else {
-			err := client.Upgrade(version)
+			err := client.UpgradeContext(ctx, version)
 			if err != nil {
 				return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
 			}
 		}
+		newCurrent, err := client.WaitForControllerContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
+		}
+		log.Printf("Upgrade complete (now %s)", newCurrent)
 		d.SetPartial("target_version")
 	}
 
@@ -311,21 +340,27 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 
 func resourceAviatrixControllerConfigDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
+
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
 	d.Set("http_access", false)
-	curStatusHttp, _ := client.GetHttpAccessEnabled()
+	curStatusHttp, _ := client.GetHttpAccessEnabledContext(ctx)
 	if curStatusHttp != "Disabled" {
-		err := client.DisableHttpAccess()
-		time.Sleep(10 * time.Second)
+		err := client.DisableHttpAccessContext(ctx)
 		if err != nil {
 			log.Printf("[ERROR] Failed to disable http access on controller %s", d.Id())
 			return err
 		}
+		if err := client.WaitForHttpAccessContext(ctx, false); err != nil {
+			return err
+		}
 	}
 
 	d.Set("fqdn_exception_rule", true)
-	curStatusException, _ := client.GetExceptionRuleStatus()
+	curStatusException, _ := client.GetExceptionRuleStatusContext(ctx)
 	if !curStatusException {
-		err := client.EnableExceptionRule()
+		err := client.EnableExceptionRuleContext(ctx)
 		if err != nil {
 			log.Printf("[ERROR] Failed to enable exception rule on controller %s", d.Id())
 			return err
@@ -333,9 +368,9 @@ func resourceAviatrixControllerConfigDelete(d *schema.ResourceData, meta interfa
 	}
 
 	d.Set("security_group_management", false)
-	curStatusSG, _ := client.GetSecurityGroupManagementStatus()
+	curStatusSG, _ := client.GetSecurityGroupManagementStatusContext(ctx)
 	if curStatusSG.State != "Disabled" {
-		err := client.DisableSecurityGroupManagement()
+		err := client.DisableSecurityGroupManagementContext(ctx)
 		if err != nil {
 			log.Printf("[ERROR] Failed to disable security group management on controller %s", d.Id())
 			return err
@@ -17,6 +17,9 @@ func resourceAviatrixFirewall() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(
+			forEach("policy", validateFirewallPolicy),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"gw_name": {
@@ -25,10 +28,11 @@ func resourceAviatrixFirewall() *schema.Resource {
 				Description: "The name of gateway.",
 			},
 			"base_policy": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "deny-all",
-				Description: "New base policy.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "deny-all",
+				ValidateFunc: validateStringIn("allow-all", "deny-all"),
+				Description:  "New base policy.",
 			},
 			"base_log_enabled": {
 				Type:        schema.TypeBool,
@@ -53,20 +57,23 @@ func resourceAviatrixFirewall() *schema.Resource {
 							Description: "CIDRs separated by comma or tag names such 'HR' or 'marketing' etc.",
 						},
 						"protocol": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "all",
-							Description: "'all', 'tcp', 'udp', 'icmp', 'sctp', 'rdp', 'dccp'.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "all",
+							ValidateFunc: validateStringIn(firewallProtocols...),
+							Description:  "'all', 'tcp', 'udp', 'icmp', 'sctp', 'rdp', 'dccp'.",
 						},
 						"port": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "A single port or a range of port numbers.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validatePortRange,
+							Description:  "A single port or a range of port numbers.",
 						},
 						"action": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Valid values: 'allow' and 'deny'.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringIn("allow", "deny"),
+							Description:  "Valid values: 'allow' and 'deny'.",
 						},
 						"log_enabled": {
 							Type:        schema.TypeBool,
@@ -81,6 +88,17 @@ func resourceAviatrixFirewall() *schema.Resource {
 	}
 }
 
+// validateFirewallPolicy checks a policy of the configuration as the
+// controller would.
+func validateFirewallPolicy(pl map[string]interface{}, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+	return client.ValidatePolicy(&goaviatrix.Policy{
+		Protocol: pl["protocol"].(string),
+		Port:     pl["port"].(string),
+		Action:   pl["action"].(string),
+	})
+}
+
 func resourceAviatrixFirewallCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
@@ -36,9 +36,10 @@ func resourceAviatrixFirewallTag() *schema.Resource {
 							Description: "The name attribute of a policy.",
 						},
 						"cidr": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The CIDR attribute of a policy.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCIDR,
+							Description:  "The CIDR attribute of a policy.",
 						},
 					},
 				},
@@ -17,6 +17,9 @@ func resourceAviatrixFQDN() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(
+			forEach("domain_names", validateFQDNDomainName),
+		),
 
 		SchemaVersion: 1,
 		MigrateState:  resourceAviatrixFQDNMigrateState,
@@ -25,6 +28,7 @@ func resourceAviatrixFQDN() *schema.Resource {
 			"fqdn_tag": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "FQDN Filter Tag Name.",
 			},
 			"fqdn_enabled": {
@@ -33,9 +37,10 @@ func resourceAviatrixFQDN() *schema.Resource {
 				Description: "FQDN Filter Tag Status. Valid values: true or false.",
 			},
 			"fqdn_mode": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Specify the tag color to be a white-list tag or black-list tag. 'white' or 'black'",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringIn("white", "black"),
+				Description:  "Specify the tag color to be a white-list tag or black-list tag. 'white' or 'black'",
 			},
 			"gw_filter_tag_list": {
 				Type:        schema.TypeList,
@@ -69,9 +74,10 @@ func resourceAviatrixFQDN() *schema.Resource {
 							Description: "FQDN.",
 						},
 						"proto": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Protocol.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringIn("all", "tcp", "udp", "icmp"),
+							Description:  "Protocol.",
 						},
 						"port": {
 							Type:        schema.TypeString,
@@ -85,6 +91,18 @@ func resourceAviatrixFQDN() *schema.Resource {
 	}
 }
 
+// validateFQDNDomainName checks the port of a domain name matches its
+// protocol.
+func validateFQDNDomainName(dn map[string]interface{}, meta interface{}) error {
+	switch proto, port := dn["proto"].(string), dn["port"].(string); {
+	case proto == "all" && port != "all":
+		return fmt.Errorf("port must be 'all' for protocol 'all'")
+	case proto == "icmp" && port != "ping":
+		return fmt.Errorf("port must be 'ping' for protocol 'icmp'")
+	}
+	return nil
+}
+
 func resourceAviatrixFQDNCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
@@ -351,9 +369,6 @@ func resourceAviatrixFQDNUpdate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	d.Partial(true)
-	if d.HasChange("fqdn_tag") {
-		return fmt.Errorf("updating fqdn_tag is not allowed")
-	}
 	if d.HasChange("fqdn_enabled") {
 		err := client.UpdateFQDNStatus(fqdn)
 		if err != nil {
@@ -1,6 +1,7 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -19,31 +20,70 @@ func resourceAviatrixGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(
+			forbid(allOf(isSet("saml_enabled"), anyOf(isSet("enable_ldap"), isSet("otp_mode"))),
+				"ldap and mfa can't be configured if saml is enabled"),
+			forbid(allOf(isSet("enable_ldap"), equals("otp_mode", "3")),
+				"ldap can't be configured along with okta authentication"),
+			requireIf(isSet("enable_ldap"), "ldap_server", "ldap server must be set if ldap is enabled"),
+			requireIf(isSet("enable_ldap"), "ldap_bind_dn", "ldap bind dn must be set if ldap is enabled"),
+			requireIf(isSet("enable_ldap"), "ldap_password", "ldap password must be set if ldap is enabled"),
+			requireIf(isSet("enable_ldap"), "ldap_base_dn", "ldap base dn must be set if ldap is enabled"),
+			requireIf(isSet("enable_ldap"), "ldap_username_attribute", "ldap user attribute must be set if ldap is enabled"),
+			requireIf(equals("otp_mode", "2"), "duo_integration_key", "duo integration key required if otp_mode set to 2"),
+			requireIf(equals("otp_mode", "2"), "duo_secret_key", "duo secret key required if otp_mode set to 2"),
+			requireIf(equals("otp_mode", "2"), "duo_api_hostname", "duo api hostname required if otp_mode set to 2"),
+			requireIf(equals("otp_mode", "2"), "duo_push_mode",
+				"duo push mode must be set to a valid value (auto, selective, or token)"),
+			requireIf(equals("otp_mode", "3"), "okta_token", "okta token must be set if otp_mode is set to 3"),
+			requireIf(equals("otp_mode", "3"), "okta_url", "okta url must be set if otp_mode is set to 3"),
+			forbid(allOf(isSet("enable_elb"), not(isSet("vpn_access"))),
+				"can not enable elb without vpn access set to yes"),
+			requireIf(anyOf(isSet("peering_ha_subnet"), isSet("peering_ha_zone")), "peering_ha_gw_size",
+				"A valid non empty peering_ha_gw_size parameter is mandatory for this resource if "+
+					"peering_ha_subnet or peering_ha_zone is set. Example: t2.micro"),
+			forbid(allOf(isSet("tags"), not(equals("cloud_type", 1))),
+				"adding tags only supported for aws, cloud_type must be 1"),
+			planTagsAll,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		SchemaVersion: 1,
+		MigrateState:  resourceAviatrixGatewayMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCloudType(1, 4, 8),
+				Description:  "Type of cloud service provider.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Account name. This account will be used to launch Aviatrix gateway.",
 			},
 			"gw_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Aviatrix gateway unique name.",
 			},
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "ID of legacy VPC/Vnet to be connected.",
 			},
 			"vpc_reg": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Region where this gateway will be launched.",
 			},
 			"gw_size": {
@@ -52,9 +92,11 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "Size of Gateway Instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "A VPC Network address range selected from one of the available network ranges.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "A VPC Network address range selected from one of the available network ranges.",
 			},
 			"enable_snat": {
 				Type:        schema.TypeBool,
@@ -67,24 +109,28 @@ func resourceAviatrixGateway() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
+				ForceNew:    true,
 				Description: "Enable user access through VPN to this container.",
 			},
 			"vpn_cidr": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "VPN CIDR block for the container.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "VPN CIDR block for the container.",
 			},
 			"enable_elb": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
+				ForceNew:    true,
 				Description: "Specify whether to enable ELB or not.",
 			},
 			"elb_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "A name for the ELB that is created.",
 			},
 			"split_tunnel": {
@@ -114,17 +160,19 @@ func resourceAviatrixGateway() *schema.Resource {
 					"when a specific name is not in the destination when Split Tunnel Mode is enabled.",
 			},
 			"additional_cidrs": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDRList,
 				Description: "A list of destination CIDR ranges that will also go through the VPN tunnel " +
 					"when Split Tunnel Mode is enabled.",
 			},
 			"otp_mode": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Two step authentication mode.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateStringIn("", "2", "3"),
+				Description:  "Two step authentication mode.",
 			},
 			"saml_enabled": {
 				Type:        schema.TypeBool,
@@ -171,10 +219,11 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "API hostname for DUO auth mode.",
 			},
 			"duo_push_mode": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Push mode for DUO auth.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateStringIn("", "auto", "selective", "token"),
+				Description:  "Push mode for DUO auth.",
 			},
 			"enable_ldap": {
 				Type:        schema.TypeBool,
@@ -214,10 +263,11 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "LDAP user attribute. Required: Yes if enable_ldap is 'yes'.",
 			},
 			"peering_ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Public Subnet Information while creating Peering HA Gateway, only subnet is accepted. Required to create peering ha gateway if cloud_type = 1 or 8 (aws or arm)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Information while creating Peering HA Gateway, only subnet is accepted. Required to create peering ha gateway if cloud_type = 1 or 8 (aws or arm)",
 			},
 			"peering_ha_zone": {
 				Type:        schema.TypeString,
@@ -247,6 +297,7 @@ func resourceAviatrixGateway() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
+				ForceNew: true,
 				Description: "When value is false, reuse an idle address in Elastic IP pool for this gateway. " +
 					"Otherwise, allocate a new Elastic IP and use it for this gateway.",
 			},
@@ -254,14 +305,21 @@ func resourceAviatrixGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Required when allocate_new_eip is false. It uses specified EIP for this gateway.",
 			},
-			"tag_list": {
-				Type:        schema.TypeList,
+			"tags": {
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				ValidateFunc: validateTags,
+				Description:  "Instance tags of cloud provider.",
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Default:     nil,
-				Description: "Instance tag of cloud provider.",
+				Computed:    true,
+				Description: "Instance tags of cloud provider, including the default tags of the provider.",
 			},
 			"public_ip": {
 				Type:        schema.TypeString,
@@ -293,6 +351,12 @@ func resourceAviatrixGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Instance ID of the backup gateway.",
 			},
+			"allow_replacement": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow Terraform to replace the gateway when an attribute which cannot be updated changes.",
+			},
 		},
 	}
 }
@@ -300,6 +364,9 @@ func resourceAviatrixGateway() *schema.Resource {
 func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
 	gateway := &goaviatrix.Gateway{
 		CloudType:          d.Get("cloud_type").(int),
 		AccountName:        d.Get("account_name").(string),
@@ -456,7 +523,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 
 	log.Printf("[INFO] Creating Aviatrix gateway: %#v", gateway)
 
-	err := client.CreateGateway(gateway)
+	err := client.CreateGatewayContext(ctx, gateway)
 	if err != nil {
 		log.Printf("[INFO] failed to create Aviatrix gateway: %#v", gateway)
 		return fmt.Errorf("failed to create Aviatrix gateway: %s", err)
@@ -467,6 +534,10 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 	flag := false
 	defer resourceAviatrixGatewayReadIfRequired(d, meta, &flag)
 
+	if _, err := client.WaitForGatewayContext(ctx, &goaviatrix.Gateway{GwName: gateway.GwName}); err != nil {
+		return fmt.Errorf("failed to wait for Aviatrix gateway %s: %s", gateway.GwName, err)
+	}
+
 	// single_AZ enabled for Gateway. https://docs.aviatrix.com/HowTos/gateway.html#high-availability
 	if singleAZ {
 		singleAZGateway := &goaviatrix.Gateway{
@@ -476,7 +547,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 
 		log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
 
-		err := client.EnableSingleAZGateway(gateway)
+		err := client.EnableSingleAZGatewayContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to create single AZ GW HA: %s", err)
 		}
@@ -497,7 +568,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 
 		log.Printf("[INFO] Enable peering HA: %#v", peeringHaGateway)
 
-		err := client.EnablePeeringHaGateway(peeringHaGateway)
+		err := client.EnablePeeringHaGatewayContext(ctx, peeringHaGateway)
 		if err != nil {
 			return fmt.Errorf("failed to create peering HA: %s", err)
 		}
@@ -514,7 +585,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 				// controller, test out first. just assuming it has that suffix
 			}
 			peeringHaGateway.GwSize = peeringHaGwSize
-			err := client.UpdateGateway(peeringHaGateway)
+			err := client.UpdateGatewayContext(ctx, peeringHaGateway)
 			log.Printf("[INFO] Resizing Peering Ha Gateway size to: %s,", peeringHaGateway.GwSize)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Peering HA Gateway size: %s", err)
@@ -522,23 +593,19 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	if _, ok := d.GetOk("tag_list"); ok && gateway.CloudType == 1 {
-		tagList := d.Get("tag_list").([]interface{})
-		tagListStr := goaviatrix.ExpandStringList(tagList)
-		tagListStr = goaviatrix.TagListStrColon(tagListStr)
-		gateway.TagList = strings.Join(tagListStr, ",")
+	if tagMap := gatewayTags(client, d.Get("tags")); len(tagMap) != 0 && gateway.CloudType == 1 {
 		tags := &goaviatrix.Tags{
 			CloudType:    1,
 			ResourceType: "gw",
 			ResourceName: d.Get("gw_name").(string),
-			TagList:      gateway.TagList,
+			Tags:         tagMap,
 		}
 
-		err = client.AddTags(tags)
+		err = client.AddTagsContext(ctx, tags)
 		if err != nil {
 			return fmt.Errorf("failed to add tags: %s", err)
 		}
-	} else if ok && gateway.CloudType != 1 {
+	} else if _, ok := d.GetOk("tags"); ok && gateway.CloudType != 1 {
 		return fmt.Errorf("adding tags only supported for aws, cloud_type must be 1")
 	}
 
@@ -547,7 +614,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 			GwName: gateway.GwName,
 		}
 
-		gw1, err := client.GetGateway(gw)
+		gw1, err := client.GetGatewayContext(ctx, gw)
 		if err != nil {
 			return fmt.Errorf("couldn't find Aviatrix Gateway: %s due to %v", gw.GwName, err)
 		}
@@ -570,8 +637,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		sTunnel.SplitTunnel = gateway.SplitTunnel
 		if sTunnel.SplitTunnel == "yes" {
 			if sTunnel.AdditionalCidrs != "" || sTunnel.NameServers != "" || sTunnel.SearchDomains != "" {
-				time.Sleep(10 * time.Second)
-				err = client.ModifySplitTunnel(sTunnel)
+				err = client.ModifySplitTunnelContext(ctx, sTunnel)
 				if err != nil {
 					return fmt.Errorf("failed to modify split tunnel: %s", err)
 				}
@@ -598,6 +664,7 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		id := d.Id()
 		log.Printf("[DEBUG] Looks like an import, no gateway name received. Import Id is %s", id)
 		d.Set("gw_name", id)
+		d.Set("allow_replacement", false)
 		d.SetId(id)
 	}
 
@@ -804,30 +871,8 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		}
 
 		if gw.CloudType == 1 {
-			tags := &goaviatrix.Tags{
-				CloudType:    1,
-				ResourceType: "gw",
-				ResourceName: d.Get("gw_name").(string),
-			}
-
-			tagList, err := client.GetTags(tags)
-			if err != nil {
-				return fmt.Errorf("unable to read tag_list for gateway: %v due to %v", gateway.GwName, err)
-			}
-
-			var tagListStr []string
-			if _, ok := d.GetOk("tag_list"); ok {
-				tagList1 := d.Get("tag_list").([]interface{})
-				tagListStr = goaviatrix.ExpandStringList(tagList1)
-			}
-			if len(goaviatrix.Difference(tagListStr, tagList)) != 0 || len(goaviatrix.Difference(tagList, tagListStr)) != 0 {
-				if err := d.Set("tag_list", tagList); err != nil {
-					log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-				}
-			} else {
-				if err := d.Set("tag_list", tagListStr); err != nil {
-					log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-				}
+			if err := readGatewayTags(client, d); err != nil {
+				return err
 			}
 		}
 
@@ -860,42 +905,13 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 
 func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
+
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
 	log.Printf("[INFO] Updating Aviatrix gateway: %#v", d.Get("gw_name").(string))
 
 	d.Partial(true)
-	if d.HasChange("cloud_type") {
-		return fmt.Errorf("updating cloud_type is not allowed")
-	}
-	if d.HasChange("account_name") {
-		return fmt.Errorf("updating account_name is not allowed")
-	}
-	if d.HasChange("gw_name") {
-		return fmt.Errorf("updating gw_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("vpc_reg") {
-		return fmt.Errorf("updating vpc_reg is not allowed")
-	}
-	if d.HasChange("subnet") {
-		return fmt.Errorf("updating subnet is not allowed")
-	}
-	if d.HasChange("vpn_access") {
-		return fmt.Errorf("updating vpn_access is not allowed")
-	}
-	if d.HasChange("enable_elb") {
-		return fmt.Errorf("updating enable_elb is not allowed")
-	}
-	if d.HasChange("elb_name") {
-		return fmt.Errorf("updating elb_name is not allowed")
-	}
-	if d.HasChange("allocate_new_eip") {
-		return fmt.Errorf("updating allocate_new_eip is not allowed")
-	}
-	if d.HasChange("eip") {
-		return fmt.Errorf("updating eip is not allowed")
-	}
 	if d.HasChange("peering_ha_eip") {
 		o, n := d.GetChange("peering_ha_eip")
 		if o != "" && n != "" {
@@ -926,7 +942,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 	if d.HasChange("gw_size") {
 		old, _ := d.GetChange("gw_size")
 		primaryGwSize = old.(string)
-		err := client.UpdateGateway(gateway)
+		err := client.UpdateGatewayContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix Gateway: %s", err)
 		}
@@ -979,7 +995,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			gw := &goaviatrix.Gateway{
 				GwName: gateway.GwName,
 			}
-			gw1, err := client.GetGateway(gw)
+			gw1, err := client.GetGatewayContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("couldn't find Aviatrix Gateway: %s due to %v", gw.GwName, err)
 			}
@@ -1055,50 +1071,18 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			vpn_gw.LbOrGatewayName = d.Get("gw_name").(string)
 		}
 
-		err := client.SetVpnGatewayAuthentication(vpn_gw)
+		err := client.SetVpnGatewayAuthenticationContext(ctx, vpn_gw)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix VPN Gateway Authentication: %s", err)
 		}
 	}
-	if d.HasChange("tag_list") && gateway.CloudType == 1 {
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-		}
-		o, n := d.GetChange("tag_list")
-		if o == nil {
-			o = new([]interface{})
-		}
-		if n == nil {
-			n = new([]interface{})
-		}
-		os := o.([]interface{})
-		ns := n.([]interface{})
-		oldList := goaviatrix.ExpandStringList(os)
-		newList := goaviatrix.ExpandStringList(ns)
-		oldTagList := goaviatrix.Difference(oldList, newList)
-		newTagList := goaviatrix.Difference(newList, oldList)
-		if len(oldTagList) != 0 || len(newTagList) != 0 {
-			if len(oldTagList) != 0 {
-				oldTagList = goaviatrix.TagListStrColon(oldTagList)
-				tags.TagList = strings.Join(oldTagList, ",")
-				err := client.DeleteTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to delete tags : %s", err)
-				}
-			}
-			if len(newTagList) != 0 {
-				newTagList = goaviatrix.TagListStrColon(newTagList)
-				tags.TagList = strings.Join(newTagList, ",")
-				err := client.AddTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to add tags : %s", err)
-				}
-			}
+	if gateway.CloudType == 1 && (d.HasChange("tags") || d.HasChange("tags_all")) {
+		if err := updateGatewayTags(ctx, client, d); err != nil {
+			return err
 		}
-		d.SetPartial("tag_list")
-	} else if d.HasChange("tag_list") && gateway.CloudType != 1 {
+		d.SetPartial("tags")
+		d.SetPartial("tags_all")
+	} else if d.HasChange("tags") && gateway.CloudType != 1 {
 		return fmt.Errorf("adding tags is only supported for aws, cloud_type must be set to 1")
 	}
 
@@ -1124,7 +1108,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 					GwName: gateway.GwName,
 				}
 
-				gw1, err := client.GetGateway(gw)
+				gw1, err := client.GetGatewayContext(ctx, gw)
 				if err != nil {
 					return fmt.Errorf("couldn't find Aviatrix Gateway: %s due to %v", gw.GwName, err)
 				}
@@ -1137,7 +1121,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 				sTunnel.VpcID = gw1.VpcID
 			}
 
-			err := client.ModifySplitTunnel(sTunnel)
+			err := client.ModifySplitTunnelContext(ctx, sTunnel)
 			if err != nil {
 				return fmt.Errorf("failed to modify split tunnel: %s", err)
 			}
@@ -1160,14 +1144,14 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 		if singleAZGateway.SingleAZ == "enabled" {
 			log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
-			err := client.EnableSingleAZGateway(gateway)
+			err := client.EnableSingleAZGatewayContext(ctx, gateway)
 			if err != nil {
 				return fmt.Errorf("failed to create single AZ GW HA: %s", err)
 			}
 		}
 		if singleAZGateway.SingleAZ == "disabled" {
 			log.Printf("[INFO] Disable Single AZ GW HA: %#v", singleAZGateway)
-			err := client.DisableSingleAZGateway(gateway)
+			err := client.DisableSingleAZGatewayContext(ctx, gateway)
 			if err != nil {
 				return fmt.Errorf("failed to disable single AZ GW HA: %s", err)
 			}
@@ -1187,12 +1171,12 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 
 		if enableNat {
-			err := client.EnableSNat(gw)
+			err := client.EnableSNatContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to enable SNAT: %s", err)
 			}
 		} else {
-			err := client.DisableSNat(gw)
+			err := client.DisableSNatContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to disable SNAT: %s", err)
 			}
@@ -1212,7 +1196,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			_, n := d.GetChange("vpn_cidr")
 			gw.VpnCidr = n.(string)
 
-			err := client.UpdateVpnCidr(gw)
+			err := client.UpdateVpnCidrContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to update vpn cidr: %s", err)
 			}
@@ -1238,7 +1222,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			_, n := d.GetChange("max_vpn_conn")
 			gw.MaxConn = n.(string)
 
-			err := client.UpdateMaxVpnConn(gw)
+			err := client.UpdateMaxVpnConnContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to update max vpn connections: %s", err)
 			}
@@ -1281,23 +1265,23 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			}
 		}
 		if newHaGwEnabled {
-			err := client.EnablePeeringHaGateway(gw)
+			err := client.EnablePeeringHaGatewayContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to enable Aviatrix peering HA gateway: %s", err)
 			}
 		} else if deleteHaGw {
-			err := client.DeleteGateway(peeringHaGateway)
+			err := client.DeleteGatewayContext(ctx, peeringHaGateway)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix peering HA gateway: %s", err)
 			}
 		} else if changeHaGw {
-			err := client.DeleteGateway(peeringHaGateway)
+			err := client.DeleteGatewayContext(ctx, peeringHaGateway)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix peering HA gateway: %s", err)
 			}
 
 			gateway.GwName = d.Get("gw_name").(string)
-			haErr := client.EnablePeeringHaGateway(gw)
+			haErr := client.EnablePeeringHaGatewayContext(ctx, gw)
 			if haErr != nil {
 				return fmt.Errorf("failed to enable Aviatrix peering HA gateway: %s", err)
 			}
@@ -1315,7 +1299,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			// OR
 			// newly configured peering HA gateway is set to be different size than primary gateway
 			// (when peering ha gateway is enabled, it's size is by default the same as primary gateway)
-			_, err := client.GetGateway(peeringHaGateway)
+			_, err := client.GetGatewayContext(ctx, peeringHaGateway)
 			if err != nil {
 				if err == goaviatrix.ErrNotFound {
 					d.Set("peering_ha_gw_size", "")
@@ -1331,7 +1315,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 				return fmt.Errorf("A valid non empty peering_ha_gw_size parameter is mandatory for this resource if " +
 					"peering_ha_subnet or peering_ha_zone is set. Example: t2.micro or us-west1-b respectively")
 			}
-			err = client.UpdateGateway(peeringHaGateway)
+			err = client.UpdateGatewayContext(ctx, peeringHaGateway)
 			log.Printf("[INFO] Updating Peering HA Gateway size to: %s ", peeringHaGateway.GwSize)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Peering HA Gw size: %s", err)
@@ -1348,6 +1332,10 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 
 func resourceAviatrixGatewayDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
+
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -1360,7 +1348,7 @@ func resourceAviatrixGatewayDelete(d *schema.ResourceData, meta interface{}) err
 		//Delete backup gateway first
 		gateway.GwName += "-hagw"
 		log.Printf("[INFO] Deleting Aviatrix Backup Gateway [-hagw]: %#v", gateway)
-		err := client.DeleteGateway(gateway)
+		err := client.DeleteGatewayContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to delete backup [-hgw] gateway: %s", err)
 		}
@@ -1370,7 +1358,7 @@ func resourceAviatrixGatewayDelete(d *schema.ResourceData, meta interface{}) err
 
 	log.Printf("[INFO] Deleting Aviatrix gateway: %#v", gateway)
 
-	err := client.DeleteGateway(gateway)
+	err := client.DeleteGatewayContext(ctx, gateway)
 	if err != nil {
 		return fmt.Errorf("failed to delete Aviatrix Gateway: %s", err)
 	}
@@ -3,6 +3,8 @@ package aviatrix
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -72,7 +74,13 @@ func preGatewayCheckARM(t *testing.T, msgCommon string) (string, string, string,
 func TestAccAviatrixGateway_basic(t *testing.T) {
 	var gateway goaviatrix.Gateway
 
-	rName := fmt.Sprintf("%s", acctest.RandString(5))
+	rec := testAccCassette(t, "SKIP_GATEWAY", "SKIP_AWS_GATEWAY", "SKIP_GCP_GATEWAY", "SKIP_ARM_GATEWAY",
+		"SKIP_AWS_ACCOUNT", "SKIP_GCP_ACCOUNT", "SKIP_ARM_ACCOUNT",
+		"AWS_ACCOUNT_NUMBER", "AWS_ACCESS_KEY", "AWS_SECRET_KEY", "AWS_VPC_ID", "AWS_REGION", "AWS_SUBNET", "AWS_GW_SIZE",
+		"GCP_ID", "GCP_CREDENTIALS_FILEPATH", "GCP_VPC_ID", "GCP_ZONE", "GCP_SUBNET", "GCP_GW_SIZE",
+		"ARM_SUBSCRIPTION_ID", "ARM_DIRECTORY_ID", "ARM_APPLICATION_ID", "ARM_APPLICATION_KEY",
+		"ARM_VNET_ID", "ARM_REGION", "ARM_SUBNET", "ARM_GW_SIZE")
+	rName := rec.Var("rName", acctest.RandString(5))
 	msgCommon := ". Set SKIP_GATEWAY to yes to skip Gateway tests"
 
 	skipGw := os.Getenv("SKIP_GATEWAY")
@@ -202,6 +210,197 @@ func TestAccAviatrixGateway_basic(t *testing.T) {
 
 }
 
+func TestAviatrixGateway_offline(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	testFakeController(t)
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_gateway.test_gw_aws"
+	awsVpcId, awsRegion, awsVpcNet := os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET")
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGatewayConfigBasicAWS(rName, "t2.micro", awsVpcId, awsRegion, awsVpcNet),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "gw_name", fmt.Sprintf("tf-testing-aws-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "gw_size", "t2.micro"),
+					resource.TestCheckResourceAttr(resourceName, "vpc_id", awsVpcId),
+					resource.TestCheckResourceAttr(resourceName, "subnet", awsVpcNet),
+					resource.TestCheckResourceAttr(resourceName, "vpc_reg", awsRegion),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAviatrixGateway_replacement(t *testing.T) {
+	srv := testFakeController(t)
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_gateway.test_gw_aws"
+	awsVpcId, awsRegion := os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION")
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGatewayConfigBasicAWS(rName, "t2.micro", awsVpcId, awsRegion, "10.0.0.0/24"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "allow_replacement", "false"),
+			},
+			{
+				Config:      testAccGatewayConfigBasicAWS(rName, "t2.micro", awsVpcId, awsRegion, "10.0.1.0/24"),
+				ExpectError: regexp.MustCompile(`changing subnet of aviatrix_gateway "tf-testing-aws-` + rName + `" replaces it`),
+			},
+			{
+				Config: testAccGatewayConfigReplacementAWS(rName, awsVpcId, awsRegion, "10.0.1.0/24"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "subnet", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "allow_replacement", "true"),
+				),
+			},
+		},
+	})
+	if got := srv.Calls("connect_container"); got != 2 {
+		t.Errorf("expected the gateway to be created twice, got %d calls", got)
+	}
+}
+
+func testAccGatewayConfigReplacementAWS(rName string, awsVpcId string, awsRegion string, awsVpcNet string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tf-acc-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_gateway" "test_gw_aws" {
+	cloud_type        = 1
+	account_name      = aviatrix_account.test_acc_aws.account_name
+	gw_name           = "tf-testing-aws-%[1]s"
+	vpc_id            = "%[5]s"
+	vpc_reg           = "%[6]s"
+	gw_size           = "t2.micro"
+	subnet            = "%[7]s"
+	allow_replacement = true
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		awsVpcId, awsRegion, awsVpcNet)
+}
+
+func TestAviatrixGateway_defaultTags(t *testing.T) {
+	testFakeController(t)
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_gateway.test_gw_aws"
+	awsVpcId, awsRegion := os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION")
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGatewayConfigDefaultTagsAWS(rName, awsVpcId, awsRegion, "1234", `Owner = "me"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Owner", "me"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.Owner", "me"),
+					testAccCheckGatewayTags(resourceName, map[string]string{"CostCenter": "1234", "Owner": "me"}),
+				),
+			},
+			{
+				Config: testAccGatewayConfigDefaultTagsAWS(rName, awsVpcId, awsRegion, "1234", `Env = "test a:b"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Env", "test a:b"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "3"),
+					testAccCheckGatewayTags(resourceName,
+						map[string]string{"CostCenter": "1234", "Env": "test a:b", "Owner": "team"}),
+				),
+			},
+			{
+				// Changing only the default tags updates the gateway.
+				Config: testAccGatewayConfigDefaultTagsAWS(rName, awsVpcId, awsRegion, "5678", `Env = "test a:b"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.CostCenter", "5678"),
+					testAccCheckGatewayTags(resourceName,
+						map[string]string{"CostCenter": "5678", "Env": "test a:b", "Owner": "team"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccGatewayConfigDefaultTagsAWS(rName string, awsVpcId string, awsRegion string, costCenter string,
+	tags string) string {
+	return fmt.Sprintf(`
+provider "aviatrix" {
+	default_tags {
+		tags = {
+			CostCenter = "%[8]s"
+			Owner      = "team"
+		}
+	}
+}
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tf-acc-aws-%[1]s"
+	cloud_type         = 1
+	aws_account_number = "%[2]s"
+	aws_iam            = false
+	aws_access_key     = "%[3]s"
+	aws_secret_key     = "%[4]s"
+}
+resource "aviatrix_gateway" "test_gw_aws" {
+	cloud_type   = 1
+	account_name = aviatrix_account.test_acc_aws.account_name
+	gw_name      = "tf-testing-aws-%[1]s"
+	vpc_id       = "%[5]s"
+	vpc_reg      = "%[6]s"
+	gw_size      = "t2.micro"
+	subnet       = "10.0.0.0/24"
+	tags         = {
+		%[7]s
+	}
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		awsVpcId, awsRegion, tags, costCenter)
+}
+
+func testAccCheckGatewayTags(n string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("gateway Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*goaviatrix.Client)
+
+		tags, err := client.GetTags(&goaviatrix.Tags{
+			CloudType:    1,
+			ResourceType: "gw",
+			ResourceName: rs.Primary.Attributes["gw_name"],
+		})
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(tags, expected) {
+			return fmt.Errorf("expected tags %v, got %v", expected, tags)
+		}
+		return nil
+	}
+}
+
 func testAccGatewayConfigBasicAWS(rName string, awsGwSize string, awsVpcId string, awsRegion string, awsVpcNet string) string {
 	return fmt.Sprintf(`
 resource "aviatrix_account" "test_acc_aws" {
@@ -0,0 +1,162 @@
+package aviatrix
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func resourceAviatrixResourceTags() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAviatrixResourceTagsCreate,
+		Read:   resourceAviatrixResourceTagsRead,
+		Update: resourceAviatrixResourceTagsUpdate,
+		Delete: resourceAviatrixResourceTagsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cloud_type": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCloudType(1),
+				Description:  "Type of cloud service provider of the tagged resource.",
+			},
+			"resource_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Type of the tagged resource, such as 'gw' for a gateway or 'vpc' for a VPC.",
+			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the tagged resource, such as a gateway name or a VPC ID.",
+			},
+			"tags": {
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Required:     true,
+				ValidateFunc: validateTags,
+				Description:  "Tags managed by this resource. Other tags of the resource are left alone.",
+			},
+		},
+	}
+}
+
+func resourceTagsResource(d *schema.ResourceData) *goaviatrix.Tags {
+	return &goaviatrix.Tags{
+		CloudType:    d.Get("cloud_type").(int),
+		ResourceType: d.Get("resource_type").(string),
+		ResourceName: d.Get("resource_name").(string),
+	}
+}
+
+func resourceAviatrixResourceTagsCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	tags := resourceTagsResource(d)
+	tags.Tags = expandTags(d.Get("tags"))
+
+	log.Printf("[INFO] Adding tags to Aviatrix %s %s: %#v", tags.ResourceType, tags.ResourceName, tags.Tags)
+
+	err := client.AddTags(tags)
+	if err != nil {
+		return fmt.Errorf("failed to add tags to %s %s: %s", tags.ResourceType, tags.ResourceName, err)
+	}
+
+	d.SetId(strconv.Itoa(tags.CloudType) + "~" + tags.ResourceType + "~" + tags.ResourceName)
+	return resourceAviatrixResourceTagsRead(d, meta)
+}
+
+func resourceAviatrixResourceTagsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	resourceName := d.Get("resource_name").(string)
+	if resourceName == "" {
+		id := d.Id()
+		log.Printf("[DEBUG] Looks like an import. Import Id is %s", id)
+
+		parts := strings.SplitN(id, "~", 3)
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid resource tags id %q, expected cloud_type~resource_type~resource_name", id)
+		}
+		cloudType, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return fmt.Errorf("invalid cloud_type %q in resource tags id %q", parts[0], id)
+		}
+		d.Set("cloud_type", cloudType)
+		d.Set("resource_type", parts[1])
+		d.Set("resource_name", parts[2])
+	}
+
+	tags := resourceTagsResource(d)
+	currentTags, err := client.GetTags(tags)
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("couldn't read tags of %s %s: %s", tags.ResourceType, tags.ResourceName, err)
+	}
+
+	if resourceName != "" {
+		// Only the keys set in the configuration are managed by the resource.
+		configured := expandTags(d.Get("tags"))
+		for k := range currentTags {
+			if _, ok := configured[k]; !ok {
+				delete(currentTags, k)
+			}
+		}
+	}
+	if err := d.Set("tags", currentTags); err != nil {
+		log.Printf("[WARN] Error setting tags for (%s): %s", d.Id(), err)
+	}
+
+	d.SetId(strconv.Itoa(tags.CloudType) + "~" + tags.ResourceType + "~" + tags.ResourceName)
+	return nil
+}
+
+func resourceAviatrixResourceTagsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+		err := updateTags(client.Context(), client, resourceTagsResource(d), expandTags(o), expandTags(n))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceAviatrixResourceTagsRead(d, meta)
+}
+
+func resourceAviatrixResourceTagsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+
+	tags := resourceTagsResource(d)
+	tags.Tags = expandTags(d.Get("tags"))
+	if len(tags.Tags) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting tags of Aviatrix %s %s: %#v", tags.ResourceType, tags.ResourceName, tags.Tags)
+
+	err := client.DeleteTags(tags)
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete tags of %s %s: %s", tags.ResourceType, tags.ResourceName, err)
+	}
+
+	return nil
+}
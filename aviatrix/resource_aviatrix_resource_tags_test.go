@@ -0,0 +1,184 @@
+package aviatrix
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestAviatrixResourceTags_offline(t *testing.T) {
+	testFakeController(t)
+	resourceName := "aviatrix_resource_tags.test_tags"
+	vpcID := os.Getenv("AWS_VPC_ID")
+
+	// addTags changes the tags of the VPC behind Terraform's back.
+	addTags := func(tags map[string]string) func() {
+		return func() {
+			client := testAccProvider.Meta().(*goaviatrix.Client)
+			err := client.AddTags(&goaviatrix.Tags{CloudType: 1, ResourceType: "vpc", ResourceName: vpcID, Tags: tags})
+			if err != nil {
+				t.Fatalf("failed to add tags: %s", err)
+			}
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceTagsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTagsConfigBasic(vpcID, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "1~vpc~"+vpcID),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Env", "test"),
+					testAccCheckResourceTags(resourceName, map[string]string{"Env": "test", "Team": "network"}),
+				),
+			},
+			{
+				// The changed value is detected and restored, other tags are left alone.
+				PreConfig: addTags(map[string]string{"Env": "prod", "Owner": "me"}),
+				Config:    testAccResourceTagsConfigBasic(vpcID, "test"),
+				Check: testAccCheckResourceTags(resourceName,
+					map[string]string{"Env": "test", "Owner": "me", "Team": "network"}),
+			},
+			{
+				Config: testAccResourceTagsConfigBasic(vpcID, "dev"),
+				Check: testAccCheckResourceTags(resourceName,
+					map[string]string{"Env": "dev", "Owner": "me", "Team": "network"}),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"tags"},
+			},
+		},
+	})
+}
+
+func TestAviatrixResourceTags_defaultTags(t *testing.T) {
+	testFakeController(t)
+	resourceName := "aviatrix_resource_tags.test_tags"
+	vpcID := os.Getenv("AWS_VPC_ID")
+
+	// The default tags are not added, so destroying the resource leaves no
+	// tags behind.
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceTagsDestroyed(vpcID),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTagsConfigDefaultTags(vpcID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					testAccCheckResourceTags(resourceName, map[string]string{"Env": "test"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceTagsConfigDefaultTags(vpcID string) string {
+	return fmt.Sprintf(`
+provider "aviatrix" {
+	default_tags {
+		tags = {
+			CostCenter = "1234"
+		}
+	}
+}
+resource "aviatrix_resource_tags" "test_tags" {
+	cloud_type    = 1
+	resource_type = "vpc"
+	resource_name = "%s"
+	tags          = {
+		Env = "test"
+	}
+}
+	`, vpcID)
+}
+
+func testAccResourceTagsConfigBasic(vpcID string, env string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_resource_tags" "test_tags" {
+	cloud_type    = 1
+	resource_type = "vpc"
+	resource_name = "%s"
+	tags          = {
+		Env  = "%s"
+		Team = "network"
+	}
+}
+	`, vpcID, env)
+}
+
+func testAccCheckResourceTags(n string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource tags Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*goaviatrix.Client)
+
+		tags, err := client.GetTags(&goaviatrix.Tags{
+			CloudType:    1,
+			ResourceType: rs.Primary.Attributes["resource_type"],
+			ResourceName: rs.Primary.Attributes["resource_name"],
+		})
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(tags, expected) {
+			return fmt.Errorf("expected tags %v, got %v", expected, tags)
+		}
+		return nil
+	}
+}
+
+func testAccCheckResourceTagsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*goaviatrix.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aviatrix_resource_tags" {
+			continue
+		}
+
+		tags, err := client.GetTags(&goaviatrix.Tags{
+			CloudType:    1,
+			ResourceType: rs.Primary.Attributes["resource_type"],
+			ResourceName: rs.Primary.Attributes["resource_name"],
+		})
+		if err != nil {
+			return err
+		}
+		for k := range tags {
+			if _, ok := rs.Primary.Attributes["tags."+k]; ok {
+				return fmt.Errorf("tag %s still exists", k)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckResourceTagsDestroyed(vpcID string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*goaviatrix.Client)
+
+		tags, err := client.GetTags(&goaviatrix.Tags{CloudType: 1, ResourceType: "vpc", ResourceName: vpcID})
+		if err != nil {
+			return err
+		}
+		if len(tags) != 0 {
+			return fmt.Errorf("expected no tags left on %s, got %v", vpcID, tags)
+		}
+		return nil
+	}
+}
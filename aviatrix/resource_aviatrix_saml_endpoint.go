@@ -25,10 +25,11 @@ func resourceAviatrixSamlEndpoint() *schema.Resource {
 				Description: "SAML Endpoint Name.",
 			},
 			"idp_metadata_type": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Type of IDP Metadata.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringIn("Text"),
+				Description:  "Type of IDP Metadata.",
 			},
 			"idp_metadata": {
 				Type:        schema.TypeString,
@@ -3,6 +3,7 @@ package aviatrix
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -68,6 +69,20 @@ func TestAccAviatrixSamlEndpoint_basic(t *testing.T) {
 	})
 }
 
+func TestAviatrixSamlEndpoint_capability(t *testing.T) {
+	testFakeController(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSamlEndpointConfigBasic(acctest.RandString(5), "metadata", "Text"),
+				ExpectError: regexp.MustCompile(`aviatrix_saml_endpoint requires controller version UserConnect-4\.7\.585 or later, controller runs UserConnect-4\.7\.520`),
+			},
+		},
+	})
+}
+
 func testAccSamlEndpointConfigBasic(rName string, idpMetadata string, idpMetadataType string) string {
 	return fmt.Sprintf(`
 resource "aviatrix_saml_endpoint" "foo" {
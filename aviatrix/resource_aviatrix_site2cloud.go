@@ -18,6 +18,39 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(
+			forbid(allOf(equals("connection_type", "mapped"),
+				anyOf(not(isSet("remote_subnet_virtual")), not(isSet("local_subnet_virtual")))),
+				"'remote_subnet_virtual' and 'local_subnet_virtual' are both required for connection type: mapped"),
+			forbid(allOf(equals("connection_type", "unmapped"),
+				anyOf(isSet("remote_subnet_virtual"), isSet("local_subnet_virtual"))),
+				"'remote_subnet_virtual' and 'local_subnet_virtual' both should be empty for connection type: ummapped"),
+			forbid(allOf(equals("tunnel_type", "tcp"), isSet("custom_algorithms")),
+				"custom_algorithms is not supported for tunnel type 'tcp'"),
+			forbid(allOf(not(isSet("custom_algorithms")), isSet("phase_1_authentication")),
+				"custom_algorithms is disabled, phase_1_authentication should be empty"),
+			forbid(allOf(not(isSet("custom_algorithms")), isSet("phase_1_dh_groups")),
+				"custom_algorithms is disabled, phase_1_dh_groups should be empty"),
+			forbid(allOf(not(isSet("custom_algorithms")), isSet("phase_1_encryption")),
+				"custom_algorithms is disabled, phase_1_encryption should be empty"),
+			forbid(allOf(not(isSet("custom_algorithms")), isSet("phase_2_authentication")),
+				"custom_algorithms is disabled, phase_2_authentication should be empty"),
+			forbid(allOf(not(isSet("custom_algorithms")), isSet("phase_2_dh_groups")),
+				"custom_algorithms is disabled, phase_2_dh_groups should be empty"),
+			forbid(allOf(not(isSet("custom_algorithms")), isSet("phase_2_encryption")),
+				"custom_algorithms is disabled, phase_2_encryption should be empty"),
+			validateSite2CloudAlgorithms,
+			forbid(allOf(isSet("private_route_encryption"), not(isSet("route_table_list"))),
+				"private_route_encryption is enabled, route_table_list cannot be empty"),
+			forbid(allOf(not(isSet("private_route_encryption")), isSet("route_table_list")),
+				"private route encryption is disabled, route_table_list should be empty"),
+			forbid(allOf(equals("tunnel_type", "udp"), isSet("ssl_server_pool")),
+				"ssl_server_pool only supports tunnel type 'tcp'"),
+			forbid(allOf(equals("tunnel_type", "tcp"), not(equals("remote_gateway_type", "avx"))),
+				"only 'avx' remote gateway type is supported for tunnel type 'tcp'"),
+			forbid(allOf(equals("tunnel_type", "tcp"), equals("ssl_server_pool", goaviatrix.SslServerPoolDefault)),
+				"'192.168.44.0/24' is default, please specify a different value for ssl_server_pool"),
+		),
 
 		SchemaVersion: 1,
 		MigrateState:  resourceAviatrixSite2CloudMigrateState,
@@ -26,71 +59,87 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "VPC Id of the cloud gateway.",
 			},
 			"connection_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Site2Cloud Connection Name.",
 			},
 			"remote_gateway_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringIn("generic", "avx", "aws", "azure", "sonicwall", "oracle"),
 				Description: "Remote gateway type. Valid values: 'generic', 'avx', 'aws', 'azure', 'sonicwall', " +
 					"and 'oracle'.",
 			},
 			"connection_type": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Connection Type. Valid values: 'mapped' and 'unmapped'.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringIn("mapped", "unmapped"),
+				Description:  "Connection Type. Valid values: 'mapped' and 'unmapped'.",
 			},
 			"tunnel_type": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Site2Cloud Tunnel Type. Valid values: 'udp' and 'tcp'",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringIn("udp", "tcp"),
+				Description:  "Site2Cloud Tunnel Type. Valid values: 'udp' and 'tcp'",
 			},
 			"primary_cloud_gateway_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Primary Cloud Gateway Name.",
 			},
 			"remote_gateway_ip": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Remote Gateway IP.",
 			},
 			"remote_subnet_cidr": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Remote Subnet CIDR.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Remote Subnet CIDR.",
 			},
 			"backup_gateway_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "Backup gateway name.",
 			},
 			"pre_shared_key": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
+				ForceNew:    true,
 				Description: "Pre-Shared Key.",
 			},
 			"local_subnet_cidr": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "Local Subnet CIDR.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Local Subnet CIDR.",
 			},
 			"ha_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
+				ForceNew:    true,
 				Description: "Specify whether enabling HA or not.",
 			},
 			"backup_remote_subnet_cidr": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Backup remote subnet CIDR.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Backup remote subnet CIDR.",
 			},
 			"backup_remote_gateway_name": {
 				Type:        schema.TypeString,
@@ -100,69 +149,84 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 			"backup_remote_gateway_ip": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "Backup remote remote gateway IP.",
 			},
 			"backup_pre_shared_key": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
+				ForceNew:    true,
 				Description: "Backup Pre-Shared Key.",
 			},
 			"remote_subnet_virtual": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Remote Subnet CIDR (Virtual).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Remote Subnet CIDR (Virtual).",
 			},
 			"local_subnet_virtual": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Local Subnet CIDR (Virtual).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Local Subnet CIDR (Virtual).",
 			},
 			"custom_algorithms": {
 				Type:        schema.TypeBool,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "Switch to enable custom/non-default algorithms for IPSec Authentication/Encryption.",
 			},
 			"phase_1_authentication": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "Phase one Authentication. Valid values: 'SHA-1', 'SHA-256', 'SHA-384' and 'SHA-512'.",
 			},
 			"phase_2_authentication": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 				Description: "Phase two Authentication. Valid values: 'NO-AUTH', 'HMAC-SHA-1', 'HMAC-SHA-256', " +
 					"'HMAC-SHA-384' and 'HMAC-SHA-512'.",
 			},
 			"phase_1_dh_groups": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "Phase one DH Groups. Valid values: '1', '2', '5', '14', '15', '16', '17' and '18'.",
 			},
 			"phase_2_dh_groups": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "Phase two DH Groups. Valid values: '1', '2', '5', '14', '15', '16', '17' and '18'.",
 			},
 			"phase_1_encryption": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 				Description: "Phase one Encryption. Valid values: '3DES', 'AES-128-CBC', 'AES-192-CBC' and " +
 					"'AES-256-CBC'.",
 			},
 			"phase_2_encryption": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 				Description: "Phase two Encryption. Valid values: '3DES', 'AES-128-CBC', 'AES-192-CBC', " +
 					"'AES-256-CBC', 'AES-128-GCM-64', 'AES-128-GCM-96' and 'AES-128-GCM-128'.",
 			},
 			"private_route_encryption": {
 				Type:        schema.TypeBool,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "Private route encryption switch.",
 			},
 			"route_table_list": {
 				Type:        schema.TypeList,
+				ForceNew:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "Route tables to modify.",
@@ -188,9 +252,11 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Description: "Longitude of backup remote gateway.",
 			},
 			"ssl_server_pool": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Specify ssl_server_pool for tunnel_type 'tcp'. Default value is '192.168.44.0/24'",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Specify ssl_server_pool for tunnel_type 'tcp'. Default value is '192.168.44.0/24'",
 			},
 			"enable_dead_peer_detection": {
 				Type:        schema.TypeBool,
@@ -198,10 +264,54 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Default:     true,
 				Description: "Switch to Enable/Disable Deed Peer Detection for an existing site2cloud connection.",
 			},
+			"allow_replacement": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow Terraform to replace the connection when an attribute which cannot be updated changes.",
+			},
 		},
 	}
 }
 
+// validateSite2CloudAlgorithms checks the custom algorithms of the
+// configuration as the controller would.
+func validateSite2CloudAlgorithms(d *schema.ResourceDiff, meta interface{}) error {
+	keys := []string{"custom_algorithms", "phase_1_authentication", "phase_1_dh_groups", "phase_1_encryption",
+		"phase_2_authentication", "phase_2_dh_groups", "phase_2_encryption"}
+	for _, k := range keys {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+	if !d.Get("custom_algorithms").(bool) {
+		return nil
+	}
+
+	s2c := &goaviatrix.Site2Cloud{
+		Phase1Auth:       d.Get("phase_1_authentication").(string),
+		Phase1DhGroups:   d.Get("phase_1_dh_groups").(string),
+		Phase1Encryption: d.Get("phase_1_encryption").(string),
+		Phase2Auth:       d.Get("phase_2_authentication").(string),
+		Phase2DhGroups:   d.Get("phase_2_dh_groups").(string),
+		Phase2Encryption: d.Get("phase_2_encryption").(string),
+	}
+	if s2c.Phase1Auth == goaviatrix.Phase1AuthDefault &&
+		s2c.Phase2Auth == goaviatrix.Phase2AuthDefault &&
+		s2c.Phase1DhGroups == goaviatrix.Phase1DhGroupDefault &&
+		s2c.Phase2DhGroups == goaviatrix.Phase2DhGroupDefault &&
+		s2c.Phase1Encryption == goaviatrix.Phase1EncryptionDefault &&
+		s2c.Phase2Encryption == goaviatrix.Phase2EncryptionDefault {
+		return fmt.Errorf("custom_algorithms is enabled, cannot use default values for " +
+			"all six algorithm parameters")
+	}
+	client := meta.(*goaviatrix.Client)
+	if err := client.Site2CloudAlgorithmCheck(s2c); err != nil {
+		return fmt.Errorf("algorithm values check failed: %s", err)
+	}
+	return nil
+}
+
 func resourceAviatrixSite2CloudCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
@@ -383,6 +493,7 @@ func resourceAviatrixSite2CloudRead(d *schema.ResourceData, meta interface{}) er
 		log.Printf("[DEBUG] Looks like an import, no tunnel name or vpc id names received. Import Id is %s", id)
 		d.Set("connection_name", strings.Split(id, "~")[0])
 		d.Set("vpc_id", strings.Split(id, "~")[1])
+		d.Set("allow_replacement", false)
 		d.SetId(id)
 	}
 
@@ -471,79 +582,6 @@ func resourceAviatrixSite2CloudUpdate(d *schema.ResourceData, meta interface{})
 
 	d.Partial(true)
 
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("connection_name") {
-		return fmt.Errorf("updating connection_name is not allowed")
-	}
-	if d.HasChange("remote_gateway_type") {
-		return fmt.Errorf("updating remote_gateway_type is not allowed")
-	}
-	if d.HasChange("connection_type") {
-		return fmt.Errorf("updating connection_type is not allowed")
-	}
-	if d.HasChange("tunnel_type") {
-		return fmt.Errorf("updating tunnel_type is not allowed")
-	}
-	if d.HasChange("primary_cloud_gateway_name") {
-		return fmt.Errorf("updating primary_cloud_gateway_name is not allowed")
-	}
-	if d.HasChange("backup_gateway_name") {
-		return fmt.Errorf("updating backup_gateway_name is not allowed")
-	}
-	if d.HasChange("pre_shared_key") {
-		return fmt.Errorf("updating pre_shared_key is not allowed")
-	}
-	if d.HasChange("remote_gateway_ip") {
-		return fmt.Errorf("updating remote_gateway_ip is not allowed")
-	}
-	if d.HasChange("ha_enabled") {
-		return fmt.Errorf("updating ha_enabled is not allowed")
-	}
-	if d.HasChange("backup_remote_gateway_ip") {
-		return fmt.Errorf("updating backup_remote_gateway_ip is not allowed")
-	}
-	if d.HasChange("backup_pre_shared_key") {
-		return fmt.Errorf("updating backup_pre_shared_key is not allowed")
-	}
-	if d.HasChange("remote_subnet_virtual") {
-		return fmt.Errorf("updating remote_subnet_virtual is not allowed")
-	}
-	if d.HasChange("local_subnet_virtual") {
-		return fmt.Errorf("updating local_subnet_virtual is not allowed")
-	}
-	if d.HasChange("custom_algorithms") {
-		return fmt.Errorf("updating custom_algorithms is not allowed")
-	}
-	if d.HasChange("phase_1_authentication") {
-		return fmt.Errorf("updating backup_remote_gateway_longitude is not allowed")
-	}
-	if d.HasChange("phase_2_authentication") {
-		return fmt.Errorf("updating phase_2_authentication is not allowed")
-	}
-	if d.HasChange("phase_1_dh_groups") {
-		return fmt.Errorf("updating phase_1_dh_groups is not allowed")
-	}
-	if d.HasChange("phase_2_dh_groups") {
-		return fmt.Errorf("updating phase_2_dh_groups is not allowed")
-	}
-	if d.HasChange("phase_1_encryption") {
-		return fmt.Errorf("updating phase_1_encryption is not allowed")
-	}
-	if d.HasChange("phase_2_encryption") {
-		return fmt.Errorf("updating phase_2_encryption is not allowed")
-	}
-	if d.HasChange("private_route_encryption") {
-		return fmt.Errorf("updating private_route_encryption is not allowed")
-	}
-	if d.HasChange("route_table_list") {
-		return fmt.Errorf("updating route_table_list is not allowed")
-	}
-	if d.HasChange("ssl_server_pool") {
-		return fmt.Errorf("updating ssl_server_pool is not allowed")
-	}
-
 	log.Printf("[INFO] Updating Aviatrix Site2Cloud: %#v", editSite2cloud)
 
 	if ok := d.HasChange("local_subnet_cidr"); ok {
@@ -52,6 +52,38 @@ func TestAccAviatrixS2C_basic(t *testing.T) {
 	})
 }
 
+func TestAviatrixS2C_offline(t *testing.T) {
+	var s2c goaviatrix.Site2Cloud
+
+	testFakeController(t)
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_site2cloud.foo"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckS2CDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccS2CConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS2CExists(resourceName, &s2c),
+					resource.TestCheckResourceAttr(resourceName, "connection_name", fmt.Sprintf("tfs-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "vpc_id", os.Getenv("AWS_VPC_ID")),
+					resource.TestCheckResourceAttr(resourceName, "tunnel_type", "udp"),
+					resource.TestCheckResourceAttr(resourceName, "primary_cloud_gateway_name", fmt.Sprintf("tfg-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "remote_subnet_cidr", "10.23.0.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "connection_type", "unmapped"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccS2CConfigBasic(rName string) string {
 	return fmt.Sprintf(`
 resource "aviatrix_account" "test" {
@@ -1,9 +1,11 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -18,31 +20,45 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(spokeGatewayDiffRules...),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		SchemaVersion: 1,
+		MigrateState:  resourceAviatrixSpokeGatewayMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCloudType(1, 4, 8),
+				Description:  "Type of cloud service provider.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "This parameter represents the name of a Cloud-Account in Aviatrix controller.",
 			},
 			"gw_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the gateway which is going to be created.",
 			},
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "VPC-ID/VNet-Name of cloud provider.",
 			},
 			"vpc_reg": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Region of cloud provider.",
 			},
 			"gw_size": {
@@ -51,9 +67,11 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Description: "Size of the gateway instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Public Subnet Info.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Info.",
 			},
 			"enable_snat": {
 				Type:        schema.TypeBool,
@@ -75,10 +93,11 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Description: "Required when allocate_new_eip is false. It uses specified EIP for this gateway.",
 			},
 			"ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "HA Subnet. Required if enabling HA for AWS/ARM.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "HA Subnet. Required if enabling HA for AWS/ARM.",
 			},
 			"ha_zone": {
 				Type:        schema.TypeString,
@@ -110,25 +129,50 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Default:     "",
 				Description: "Specify the transit Gateway.",
 			},
-			"tag_list": {
-				Type:        schema.TypeList,
+			"tags": {
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				ValidateFunc: validateTags,
+				Description:  "Instance tags of cloud provider.",
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Default:     nil,
-				Description: "Instance tag of cloud provider.",
+				Computed:    true,
+				Description: "Instance tags of cloud provider, including the default tags of the provider.",
 			},
 			"cloud_instance_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Cloud instance ID.",
 			},
+			"allow_replacement": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow Terraform to replace the gateway when an attribute which cannot be updated changes.",
+			},
 		},
 	}
 }
 
+// spokeGatewayDiffRules are the rules on the attributes of spoke gateways.
+var spokeGatewayDiffRules = []diffRule{
+	requireIf(anyOf(isSet("ha_subnet"), isSet("ha_zone")), "ha_gw_size",
+		"A valid non empty ha_gw_size parameter is mandatory for this resource if ha_subnet or ha_zone is set. "+
+			"Example: t2.micro"),
+	forbid(allOf(isSet("tags"), not(equals("cloud_type", 1))),
+		"adding tags only supported for aws, cloud_type must be 1"),
+	planTagsAll,
+}
+
 func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
 	gateway := &goaviatrix.SpokeVpc{
 		CloudType:      d.Get("cloud_type").(int),
 		AccountName:    d.Get("account_name").(string),
@@ -188,7 +232,7 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 
 	log.Printf("[INFO] Creating Aviatrix Spoke Gateway: %#v", gateway)
 
-	err := client.LaunchSpokeVpc(gateway)
+	err := client.LaunchSpokeVpcContext(ctx, gateway)
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix Spoke Gateway: %s", err)
 	}
@@ -198,6 +242,10 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 	flag := false
 	defer resourceAviatrixSpokeGatewayReadIfRequired(d, meta, &flag)
 
+	if _, err := client.WaitForGatewayContext(ctx, &goaviatrix.Gateway{GwName: gateway.GwName}); err != nil {
+		return fmt.Errorf("failed to wait for Aviatrix Spoke Gateway %s: %s", gateway.GwName, err)
+	}
+
 	if singleAZ {
 		singleAZGateway := &goaviatrix.Gateway{
 			GwName:   d.Get("gw_name").(string),
@@ -206,7 +254,7 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 
 		log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
 
-		err := client.EnableSingleAZGateway(singleAZGateway)
+		err := client.EnableSingleAZGatewayContext(ctx, singleAZGateway)
 		if err != nil {
 			return fmt.Errorf("failed to create single AZ GW HA: %s", err)
 		}
@@ -223,7 +271,7 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 
 		haGateway.Eip = d.Get("ha_eip").(string)
 
-		err = client.EnableHaSpokeVpc(haGateway)
+		err = client.EnableHaSpokeVpcContext(ctx, haGateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable HA Aviatrix SpokeGateway: %s", err)
 		}
@@ -245,7 +293,7 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 
 			log.Printf("[INFO] Resizing Spoke HA Gateway size to: %s ", haGateway.GwSize)
 
-			err := client.UpdateGateway(haGateway)
+			err := client.UpdateGatewayContext(ctx, haGateway)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Spoke HA Gateway size: %s", err)
 			}
@@ -254,28 +302,24 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	if _, ok := d.GetOk("tag_list"); ok && gateway.CloudType == 1 {
-		tagList := d.Get("tag_list").([]interface{})
-		tagListStr := goaviatrix.ExpandStringList(tagList)
-		tagListStr = goaviatrix.TagListStrColon(tagListStr)
-		gateway.TagList = strings.Join(tagListStr, ",")
+	if tagMap := gatewayTags(client, d.Get("tags")); len(tagMap) != 0 && gateway.CloudType == 1 {
 		tags := &goaviatrix.Tags{
 			CloudType:    1,
 			ResourceType: "gw",
 			ResourceName: d.Get("gw_name").(string),
-			TagList:      gateway.TagList,
+			Tags:         tagMap,
 		}
-		err = client.AddTags(tags)
+		err = client.AddTagsContext(ctx, tags)
 		if err != nil {
 			return fmt.Errorf("failed to add tags: %s", err)
 		}
-	} else if ok && gateway.CloudType != 1 {
+	} else if _, ok := d.GetOk("tags"); ok && gateway.CloudType != 1 {
 		return fmt.Errorf("adding tags only supported for aws, cloud_type must be 1")
 	}
 
 	if transitGwName := d.Get("transit_gw").(string); transitGwName != "" {
 		//No HA config, just return
-		err := client.SpokeJoinTransit(gateway)
+		err := client.SpokeJoinTransitContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to join TransitGateway: %s", err)
 		}
@@ -300,6 +344,7 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 		id := d.Id()
 		log.Printf("[DEBUG] Looks like an import, no gateway name received. Import Id is %s", id)
 		d.Set("gw_name", id)
+		d.Set("allow_replacement", false)
 		d.SetId(id)
 	}
 
@@ -369,28 +414,8 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 	}
 
 	if gw.CloudType == 1 {
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-		}
-		tagList, err := client.GetTags(tags)
-		if err != nil {
-			return fmt.Errorf("unable to read tag_list for gateway: %v due to %v", gateway.GwName, err)
-		}
-		var tagListStr []string
-		if _, ok := d.GetOk("tag_list"); ok {
-			tagList1 := d.Get("tag_list").([]interface{})
-			tagListStr = goaviatrix.ExpandStringList(tagList1)
-		}
-		if len(goaviatrix.Difference(tagListStr, tagList)) != 0 || len(goaviatrix.Difference(tagList, tagListStr)) != 0 {
-			if err := d.Set("tag_list", tagList); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
-		} else {
-			if err := d.Set("tag_list", tagListStr); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
+		if err := readGatewayTags(client, d); err != nil {
+			return err
 		}
 	}
 
@@ -429,6 +454,9 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -443,24 +471,6 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 
 	d.Partial(true)
 
-	if d.HasChange("cloud_type") {
-		return fmt.Errorf("updating cloud_type is not allowed")
-	}
-	if d.HasChange("account_name") {
-		return fmt.Errorf("updating account_name is not allowed")
-	}
-	if d.HasChange("gw_name") {
-		return fmt.Errorf("updating gw_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("vpc_reg") {
-		return fmt.Errorf("updating vpc_reg is not allowed")
-	}
-	if d.HasChange("subnet") {
-		return fmt.Errorf("updating subnet is not allowed")
-	}
 	if d.HasChange("single_az_ha") {
 		singleAZGateway := &goaviatrix.Gateway{
 			GwName: d.Get("gw_name").(string),
@@ -477,60 +487,27 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		if singleAZGateway.SingleAZ == "enabled" {
 			log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
 
-			err := client.EnableSingleAZGateway(singleAZGateway)
+			err := client.EnableSingleAZGatewayContext(ctx, singleAZGateway)
 			if err != nil {
 				return fmt.Errorf("failed to enable single AZ GW HA: %s", err)
 			}
 		} else {
 			log.Printf("[INFO] Disable Single AZ GW HA: %#v", singleAZGateway)
 
-			err := client.DisableSingleAZGateway(singleAZGateway)
+			err := client.DisableSingleAZGatewayContext(ctx, singleAZGateway)
 			if err != nil {
 				return fmt.Errorf("failed to disable single AZ GW HA: %s", err)
 			}
 		}
 	}
 
-	if d.HasChange("tag_list") && gateway.CloudType == 1 {
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-		}
-		o, n := d.GetChange("tag_list")
-		if o == nil {
-			o = new([]interface{})
-		}
-		if n == nil {
-			n = new([]interface{})
-		}
-		os := o.([]interface{})
-		ns := n.([]interface{})
-		oldList := goaviatrix.ExpandStringList(os)
-		newList := goaviatrix.ExpandStringList(ns)
-		oldTagList := goaviatrix.Difference(oldList, newList)
-		newTagList := goaviatrix.Difference(newList, oldList)
-		if len(oldTagList) != 0 || len(newTagList) != 0 {
-			if len(oldTagList) != 0 {
-				oldTagList = goaviatrix.TagListStrColon(oldTagList)
-				tags.TagList = strings.Join(oldTagList, ",")
-				err := client.DeleteTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to delete tags : %s", err)
-				}
-			}
-			if len(newTagList) != 0 {
-				newTagList = goaviatrix.TagListStrColon(newTagList)
-				tags.TagList = strings.Join(newTagList, ",")
-				err := client.AddTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to add tags : %s", err)
-				}
-			}
+	if gateway.CloudType == 1 && (d.HasChange("tags") || d.HasChange("tags_all")) {
+		if err := updateGatewayTags(ctx, client, d); err != nil {
+			return err
 		}
-
-		d.SetPartial("tag_list")
-	} else if d.HasChange("tag_list") && gateway.CloudType != 1 {
+		d.SetPartial("tags")
+		d.SetPartial("tags_all")
+	} else if d.HasChange("tags") && gateway.CloudType != 1 {
 		return fmt.Errorf("adding tags is only supported for aws, cloud_type must be set to 1")
 	}
 
@@ -540,7 +517,7 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		old, _ := d.GetChange("gw_size")
 		primaryGwSize = old.(string)
 		gateway.GwSize = d.Get("gw_size").(string)
-		err := client.UpdateGateway(gateway)
+		err := client.UpdateGatewayContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix SpokeGateway: %s", err)
 		}
@@ -583,28 +560,28 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 		if newHaGwEnabled {
 			//New configuration to enable HA
-			err := client.EnableHaSpokeVpc(spokeGw)
+			err := client.EnableHaSpokeVpcContext(ctx, spokeGw)
 			if err != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix SpokeGateway: %s", err)
 			}
 			newHaGwEnabled = true
 		} else if deleteHaGw {
 			//Ha configuration has been deleted
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayContext(ctx, haGateway)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix SpokeGateway HA gateway: %s", err)
 			}
 		} else if changeHaGw {
 			//HA subnet has been modified. Delete older HA GW,
 			// and launch new HA GW in new subnet.
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayContext(ctx, haGateway)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix SpokeGateway HA gateway: %s", err)
 			}
 
 			gateway.GwName = d.Get("spokeGw_name").(string)
 			//New configuration to enable HA
-			haErr := client.EnableHaSpokeVpc(spokeGw)
+			haErr := client.EnableHaSpokeVpcContext(ctx, spokeGw)
 			if haErr != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix SpokeGateway: %s", err)
 			}
@@ -621,7 +598,7 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 			// OR
 			// newly configured Ha gateway is set to be different size than primary gateway
 			// (when ha gateway is enabled, it's size is by default the same as primary gateway)
-			_, err := client.GetGateway(haGateway)
+			_, err := client.GetGatewayContext(ctx, haGateway)
 			if err != nil {
 				if err == goaviatrix.ErrNotFound {
 					d.Set("ha_gw_size", "")
@@ -636,7 +613,7 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 				return fmt.Errorf("A valid non empty ha_gw_size parameter is mandatory for this resource if " +
 					"ha_subnet or ha_zone is set. Example: t2.micro or us-west1-b")
 			}
-			err = client.UpdateGateway(haGateway)
+			err = client.UpdateGatewayContext(ctx, haGateway)
 			log.Printf("[INFO] Updating HA Gateway size to: %s ", haGateway.GwSize)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Spoke HA Gw size: %s", err)
@@ -654,12 +631,12 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		enableNat := d.Get("enable_snat").(bool)
 
 		if enableNat {
-			err := client.EnableSNat(gw)
+			err := client.EnableSNatContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to enable SNAT: %s", err)
 			}
 		} else {
-			err := client.DisableSNat(gw)
+			err := client.DisableSNatContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to disable SNAT: %s", err)
 			}
@@ -679,24 +656,24 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		o, n := d.GetChange("transit_gw")
 		if o == "" {
 			//New configuration to join to transit GW
-			err := client.SpokeJoinTransit(spokeVPC)
+			err := client.SpokeJoinTransitContext(ctx, spokeVPC)
 			if err != nil {
 				return fmt.Errorf("failed to join Transit Gateway: %s", err)
 			}
 		} else if n == "" {
 			//Transit GW has been deleted, leave transit GW.
-			err := client.SpokeLeaveTransit(spokeVPC)
+			err := client.SpokeLeaveTransitContext(ctx, spokeVPC)
 			if err != nil {
 				return fmt.Errorf("failed to leave Transit Gateway: %s", err)
 			}
 		} else {
 			//Change transit GW
-			err := client.SpokeLeaveTransit(spokeVPC)
+			err := client.SpokeLeaveTransitContext(ctx, spokeVPC)
 			if err != nil {
 				return fmt.Errorf("failed to leave Transit Gateway: %s", err)
 			}
 
-			err = client.SpokeJoinTransit(spokeVPC)
+			err = client.SpokeJoinTransitContext(ctx, spokeVPC)
 			if err != nil {
 				return fmt.Errorf("failed to join Transit Gateway: %s", err)
 			}
@@ -713,6 +690,9 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 func resourceAviatrixSpokeGatewayDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -725,7 +705,7 @@ func resourceAviatrixSpokeGatewayDelete(d *schema.ResourceData, meta interface{}
 			GwName: d.Get("gw_name").(string),
 		}
 
-		err := client.SpokeLeaveTransit(spokeVPC)
+		err := client.SpokeLeaveTransitContext(ctx, spokeVPC)
 		if err != nil {
 			return fmt.Errorf("failed to leave Transit Gateway: %s", err)
 		}
@@ -737,7 +717,7 @@ func resourceAviatrixSpokeGatewayDelete(d *schema.ResourceData, meta interface{}
 	if haSubnet != "" || haZone != "" {
 		//Delete HA Gw too
 		gateway.GwName += "-hagw"
-		err := client.DeleteGateway(gateway)
+		err := client.DeleteGatewayContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to delete Aviatrix SpokeGateway HA gateway: %s", err)
 		}
@@ -745,7 +725,7 @@ func resourceAviatrixSpokeGatewayDelete(d *schema.ResourceData, meta interface{}
 
 	gateway.GwName = d.Get("gw_name").(string)
 
-	err := client.DeleteGateway(gateway)
+	err := client.DeleteGatewayContext(ctx, gateway)
 	if err != nil {
 		return fmt.Errorf("failed to delete Aviatrix SpokeGateway: %s", err)
 	}
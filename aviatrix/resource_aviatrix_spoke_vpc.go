@@ -18,34 +18,41 @@ func resourceAviatrixSpokeVpc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(spokeGatewayDiffRules...),
 
-		SchemaVersion: 1,
+		SchemaVersion: 2,
 		MigrateState:  resourceSpokeVpcMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCloudType(1, 4, 8),
+				Description:  "Type of cloud service provider.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "This parameter represents the name of a Cloud-Account in Aviatrix controller.",
 			},
 			"gw_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the gateway which is going to be created.",
 			},
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "VPC-ID/VNet-Name of cloud provider.",
 			},
 			"vpc_reg": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Region of cloud provider.",
 			},
 			"vpc_size": {
@@ -54,21 +61,25 @@ func resourceAviatrixSpokeVpc() *schema.Resource {
 				Description: "Size of the gateway instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Public Subnet Info.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Info.",
 			},
 			"enable_nat": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "no",
-				Description: "Specify whether enabling NAT feature on the gateway or not.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "no",
+				ValidateFunc: validateStringIn("", "yes", "no"),
+				Description:  "Specify whether enabling NAT feature on the gateway or not.",
 			},
 			"ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "HA Subnet. Required if enabling HA for AWS/ARM.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "HA Subnet. Required if enabling HA for AWS/ARM.",
 			},
 			"ha_zone": {
 				Type:        schema.TypeString,
@@ -83,10 +94,11 @@ func resourceAviatrixSpokeVpc() *schema.Resource {
 				Description: "HA Gateway Size.",
 			},
 			"single_az_ha": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "disabled",
-				Description: "Set to 'enabled' if this feature is desired.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ValidateFunc: validateStringIn("", "enabled", "disabled"),
+				Description:  "Set to 'enabled' if this feature is desired.",
 			},
 			"transit_gw": {
 				Type:        schema.TypeString,
@@ -94,18 +106,30 @@ func resourceAviatrixSpokeVpc() *schema.Resource {
 				Default:     "",
 				Description: "Specify the transit Gateway.",
 			},
-			"tag_list": {
-				Type:        schema.TypeList,
+			"tags": {
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				ValidateFunc: validateTags,
+				Description:  "Instance tags of cloud provider.",
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Default:     nil,
-				Description: "Instance tag of cloud provider.",
+				Computed:    true,
+				Description: "Instance tags of cloud provider, including the default tags of the provider.",
 			},
 			"cloud_instance_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Cloud instance ID.",
 			},
+			"allow_replacement": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow Terraform to replace the gateway when an attribute which cannot be updated changes.",
+			},
 		},
 	}
 }
@@ -215,21 +239,18 @@ func resourceAviatrixSpokeVpcCreate(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
-	if _, ok := d.GetOk("tag_list"); ok && gateway.CloudType == 1 {
-		tagList := d.Get("tag_list").([]interface{})
-		tagListStr := goaviatrix.ExpandStringList(tagList)
-		gateway.TagList = strings.Join(tagListStr, ",")
+	if tagMap := gatewayTags(client, d.Get("tags")); len(tagMap) != 0 && gateway.CloudType == 1 {
 		tags := &goaviatrix.Tags{
 			CloudType:    1,
 			ResourceType: "gw",
 			ResourceName: d.Get("gw_name").(string),
-			TagList:      gateway.TagList,
+			Tags:         tagMap,
 		}
 		err = client.AddTags(tags)
 		if err != nil {
 			return fmt.Errorf("failed to add tags: %s", err)
 		}
-	} else if ok && gateway.CloudType != 1 {
+	} else if _, ok := d.GetOk("tags"); ok && gateway.CloudType != 1 {
 		return fmt.Errorf("adding tags only supported for aws, cloud_type must be 1")
 	}
 
@@ -260,6 +281,7 @@ func resourceAviatrixSpokeVpcRead(d *schema.ResourceData, meta interface{}) erro
 		id := d.Id()
 		log.Printf("[DEBUG] Looks like an import, no gateway name received. Import Id is %s", id)
 		d.Set("gw_name", id)
+		d.Set("allow_replacement", false)
 		d.SetId(id)
 	}
 
@@ -311,28 +333,8 @@ func resourceAviatrixSpokeVpcRead(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	if gw.CloudType == 1 {
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-		}
-		tagList, err := client.GetTags(tags)
-		if err != nil {
-			return fmt.Errorf("unable to read tag_list for gateway: %v due to %v", gateway.GwName, err)
-		}
-		var tagListStr []string
-		if _, ok := d.GetOk("tag_list"); ok {
-			tagList1 := d.Get("tag_list").([]interface{})
-			tagListStr = goaviatrix.ExpandStringList(tagList1)
-		}
-		if len(goaviatrix.Difference(tagListStr, tagList)) != 0 || len(goaviatrix.Difference(tagList, tagListStr)) != 0 {
-			if err := d.Set("tag_list", tagList); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
-		} else {
-			if err := d.Set("tag_list", tagListStr); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
+		if err := readGatewayTags(client, d); err != nil {
+			return err
 		}
 	}
 
@@ -378,24 +380,6 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 	log.Printf("[INFO] Updating Aviatrix gateway: %#v", gateway)
 
 	d.Partial(true)
-	if d.HasChange("cloud_type") {
-		return fmt.Errorf("updating cloud_type is not allowed")
-	}
-	if d.HasChange("account_name") {
-		return fmt.Errorf("updating account_name is not allowed")
-	}
-	if d.HasChange("gw_name") {
-		return fmt.Errorf("updating gw_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("vpc_reg") {
-		return fmt.Errorf("updating vpc_reg is not allowed")
-	}
-	if d.HasChange("subnet") {
-		return fmt.Errorf("updating subnet is not allowed")
-	}
 	if d.HasChange("single_az_ha") {
 		_, singleAz := d.GetChange("single_az_ha")
 		singleAZGateway := &goaviatrix.Gateway{
@@ -419,43 +403,13 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
-	if d.HasChange("tag_list") && gateway.CloudType == 1 {
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-		}
-		o, n := d.GetChange("tag_list")
-		if o == nil {
-			o = new([]interface{})
-		}
-		if n == nil {
-			n = new([]interface{})
-		}
-		os := o.([]interface{})
-		ns := n.([]interface{})
-		oldList := goaviatrix.ExpandStringList(os)
-		newList := goaviatrix.ExpandStringList(ns)
-		oldTagList := goaviatrix.Difference(oldList, newList)
-		newTagList := goaviatrix.Difference(newList, oldList)
-		if len(oldTagList) != 0 || len(newTagList) != 0 {
-			if len(oldTagList) != 0 {
-				tags.TagList = strings.Join(oldTagList, ",")
-				err := client.DeleteTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to delete tags : %s", err)
-				}
-			}
-			if len(newTagList) != 0 {
-				tags.TagList = strings.Join(newTagList, ",")
-				err := client.AddTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to add tags : %s", err)
-				}
-			}
+	if gateway.CloudType == 1 && (d.HasChange("tags") || d.HasChange("tags_all")) {
+		if err := updateGatewayTags(client.Context(), client, d); err != nil {
+			return err
 		}
-		d.SetPartial("tag_list")
-	} else if d.HasChange("tag_list") && gateway.CloudType != 1 {
+		d.SetPartial("tags")
+		d.SetPartial("tags_all")
+	} else if d.HasChange("tags") && gateway.CloudType != 1 {
 		return fmt.Errorf("adding tags is only supported for aws, cloud_type must be set to 1")
 	}
 
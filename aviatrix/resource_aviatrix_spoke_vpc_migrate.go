@@ -11,8 +11,15 @@ func resourceSpokeVpcMigrateState(
 	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
 	switch v {
 	case 0:
-		log.Println("[INFO] Found AVIATRIX Spoke Vpc State v0; migrating to v1")
-		return migrateSpokeVpcStateV0toV1(is)
+		log.Println("[INFO] Found AVIATRIX Spoke Vpc State v0; migrating to v2")
+		is, err := migrateSpokeVpcStateV0toV1(is)
+		if err != nil {
+			return is, err
+		}
+		return migrateSpokeVpcStateV1toV2(is)
+	case 1:
+		log.Println("[INFO] Found AVIATRIX Spoke Vpc State v1; migrating to v2")
+		return migrateSpokeVpcStateV1toV2(is)
 	default:
 		return is, fmt.Errorf("unexpected schema version: %d", v)
 	}
@@ -33,3 +40,16 @@ func migrateSpokeVpcStateV0toV1(is *terraform.InstanceState) (*terraform.Instanc
 	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
 	return is, nil
 }
+
+func migrateSpokeVpcStateV1toV2(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() || is.Attributes == nil {
+		log.Println("[DEBUG] Empty Spoke Vpc State; nothing to migrate.")
+		return is, nil
+	}
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	migrateTagListToTags(is)
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
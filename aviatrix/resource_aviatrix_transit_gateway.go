@@ -1,9 +1,11 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -18,31 +20,45 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(transitGatewayDiffRules...),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		SchemaVersion: 1,
+		MigrateState:  resourceAviatrixTransitGatewayMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider, requires an integer value. Use 1 for AWS.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCloudType(1, 8),
+				Description:  "Type of cloud service provider, requires an integer value. Use 1 for AWS.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "This parameter represents the name of a Cloud-Account in Aviatrix controller.",
 			},
 			"gw_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the gateway which is going to be created.",
 			},
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "VPC-ID/VNet-Name of cloud provider.",
 			},
 			"vpc_reg": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Region of cloud provider.",
 			},
 			"gw_size": {
@@ -51,14 +67,17 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 				Description: "Size of the gateway instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Public Subnet Name.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Name.",
 			},
 			"insane_mode_az": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "",
+				ForceNew:    true,
 				Description: "AZ of subnet being created for Insane Mode Transit Gateway. Required if insane_mode is enabled.",
 			},
 			"allocate_new_eip": {
@@ -75,10 +94,11 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 				Description: "Required when allocate_new_eip is false. It uses specified EIP for this gateway.",
 			},
 			"ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "HA Subnet.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "HA Subnet.",
 			},
 			"ha_insane_mode_az": {
 				Type:        schema.TypeString,
@@ -104,12 +124,18 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 				Default:     false,
 				Description: "Enable or disable Source NAT for this container.",
 			},
-			"tag_list": {
-				Type:        schema.TypeList,
+			"tags": {
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				ValidateFunc: validateTags,
+				Description:  "Instance tags of cloud provider.",
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Default:     nil,
-				Description: "Instance tag of cloud provider.",
+				Computed:    true,
+				Description: "Instance tags of cloud provider, including the default tags of the provider.",
 			},
 			"enable_hybrid_connection": {
 				Type:        schema.TypeBool,
@@ -127,6 +153,7 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
+				ForceNew:    true,
 				Description: "Enable Insane Mode for Transit. Valid values: true, false. If insane mode is enabled, gateway size has to at least be c5 size.",
 			},
 			"enable_firenet_interfaces": {
@@ -135,13 +162,39 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 				Default:     false,
 				Description: "Specify whether to enable firenet interfaces or not.",
 			},
+			"allow_replacement": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow Terraform to replace the gateway when an attribute which cannot be updated changes.",
+			},
 		},
 	}
 }
 
+// transitGatewayDiffRules are the rules on the attributes of transit
+// gateways.
+var transitGatewayDiffRules = []diffRule{
+	forbid(allOf(isSet("insane_mode"), not(equals("cloud_type", 1))),
+		"insane_mode is only support for aws (cloud_type = 1)"),
+	requireIf(isSet("insane_mode"), "insane_mode_az", "insane_mode_az needed if insane_mode is enabled"),
+	requireIf(allOf(isSet("insane_mode"), isSet("ha_subnet")), "ha_insane_mode_az",
+		"ha_insane_mode_az needed if insane_mode is enabled and ha_subnet is set"),
+	requireIf(isSet("ha_subnet"), "ha_gw_size",
+		"A valid non empty ha_gw_size parameter is mandatory for this resource if ha_subnet is set. Example: t2.micro"),
+	forbid(allOf(isSet("tags"), not(equals("cloud_type", 1))),
+		"'tags' is only supported for AWS cloud type 1"),
+	forbid(allOf(isSet("enable_hybrid_connection"), not(equals("cloud_type", 1))),
+		"'enable_hybrid_connection' is only supported for AWS cloud type 1"),
+	planTagsAll,
+}
+
 func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
 	gateway := &goaviatrix.TransitVpc{
 		CloudType:              d.Get("cloud_type").(int),
 		AccountName:            d.Get("account_name").(string),
@@ -221,7 +274,7 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 
 	log.Printf("[INFO] Creating Aviatrix Transit Gateway: %#v", gateway)
 
-	err := client.LaunchTransitVpc(gateway)
+	err := client.LaunchTransitVpcContext(ctx, gateway)
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix Transit Gateway: %s", err)
 	}
@@ -231,6 +284,10 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 	flag := false
 	defer resourceAviatrixTransitGatewayReadIfRequired(d, meta, &flag)
 
+	if _, err := client.WaitForGatewayContext(ctx, &goaviatrix.Gateway{GwName: gateway.GwName}); err != nil {
+		return fmt.Errorf("failed to wait for Aviatrix Transit Gateway %s: %s", gateway.GwName, err)
+	}
+
 	if haSubnet != "" {
 		//Enable HA
 		transitGateway := &goaviatrix.TransitVpc{
@@ -249,7 +306,7 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 
 		log.Printf("[INFO] Enabling HA on Transit Gateway: %#v", haSubnet)
 
-		err = client.EnableHaTransitVpc(transitGateway)
+		err = client.EnableHaTransitVpcContext(ctx, transitGateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable HA Aviatrix Transit Gateway: %s", err)
 		}
@@ -271,29 +328,25 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 
 			log.Printf("[INFO] Resizing Transit HA GAteway size to: %s ", haGateway.GwSize)
 
-			err := client.UpdateGateway(haGateway)
+			err := client.UpdateGatewayContext(ctx, haGateway)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Transit HA Gateway size: %s", err)
 			}
 		}
 	}
 
-	if _, ok := d.GetOk("tag_list"); ok {
-		if cloudType != 1 {
-			return fmt.Errorf("'tag_list' is only supported for AWS cloud type 1")
-		}
-		tagList := d.Get("tag_list").([]interface{})
-		tagListStr := goaviatrix.ExpandStringList(tagList)
-		tagListStr = goaviatrix.TagListStrColon(tagListStr)
-		gateway.TagList = strings.Join(tagListStr, ",")
+	if _, ok := d.GetOk("tags"); ok && cloudType != 1 {
+		return fmt.Errorf("'tags' is only supported for AWS cloud type 1")
+	}
+	if tagMap := gatewayTags(client, d.Get("tags")); len(tagMap) != 0 && cloudType == 1 {
 		tags := &goaviatrix.Tags{
 			CloudType:    1,
 			ResourceType: "gw",
 			ResourceName: d.Get("gw_name").(string),
-			TagList:      gateway.TagList,
+			Tags:         tagMap,
 		}
 
-		err = client.AddTags(tags)
+		err = client.AddTagsContext(ctx, tags)
 		if err != nil {
 			return fmt.Errorf("failed to add tags: %s", err)
 		}
@@ -309,14 +362,14 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 			return fmt.Errorf("'enable_hybrid_connection' is only supported for AWS cloud type 1")
 		}
 
-		err := client.AttachTransitGWForHybrid(gateway)
+		err := client.AttachTransitGWForHybridContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable transit GW for Hybrid: %s", err)
 		}
 	}
 
 	if connectedTransit {
-		err := client.EnableConnectedTransit(gateway)
+		err := client.EnableConnectedTransitContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable connected transit: %s", err)
 		}
@@ -327,7 +380,7 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 			GwName: gateway.GwName,
 		}
 
-		err := client.EnableSNat(gw)
+		err := client.EnableSNatContext(ctx, gw)
 		if err != nil {
 			return fmt.Errorf("failed to enable SNAT: %s", err)
 		}
@@ -335,7 +388,7 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 
 	enableFireNetInterfaces := d.Get("enable_firenet_interfaces").(bool)
 	if enableFireNetInterfaces {
-		err := client.EnableGatewayFireNetInterfaces(gateway)
+		err := client.EnableGatewayFireNetInterfacesContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable transit GW for FireNet Interfaces: %s", err)
 		}
@@ -360,6 +413,7 @@ func resourceAviatrixTransitGatewayRead(d *schema.ResourceData, meta interface{}
 		id := d.Id()
 		log.Printf("[DEBUG] Looks like an import, no gateway name received. Import Id is %s", id)
 		d.Set("gw_name", id)
+		d.Set("allow_replacement", false)
 		d.SetId(id)
 	}
 
@@ -437,30 +491,8 @@ func resourceAviatrixTransitGatewayRead(d *schema.ResourceData, meta interface{}
 	}
 
 	if gw.CloudType == 1 {
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-		}
-
-		tagList, err := client.GetTags(tags)
-		if err != nil {
-			return fmt.Errorf("unable to read tag_list for gateway: %v due to %v", gateway.GwName, err)
-		}
-
-		var tagListStr []string
-		if _, ok := d.GetOk("tag_list"); ok {
-			tagList1 := d.Get("tag_list").([]interface{})
-			tagListStr = goaviatrix.ExpandStringList(tagList1)
-		}
-		if len(goaviatrix.Difference(tagListStr, tagList)) != 0 || len(goaviatrix.Difference(tagList, tagListStr)) != 0 {
-			if err := d.Set("tag_list", tagList); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
-		} else {
-			if err := d.Set("tag_list", tagListStr); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
+		if err := readGatewayTags(client, d); err != nil {
+			return err
 		}
 	}
 
@@ -495,6 +527,10 @@ func resourceAviatrixTransitGatewayRead(d *schema.ResourceData, meta interface{}
 
 func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
+
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -507,35 +543,10 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 
 	d.Partial(true)
 
-	if d.HasChange("cloud_type") {
-		return fmt.Errorf("updating cloud_type is not allowed")
-	}
-	if d.HasChange("account_name") {
-		return fmt.Errorf("updating account_name is not allowed")
-	}
-	if d.HasChange("gw_name") {
-		return fmt.Errorf("updating gw_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("vpc_reg") {
-		return fmt.Errorf("updating vpc_reg is not allowed")
-	}
-	if d.HasChange("subnet") {
-		return fmt.Errorf("updating subnet is not allowed")
-	}
-	if d.HasChange("insane_mode") {
-		return fmt.Errorf("updating insane_mode is not allowed")
-	}
-	if d.HasChange("insane_mode_az") {
-		return fmt.Errorf("updating insane_mode_az is not allowed")
-	}
-
 	if d.HasChange("gw_size") {
 		gateway.GwSize = d.Get("gw_size").(string)
 
-		err := client.UpdateGateway(gateway)
+		err := client.UpdateGatewayContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix Transit Gateway: %s", err)
 		}
@@ -566,26 +577,26 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		o, n := d.GetChange("ha_subnet")
 		if o == "" {
 			//New configuration to enable HA
-			err := client.EnableHaTransitVpc(transitGateway)
+			err := client.EnableHaTransitVpcContext(ctx, transitGateway)
 			if err != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix Transit Gateway: %s", err)
 			}
 		} else if n == "" {
 			//Ha configuration has been deleted
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayContext(ctx, haGateway)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix Transit Gateway HA gateway: %s", err)
 			}
 		} else {
 			//HA subnet has been modified. Delete older HA GW, and launch new HA GW in new subnet.
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayContext(ctx, haGateway)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix Transit Gateway HA gateway: %s", err)
 			}
 
 			gateway.GwName = d.Get("gw_name").(string)
 			//New configuration to enable HA
-			haErr := client.EnableHaTransitVpc(transitGateway)
+			haErr := client.EnableHaTransitVpcContext(ctx, transitGateway)
 			if haErr != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix Transit Gateway: %s", err)
 			}
@@ -594,48 +605,16 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 	}
 
 	if gateway.CloudType == 1 {
-		if d.HasChange("tag_list") {
-			tags := &goaviatrix.Tags{
-				CloudType:    1,
-				ResourceType: "gw",
-				ResourceName: d.Get("gw_name").(string),
+		if d.HasChange("tags") || d.HasChange("tags_all") {
+			if err := updateGatewayTags(ctx, client, d); err != nil {
+				return err
 			}
-			o, n := d.GetChange("tag_list")
-			if o == nil {
-				o = new([]interface{})
-			}
-			if n == nil {
-				n = new([]interface{})
-			}
-			os := o.([]interface{})
-			ns := n.([]interface{})
-			oldList := goaviatrix.ExpandStringList(os)
-			newList := goaviatrix.ExpandStringList(ns)
-			oldTagList := goaviatrix.Difference(oldList, newList)
-			newTagList := goaviatrix.Difference(newList, oldList)
-			if len(oldTagList) != 0 || len(newTagList) != 0 {
-				if len(oldTagList) != 0 {
-					oldTagList = goaviatrix.TagListStrColon(oldTagList)
-					tags.TagList = strings.Join(oldTagList, ",")
-					err := client.DeleteTags(tags)
-					if err != nil {
-						return fmt.Errorf("failed to delete tags : %s", err)
-					}
-				}
-				if len(newTagList) != 0 {
-					newTagList = goaviatrix.TagListStrColon(newTagList)
-					tags.TagList = strings.Join(newTagList, ",")
-					err := client.AddTags(tags)
-					if err != nil {
-						return fmt.Errorf("failed to add tags : %s", err)
-					}
-				}
-			}
-			d.SetPartial("tag_list")
+			d.SetPartial("tags")
+			d.SetPartial("tags_all")
 		}
 	} else {
-		if d.HasChange("tag_list") {
-			return fmt.Errorf("'tag_list' is only supported for AWS cloud type 1")
+		if d.HasChange("tags") {
+			return fmt.Errorf("'tags' is only supported for AWS cloud type 1")
 		}
 	}
 
@@ -650,12 +629,12 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 			}
 			enableHybridConnection := d.Get("enable_hybrid_connection").(bool)
 			if enableHybridConnection {
-				err := client.AttachTransitGWForHybrid(transitGateway)
+				err := client.AttachTransitGWForHybridContext(ctx, transitGateway)
 				if err != nil {
 					return fmt.Errorf("failed to enable transit GW for Hybrid: %s", err)
 				}
 			} else {
-				err := client.DetachTransitGWForHybrid(transitGateway)
+				err := client.DetachTransitGWForHybridContext(ctx, transitGateway)
 				if err != nil {
 					return fmt.Errorf("failed to disable transit GW for Hybrid: %s", err)
 				}
@@ -678,12 +657,12 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		connectedTransit := d.Get("connected_transit").(bool)
 
 		if connectedTransit {
-			err := client.EnableConnectedTransit(transitGateway)
+			err := client.EnableConnectedTransitContext(ctx, transitGateway)
 			if err != nil {
 				return fmt.Errorf("failed to enable connected transit: %s", err)
 			}
 		} else {
-			err := client.DisableConnectedTransit(transitGateway)
+			err := client.DisableConnectedTransitContext(ctx, transitGateway)
 			if err != nil {
 				return fmt.Errorf("failed to disable connected transit: %s", err)
 			}
@@ -693,7 +672,7 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 	}
 
 	if d.HasChange("ha_gw_size") {
-		_, err := client.GetGateway(haGateway)
+		_, err := client.GetGatewayContext(ctx, haGateway)
 		if err != nil {
 			if err == goaviatrix.ErrNotFound {
 				d.Set("ha_gw_size", "")
@@ -710,7 +689,7 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 				"ha_subnet is set. Example: t2.micro")
 		}
 
-		err = client.UpdateGateway(haGateway)
+		err = client.UpdateGatewayContext(ctx, haGateway)
 		log.Printf("[INFO] Updating Transit HA GAteway size to: %s ", haGateway.GwSize)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix Transit HA Gw size: %s", err)
@@ -727,12 +706,12 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		enableNat := d.Get("enable_snat").(bool)
 
 		if enableNat {
-			err := client.EnableSNat(gw)
+			err := client.EnableSNatContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to enable SNAT: %s", err)
 			}
 		} else {
-			err := client.DisableSNat(gw)
+			err := client.DisableSNatContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to disable SNAT: %s", err)
 			}
@@ -747,12 +726,12 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		}
 		enableFireNetInterfaces := d.Get("enable_firenet_interfaces").(bool)
 		if enableFireNetInterfaces {
-			err := client.EnableGatewayFireNetInterfaces(transitGW)
+			err := client.EnableGatewayFireNetInterfacesContext(ctx, transitGW)
 			if err != nil {
 				return fmt.Errorf("failed to enable transit GW for FireNet Interfaces: %s", err)
 			}
 		} else {
-			err := client.DisableGatewayFireNetInterfaces(transitGW)
+			err := client.DisableGatewayFireNetInterfacesContext(ctx, transitGW)
 			if err != nil {
 				return fmt.Errorf("failed to remove transit GW for FireNet Interfaces: %s", err)
 			}
@@ -766,6 +745,9 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 func resourceAviatrixTransitGatewayDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -780,7 +762,7 @@ func resourceAviatrixTransitGatewayDelete(d *schema.ResourceData, meta interface
 			GwName:    d.Get("gw_name").(string),
 		}
 
-		err := client.DisableGatewayFireNetInterfaces(gw)
+		err := client.DisableGatewayFireNetInterfacesContext(ctx, gw)
 		if err != nil {
 			return fmt.Errorf("failed to disable transit GW for FireNet Interfaces: %s", err)
 		}
@@ -790,7 +772,7 @@ func resourceAviatrixTransitGatewayDelete(d *schema.ResourceData, meta interface
 	if haSubnet := d.Get("ha_subnet").(string); haSubnet != "" {
 		gateway.GwName += "-hagw"
 
-		err := client.DeleteGateway(gateway)
+		err := client.DeleteGatewayContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to delete Aviatrix Transit Gateway HA gateway: %s", err)
 		}
@@ -798,7 +780,7 @@ func resourceAviatrixTransitGatewayDelete(d *schema.ResourceData, meta interface
 
 	gateway.GwName = d.Get("gw_name").(string)
 
-	err := client.DeleteGateway(gateway)
+	err := client.DeleteGatewayContext(ctx, gateway)
 	if err != nil {
 		return fmt.Errorf("failed to delete Aviatrix Transit Gateway: %s", err)
 	}
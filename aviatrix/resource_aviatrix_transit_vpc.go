@@ -18,34 +18,41 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(transitGatewayDiffRules...),
 
-		SchemaVersion: 2,
+		SchemaVersion: 3,
 		MigrateState:  resourceTransitVpcMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider, requires an integer value. Use 1 for AWS.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCloudType(1, 8),
+				Description:  "Type of cloud service provider, requires an integer value. Use 1 for AWS.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "This parameter represents the name of a Cloud-Account in Aviatrix controller.",
 			},
 			"gw_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the gateway which is going to be created.",
 			},
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "VPC-ID/VNet-Name of cloud provider.",
 			},
 			"vpc_reg": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Region of cloud provider.",
 			},
 			"vpc_size": {
@@ -54,21 +61,25 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 				Description: "Size of the gateway instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Public Subnet Name.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Name.",
 			},
 			"insane_mode_az": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "",
+				ForceNew:    true,
 				Description: "AZ of subnet being created for Insane Mode Transit Gateway. Required if insane_mode is enabled.",
 			},
 			"ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "HA Subnet.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "HA Subnet.",
 			},
 			"ha_insane_mode_az": {
 				Type:        schema.TypeString,
@@ -83,17 +94,24 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 				Description: "HA Gateway Size. Mandatory if HA is enabled (ha_subnet is set).",
 			},
 			"enable_nat": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "no",
-				Description: "Enable NAT for this container.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "no",
+				ValidateFunc: validateStringIn("", "yes", "no"),
+				Description:  "Enable NAT for this container.",
+			},
+			"tags": {
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				ValidateFunc: validateTags,
+				Description:  "Instance tags of cloud provider.",
 			},
-			"tag_list": {
-				Type:        schema.TypeList,
+			"tags_all": {
+				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Default:     nil,
-				Description: "Instance tag of cloud provider.",
+				Computed:    true,
+				Description: "Instance tags of cloud provider, including the default tags of the provider.",
 			},
 			"enable_hybrid_connection": {
 				Type:        schema.TypeBool,
@@ -102,15 +120,17 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 				Description: "Sign of readiness for TGW connection.",
 			},
 			"connected_transit": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "no",
-				Description: "Specify Connected Transit status.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "no",
+				ValidateFunc: validateStringIn("yes", "no"),
+				Description:  "Specify Connected Transit status.",
 			},
 			"insane_mode": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
+				ForceNew:    true,
 				Description: "Enable Insane Mode for Transit. Valid values: true, false. If insane mode is enabled, gateway size has to at least be c5 size.",
 			},
 			"enable_firenet_interfaces": {
@@ -119,6 +139,12 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 				Default:     false,
 				Description: "Specify whether to enable firenet interfaces or not.",
 			},
+			"allow_replacement": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow Terraform to replace the gateway when an attribute which cannot be updated changes.",
+			},
 		},
 	}
 }
@@ -240,18 +266,15 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	if _, ok := d.GetOk("tag_list"); ok {
-		if cloudType != 1 {
-			return fmt.Errorf("'tag_list' is only supported for AWS cloud type 1")
-		}
-		tagList := d.Get("tag_list").([]interface{})
-		tagListStr := goaviatrix.ExpandStringList(tagList)
-		gateway.TagList = strings.Join(tagListStr, ",")
+	if _, ok := d.GetOk("tags"); ok && cloudType != 1 {
+		return fmt.Errorf("'tags' is only supported for AWS cloud type 1")
+	}
+	if tagMap := gatewayTags(client, d.Get("tags")); len(tagMap) != 0 && cloudType == 1 {
 		tags := &goaviatrix.Tags{
 			CloudType:    1,
 			ResourceType: "gw",
 			ResourceName: d.Get("gw_name").(string),
-			TagList:      gateway.TagList,
+			Tags:         tagMap,
 		}
 		err = client.AddTags(tags)
 		if err != nil {
@@ -318,6 +341,7 @@ func resourceAviatrixTransitVpcRead(d *schema.ResourceData, meta interface{}) er
 		id := d.Id()
 		log.Printf("[DEBUG] Looks like an import, no gateway name received. Import Id is %s", id)
 		d.Set("gw_name", id)
+		d.Set("allow_replacement", false)
 		d.SetId(id)
 	}
 
@@ -372,28 +396,8 @@ func resourceAviatrixTransitVpcRead(d *schema.ResourceData, meta interface{}) er
 	}
 
 	if gw.CloudType == 1 {
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-		}
-		tagList, err := client.GetTags(tags)
-		if err != nil {
-			return fmt.Errorf("unable to read tag_list for gateway: %v due to %v", gateway.GwName, err)
-		}
-		var tagListStr []string
-		if _, ok := d.GetOk("tag_list"); ok {
-			tagList1 := d.Get("tag_list").([]interface{})
-			tagListStr = goaviatrix.ExpandStringList(tagList1)
-		}
-		if len(goaviatrix.Difference(tagListStr, tagList)) != 0 || len(goaviatrix.Difference(tagList, tagListStr)) != 0 {
-			if err := d.Set("tag_list", tagList); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
-		} else {
-			if err := d.Set("tag_list", tagListStr); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
+		if err := readGatewayTags(client, d); err != nil {
+			return err
 		}
 	}
 
@@ -435,30 +439,6 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 	log.Printf("[INFO] Updating Aviatrix TransitVpc: %#v", gateway)
 
 	d.Partial(true)
-	if d.HasChange("cloud_type") {
-		return fmt.Errorf("updating cloud_type is not allowed")
-	}
-	if d.HasChange("account_name") {
-		return fmt.Errorf("updating account_name is not allowed")
-	}
-	if d.HasChange("gw_name") {
-		return fmt.Errorf("updating gw_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("vpc_reg") {
-		return fmt.Errorf("updating vpc_reg is not allowed")
-	}
-	if d.HasChange("subnet") {
-		return fmt.Errorf("updating subnet is not allowed")
-	}
-	if d.HasChange("insane_mode") {
-		return fmt.Errorf("updating insane_mode is not allowed")
-	}
-	if d.HasChange("insane_mode_az") {
-		return fmt.Errorf("updating insane_mode_az is not allowed")
-	}
 
 	if d.HasChange("vpc_size") {
 		gateway.GwSize = d.Get("vpc_size").(string)
@@ -515,46 +495,16 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	if gateway.CloudType == 1 {
-		if d.HasChange("tag_list") {
-			tags := &goaviatrix.Tags{
-				CloudType:    1,
-				ResourceType: "gw",
-				ResourceName: d.Get("gw_name").(string),
-			}
-			o, n := d.GetChange("tag_list")
-			if o == nil {
-				o = new([]interface{})
-			}
-			if n == nil {
-				n = new([]interface{})
-			}
-			os := o.([]interface{})
-			ns := n.([]interface{})
-			oldList := goaviatrix.ExpandStringList(os)
-			newList := goaviatrix.ExpandStringList(ns)
-			oldTagList := goaviatrix.Difference(oldList, newList)
-			newTagList := goaviatrix.Difference(newList, oldList)
-			if len(oldTagList) != 0 || len(newTagList) != 0 {
-				if len(oldTagList) != 0 {
-					tags.TagList = strings.Join(oldTagList, ",")
-					err := client.DeleteTags(tags)
-					if err != nil {
-						return fmt.Errorf("failed to delete tags : %s", err)
-					}
-				}
-				if len(newTagList) != 0 {
-					tags.TagList = strings.Join(newTagList, ",")
-					err := client.AddTags(tags)
-					if err != nil {
-						return fmt.Errorf("failed to add tags : %s", err)
-					}
-				}
+		if d.HasChange("tags") || d.HasChange("tags_all") {
+			if err := updateGatewayTags(client.Context(), client, d); err != nil {
+				return err
 			}
-			d.SetPartial("tag_list")
+			d.SetPartial("tags")
+			d.SetPartial("tags_all")
 		}
 	} else {
-		if d.HasChange("tag_list") {
-			return fmt.Errorf("'tag_list' is only supported for AWS cloud type 1")
+		if d.HasChange("tags") {
+			return fmt.Errorf("'tags' is only supported for AWS cloud type 1")
 		}
 	}
 
@@ -11,11 +11,25 @@ func resourceTransitVpcMigrateState(
 	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
 	switch v {
 	case 0:
-		log.Println("[INFO] Found AVIATRIX Transit Vpc State v0; migrating to v1")
-		return migrateTransitVpcStateV0toV1(is)
+		log.Println("[INFO] Found AVIATRIX Transit Vpc State v0; migrating to v3")
+		is, err := migrateTransitVpcStateV0toV1(is)
+		if err != nil {
+			return is, err
+		}
+		if is, err = migrateTransitVpcStateV1toV2(is); err != nil {
+			return is, err
+		}
+		return migrateTransitVpcStateV2toV3(is)
 	case 1:
-		log.Println("[INFO] Found AVIATRIX Transit Vpc State v1; migrating to v2")
-		return migrateTransitVpcStateV1toV2(is)
+		log.Println("[INFO] Found AVIATRIX Transit Vpc State v1; migrating to v3")
+		is, err := migrateTransitVpcStateV1toV2(is)
+		if err != nil {
+			return is, err
+		}
+		return migrateTransitVpcStateV2toV3(is)
+	case 2:
+		log.Println("[INFO] Found AVIATRIX Transit Vpc State v2; migrating to v3")
+		return migrateTransitVpcStateV2toV3(is)
 	default:
 		return is, fmt.Errorf("unexpected schema version: %d", v)
 	}
@@ -49,3 +63,16 @@ func migrateTransitVpcStateV1toV2(is *terraform.InstanceState) (*terraform.Insta
 	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
 	return is, nil
 }
+
+func migrateTransitVpcStateV2toV3(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() || is.Attributes == nil {
+		log.Println("[DEBUG] Empty Transit Vpc State; nothing to migrate.")
+		return is, nil
+	}
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	migrateTagListToTags(is)
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
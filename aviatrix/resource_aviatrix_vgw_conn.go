@@ -1,6 +1,7 @@
 package aviatrix
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -26,27 +27,33 @@ func resourceAviatrixVGWConn() *schema.Resource {
 			"conn_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "The name of for Transit GW to VGW connection connection which is going to be created.",
 			},
 			"gw_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the Transit Gateway.",
 			},
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "VPC-ID where the Transit Gateway is located.",
 			},
 			"bgp_vgw_id": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Id of AWS's VGW that is used for this connection.",
 			},
 			"bgp_local_as_num": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "BGP Local ASN (Autonomous System Number). Integer between 1-65535.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateASN,
+				Description:  "BGP Local ASN (Autonomous System Number). Integer between 1-65535.",
 			},
 			"enable_advertise_transit_cidr": {
 				Type:        schema.TypeBool,
@@ -55,10 +62,11 @@ func resourceAviatrixVGWConn() *schema.Resource {
 				Description: "Switch to Enable/Disable advertise transit VPC network CIDR.",
 			},
 			"bgp_manual_spoke_advertise_cidrs": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Intended CIDR list to advertise to VGW.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDRList,
+				Description:  "Intended CIDR list to advertise to VGW.",
 			},
 		},
 	}
@@ -171,22 +179,6 @@ func resourceAviatrixVGWConnUpdate(d *schema.ResourceData, meta interface{}) err
 
 	d.Partial(true)
 
-	if d.HasChange("conn_name") {
-		return fmt.Errorf("updating conn_name is not allowed")
-	}
-	if d.HasChange("gw_name") {
-		return fmt.Errorf("updating gw_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("bgp_vgw_id") {
-		return fmt.Errorf("updating bgp_vgw_id is not allowed")
-	}
-	if d.HasChange("bgp_local_as_num") {
-		return fmt.Errorf("updating bgp_local_as_num is not allowed")
-	}
-
 	if d.HasChange("enable_advertise_transit_cidr") {
 		enableAdvertiseTransitCidr := d.Get("enable_advertise_transit_cidr").(bool)
 		if enableAdvertiseTransitCidr {
@@ -237,7 +229,7 @@ func resourceAviatrixVGWConnDelete(d *schema.ResourceData, meta interface{}) err
 
 	err := client.DeleteVGWConn(vgwConn)
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
 			return nil
 		}
 		return fmt.Errorf("failed to delete Aviatrix VGWConn: %s", err)
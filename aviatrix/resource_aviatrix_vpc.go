@@ -16,16 +16,21 @@ func resourceAviatrixVpc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(
+			forbid(allOf(isSet("aviatrix_transit_vpc"), isSet("aviatrix_firenet_vpc")),
+				"vpc cannot be aviatrix transit vpc and aviatrix firenet vpc at the same time"),
+		),
 
 		SchemaVersion: 1,
 		MigrateState:  resourceAviatrixVpcMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCloudType(1),
+				Description:  "Type of cloud service provider.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
@@ -46,10 +51,11 @@ func resourceAviatrixVpc() *schema.Resource {
 				Description: "Name of the VPC to be created.",
 			},
 			"cidr": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Subnet of the VPC to be created.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Subnet of the VPC to be created.",
 			},
 			"aviatrix_transit_vpc": {
 				Type:        schema.TypeBool,
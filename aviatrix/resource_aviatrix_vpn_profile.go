@@ -17,17 +17,23 @@ func resourceAviatrixProfile() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateDiff(
+			forEach("policy", validateProfileRule),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "name for the VPN profile.",
 			},
 			"base_rule": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Base policy rule of  the profile to be added. Enter 'allow_all' or 'deny_all'.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStringIn("", "allow_all", "deny_all"),
+				Description:  "Base policy rule of  the profile to be added. Enter 'allow_all' or 'deny_all'.",
 			},
 			"users": {
 				Type:        schema.TypeList,
@@ -42,24 +48,28 @@ func resourceAviatrixProfile() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"action": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The opposite of the base rule for correct behaviour. 'allow' or 'deny'.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringIn("allow", "deny"),
+							Description:  "The opposite of the base rule for correct behaviour. 'allow' or 'deny'.",
 						},
 						"proto": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Protocol to allow or deny.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringIn(firewallProtocols...),
+							Description:  "Protocol to allow or deny.",
 						},
 						"port": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Port to be allowed or denied.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validatePortRange,
+							Description:  "Port to be allowed or denied.",
 						},
 						"target": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "CIDR to be allowed or denied.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCIDR,
+							Description:  "CIDR to be allowed or denied.",
 						},
 					},
 				},
@@ -68,6 +78,17 @@ func resourceAviatrixProfile() *schema.Resource {
 	}
 }
 
+// validateProfileRule checks a policy of the configuration as the controller
+// would.
+func validateProfileRule(dn map[string]interface{}, meta interface{}) error {
+	client := meta.(*goaviatrix.Client)
+	return client.ValidateProfileRule(&goaviatrix.ProfileRule{
+		Action:   dn["action"].(string),
+		Protocol: dn["proto"].(string),
+		Port:     dn["port"].(string),
+	})
+}
+
 func resourceAviatrixProfileCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
@@ -216,12 +237,6 @@ func resourceAviatrixProfileUpdate(d *schema.ResourceData, meta interface{}) err
 
 	log.Printf("[INFO] Reading Aviatrix Profile: %#v", profile)
 
-	if d.HasChange("name") {
-		return fmt.Errorf("cannot change name of a profile")
-	}
-	if d.HasChange("base_rule") {
-		return fmt.Errorf("cannot change base rule of a profile")
-	}
 	if d.HasChange("users") {
 
 		oldU, newU := d.GetChange("users")
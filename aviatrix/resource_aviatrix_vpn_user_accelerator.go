@@ -1,6 +1,7 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -18,6 +19,10 @@ func resourceAviatrixVPNUserAccelerator() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"elb_name": {
@@ -33,9 +38,12 @@ func resourceAviatrixVPNUserAccelerator() *schema.Resource {
 func resourceAviatrixVPNUserAcceleratorCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
 	elb := d.Get("elb_name").(string)
 	// compare if elb is in elb list for current elbs
-	elbList, err := client.GetVpnUserAccelerator()
+	elbList, err := client.GetVpnUserAcceleratorContext(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to read endpoint list for User Accelerator due to %v", err)
 	}
@@ -51,18 +59,12 @@ func resourceAviatrixVPNUserAcceleratorCreate(d *schema.ResourceData, meta inter
 		log.Printf("[DEBUG] Endpoint list: %s", xlr.Endpoints)
 
 		log.Printf("[INFO] Creating User Accelerator.")
-		err := client.UpdateVpnUserAccelerator(xlr)
+		// wait for the controller to find a newly created elb
+		err := client.WaitForFoundContext(ctx, "elb "+elb, func() error {
+			return client.UpdateVpnUserAcceleratorContext(ctx, xlr)
+		})
 		if err != nil {
-			// retry in case of not found elb after waiting
-			if strings.Contains(err.Error(), "Endpoint not found") {
-				time.Sleep(180 * time.Second)
-				err := client.UpdateVpnUserAccelerator(xlr)
-				if err != nil {
-					return fmt.Errorf("failed to create Vpn User Accelerator: %s", err)
-				}
-			} else {
-				return fmt.Errorf("failed to create Vpn User Accelerator: %s", err)
-			}
+			return fmt.Errorf("failed to create Vpn User Accelerator: %s", err)
 		}
 	}
 
@@ -112,10 +114,13 @@ func resourceAviatrixVPNUserAcceleratorRead(d *schema.ResourceData, meta interfa
 func resourceAviatrixVPNUserAcceleratorDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*goaviatrix.Client)
 
+	ctx, cancel := context.WithTimeout(client.Context(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
 	elbName := d.Get("elb_name").(string)
 	toDelete := []string{elbName}
 
-	elbList, err := client.GetVpnUserAccelerator()
+	elbList, err := client.GetVpnUserAcceleratorContext(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to read endpoint list for User Accelerator due to %v", err)
 	}
@@ -132,7 +137,7 @@ func resourceAviatrixVPNUserAcceleratorDelete(d *schema.ResourceData, meta inter
 			xlr.Endpoints = "[]"
 		}
 
-		err := client.UpdateVpnUserAccelerator(xlr)
+		err := client.UpdateVpnUserAcceleratorContext(ctx, xlr)
 		if err != nil {
 			return fmt.Errorf("unable to remove elb in Vpn User Accelerator due to %v", err)
 		}
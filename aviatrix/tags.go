@@ -0,0 +1,159 @@
+package aviatrix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// expandTags returns the tags of a tags attribute.
+func expandTags(tags interface{}) map[string]string {
+	m, _ := tags.(map[string]interface{})
+	tagMap := make(map[string]string, len(m))
+	for k, v := range m {
+		tagMap[k] = v.(string)
+	}
+	return tagMap
+}
+
+// gatewayTags returns the tags of the tags attribute of a gateway and the
+// default tags of the provider.
+func gatewayTags(client *goaviatrix.Client, tags interface{}) map[string]string {
+	return client.MergeDefaultTags(expandTags(tags))
+}
+
+// gatewayTagsResource identifies the tags of an AWS gateway.
+func gatewayTagsResource(d *schema.ResourceData) *goaviatrix.Tags {
+	return &goaviatrix.Tags{
+		CloudType:    1,
+		ResourceType: "gw",
+		ResourceName: d.Get("gw_name").(string),
+	}
+}
+
+// planTagsAll plans tags_all of an AWS gateway as its tags and the default
+// tags of the provider, so that changing the default tags, or a default tag
+// changed outside of Terraform, shows up as a diff of the gateway.
+func planTagsAll(d *schema.ResourceDiff, meta interface{}) error {
+	if aws, known := equals("cloud_type", 1)(d); !known || !aws {
+		return nil
+	}
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+	client, ok := meta.(*goaviatrix.Client)
+	if !ok {
+		return nil
+	}
+	tagsAll := gatewayTags(client, d.Get("tags"))
+	if reflect.DeepEqual(expandTags(d.Get("tags_all")), tagsAll) {
+		return nil
+	}
+	return d.SetNew("tags_all", tagsAll)
+}
+
+// readGatewayTags sets the tags_all attribute of an AWS gateway to the tags
+// the controller reports for it, and its tags attribute to those tags but
+// the default tags of the provider.
+func readGatewayTags(client *goaviatrix.Client, d *schema.ResourceData) error {
+	tags, err := client.GetTags(gatewayTagsResource(d))
+	if err != nil {
+		return fmt.Errorf("unable to read tags for gateway: %v due to %v", d.Get("gw_name"), err)
+	}
+
+	if err := d.Set("tags_all", tags); err != nil {
+		log.Printf("[WARN] Error setting tags_all for (%s): %s", d.Id(), err)
+	}
+	tags = client.WithoutDefaultTags(tags, expandTags(d.Get("tags")))
+	if err := d.Set("tags", tags); err != nil {
+		log.Printf("[WARN] Error setting tags for (%s): %s", d.Id(), err)
+	}
+	return nil
+}
+
+// updateGatewayTags sets the tags of an AWS gateway to its tags and the
+// default tags of the provider, deleting the tags it had before, including
+// former default tags, that are not among them anymore.
+func updateGatewayTags(ctx context.Context, client *goaviatrix.Client, d *schema.ResourceData) error {
+	o, _ := d.GetChange("tags")
+	oAll, _ := d.GetChange("tags_all")
+	oldTags := expandTags(oAll)
+	for k, v := range expandTags(o) {
+		oldTags[k] = v
+	}
+	return updateTags(ctx, client, gatewayTagsResource(d), oldTags, gatewayTags(client, d.Get("tags")))
+}
+
+// updateTags deletes the tags of oldTags missing from newTags and adds the
+// tags of newTags the resource misses or has another value for, e.g. because
+// they were changed outside of Terraform.
+func updateTags(ctx context.Context, client *goaviatrix.Client, tags *goaviatrix.Tags,
+	oldTags map[string]string, newTags map[string]string) error {
+	currentTags, err := client.GetTagsContext(ctx, tags)
+	if err != nil {
+		return fmt.Errorf("failed to read tags: %s", err)
+	}
+
+	delTags := make(map[string]string)
+	for k, v := range oldTags {
+		if _, ok := newTags[k]; !ok {
+			if _, ok := currentTags[k]; ok {
+				delTags[k] = v
+			}
+		}
+	}
+	addTags := make(map[string]string)
+	for k, v := range newTags {
+		if cv, ok := currentTags[k]; !ok || cv != v {
+			addTags[k] = v
+		}
+	}
+	if len(delTags) != 0 {
+		tags.Tags = delTags
+		err := client.DeleteTagsContext(ctx, tags)
+		if err != nil {
+			return fmt.Errorf("failed to delete tags : %s", err)
+		}
+	}
+	if len(addTags) != 0 {
+		tags.Tags = addTags
+		err := client.AddTagsContext(ctx, tags)
+		if err != nil {
+			return fmt.Errorf("failed to add tags : %s", err)
+		}
+	}
+	return nil
+}
+
+// migrateTagListToTags moves the key:value pairs of the tag_list attribute
+// of a gateway's state to its tags attribute.
+func migrateTagListToTags(is *terraform.InstanceState) {
+	n, _ := strconv.Atoi(is.Attributes["tag_list.#"])
+	delete(is.Attributes, "tag_list.#")
+	tags := make(map[string]string)
+	for i := 0; i < n; i++ {
+		key := "tag_list." + strconv.Itoa(i)
+		tag := is.Attributes[key]
+		delete(is.Attributes, key)
+		kv := strings.SplitN(tag, ":", 2)
+		if len(kv) != 2 {
+			log.Printf("[WARN] Dropping tag %q without value from the state", tag)
+			continue
+		}
+		tags[kv[0]] = kv[1]
+	}
+	if len(tags) == 0 {
+		return
+	}
+	for k, v := range tags {
+		is.Attributes["tags."+k] = v
+	}
+	is.Attributes["tags.%"] = strconv.Itoa(len(tags))
+}
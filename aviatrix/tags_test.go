@@ -0,0 +1,62 @@
+package aviatrix
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestMigrateTagListToTags(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		attrs map[string]string
+		want  map[string]string
+	}{
+		{
+			name:  "no tag_list",
+			attrs: map[string]string{"gw_name": "gw1"},
+			want:  map[string]string{"gw_name": "gw1"},
+		},
+		{
+			name:  "empty tag_list",
+			attrs: map[string]string{"gw_name": "gw1", "tag_list.#": "0"},
+			want:  map[string]string{"gw_name": "gw1"},
+		},
+		{
+			name: "tags",
+			attrs: map[string]string{
+				"gw_name":    "gw1",
+				"tag_list.#": "3",
+				"tag_list.0": "Owner:me",
+				"tag_list.1": "Url:https://example.com:8443",
+				"tag_list.2": "Empty:",
+			},
+			want: map[string]string{
+				"gw_name":    "gw1",
+				"tags.%":     "3",
+				"tags.Owner": "me",
+				"tags.Url":   "https://example.com:8443",
+				"tags.Empty": "",
+			},
+		},
+		{
+			name: "tag without value",
+			attrs: map[string]string{
+				"tag_list.#": "2",
+				"tag_list.0": "Owner",
+				"tag_list.1": "Env:test",
+			},
+			want: map[string]string{
+				"tags.%":   "1",
+				"tags.Env": "test",
+			},
+		},
+	} {
+		is := &terraform.InstanceState{ID: "gw1", Attributes: tc.attrs}
+		migrateTagListToTags(is)
+		if !reflect.DeepEqual(is.Attributes, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, is.Attributes)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package aviatrix
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// cloudTypeNames are the names of the cloud types, for error messages.
+var cloudTypeNames = map[int]string{
+	1: "aws",
+	4: "gcp",
+	8: "arm",
+}
+
+// firewallProtocols are the protocols of firewall and VPN profile rules.
+var firewallProtocols = []string{"all", "tcp", "udp", "icmp", "sctp", "rdp", "dccp"}
+
+// validateStringIn returns a SchemaValidateFunc accepting only the given
+// values.
+func validateStringIn(valid ...string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+		for _, s := range valid {
+			if v == s {
+				return nil, nil
+			}
+		}
+		quoted := make([]string, len(valid))
+		for i, s := range valid {
+			quoted[i] = strconv.Quote(s)
+		}
+		return nil, []error{fmt.Errorf("%s can only be one of %s, got %q", k, strings.Join(quoted, ", "), v)}
+	}
+}
+
+// validateStringNotIn returns a SchemaValidateFunc rejecting the given
+// values, e.g. names reserved by the controller.
+func validateStringNotIn(invalid ...string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+		for _, s := range invalid {
+			if v == s {
+				return nil, []error{fmt.Errorf("%s can't be %q", k, v)}
+			}
+		}
+		return nil, nil
+	}
+}
+
+// validateCloudType returns a SchemaValidateFunc accepting only the given
+// cloud types.
+func validateCloudType(valid ...int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(int)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be int", k)}
+		}
+		names := make([]string, len(valid))
+		for i, t := range valid {
+			if v == t {
+				return nil, nil
+			}
+			names[i] = fmt.Sprintf("%s (%d)", cloudTypeNames[t], t)
+		}
+		return nil, []error{fmt.Errorf("invalid %s %d, it can only be %s", k, v, joinChoices(names))}
+	}
+}
+
+// joinChoices joins the choices as "a, b, or c".
+func joinChoices(choices []string) string {
+	if len(choices) < 2 {
+		return strings.Join(choices, "")
+	}
+	return strings.Join(choices[:len(choices)-1], ", ") + ", or " + choices[len(choices)-1]
+}
+
+// validateCIDR accepts a CIDR such as 10.0.0.0/16, or an empty string.
+func validateCIDR(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	if _, _, err := net.ParseCIDR(v); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a valid CIDR, got %q", k, v)}
+	}
+	return nil, nil
+}
+
+// validateIPAddress accepts an IP address, or an empty string.
+func validateIPAddress(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v != "" && net.ParseIP(v) == nil {
+		return nil, []error{fmt.Errorf("%s must be a valid IP address, got %q", k, v)}
+	}
+	return nil, nil
+}
+
+// validateCIDRList accepts a comma separated list of CIDRs, or an empty
+// string.
+func validateCIDRList(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	var errs []error
+	for _, cidr := range strings.Split(v, ",") {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			errs = append(errs, fmt.Errorf("%s must be a comma separated list of valid CIDRs, got %q", k, cidr))
+		}
+	}
+	return nil, errs
+}
+
+// validateASN accepts a BGP AS number, as a string or an int, or an empty
+// string.
+func validateASN(i interface{}, k string) ([]string, []error) {
+	var v string
+	switch i := i.(type) {
+	case string:
+		v = i
+	case int:
+		v = strconv.Itoa(i)
+	default:
+		return nil, []error{fmt.Errorf("expected type of %s to be string or int", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	if asn, err := strconv.ParseUint(v, 10, 32); err != nil || asn == 0 {
+		return nil, []error{fmt.Errorf("%s must be an AS number between 1 and 4294967295, got %q", k, v)}
+	}
+	return nil, nil
+}
+
+// validatePortRange accepts a port, a range of ports written as "low:high",
+// or an empty string.
+func validatePortRange(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	bounds := strings.SplitN(v, ":", 2)
+	var ports []int
+	for _, b := range bounds {
+		port, err := strconv.Atoi(b)
+		if err != nil || port < 0 || port > 65535 {
+			return nil, []error{fmt.Errorf("%s must be a port or a range of ports such as '0:65535', got %q", k, v)}
+		}
+		ports = append(ports, port)
+	}
+	if len(ports) == 2 && ports[0] > ports[1] {
+		return nil, []error{fmt.Errorf("%s must be a port or a range of ports such as '0:65535', got %q", k, v)}
+	}
+	return nil, nil
+}
+
+// validateTags accepts a map of tags the controller's tag list can carry:
+// keys without colons or commas, values without commas.
+func validateTags(i interface{}, k string) ([]string, []error) {
+	tags, ok := i.(map[string]interface{})
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be map", k)}
+	}
+	var errs []error
+	for key, value := range tags {
+		v, _ := value.(string)
+		if err := goaviatrix.ValidateTag(key, v); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", k, err))
+		}
+	}
+	return nil, errs
+}
+
+// diffCond is a condition on the planned values of a resource. known is
+// false if it depends on values not known until apply.
+type diffCond func(d *schema.ResourceDiff) (holds bool, known bool)
+
+// diffRule is a cross-field rule on the planned values of a resource. It
+// returns nil if the rule holds or depends on values not known until apply.
+type diffRule func(d *schema.ResourceDiff, meta interface{}) error
+
+// validateDiff returns a CustomizeDiffFunc checking the rules, so that an
+// invalid configuration fails at plan time instead of during apply, when
+// other resources may already have been changed.
+func validateDiff(rules ...diffRule) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		for _, rule := range rules {
+			if err := rule(d, meta); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// isSet holds if the attribute is set to a value other than its zero value.
+func isSet(key string) diffCond {
+	return func(d *schema.ResourceDiff) (bool, bool) {
+		if !d.NewValueKnown(key) {
+			return false, false
+		}
+		_, ok := d.GetOk(key)
+		return ok, true
+	}
+}
+
+// equals holds if the attribute is set to value.
+func equals(key string, value interface{}) diffCond {
+	return func(d *schema.ResourceDiff) (bool, bool) {
+		if !d.NewValueKnown(key) {
+			return false, false
+		}
+		return reflect.DeepEqual(d.Get(key), value), true
+	}
+}
+
+// sameAs holds if the two attributes are set to the same value.
+func sameAs(key string, other string) diffCond {
+	return func(d *schema.ResourceDiff) (bool, bool) {
+		if !d.NewValueKnown(key) || !d.NewValueKnown(other) {
+			return false, false
+		}
+		return reflect.DeepEqual(d.Get(key), d.Get(other)), true
+	}
+}
+
+// not holds if cond does not.
+func not(cond diffCond) diffCond {
+	return func(d *schema.ResourceDiff) (bool, bool) {
+		holds, known := cond(d)
+		return !holds, known
+	}
+}
+
+// allOf holds if all the conditions hold. It is known as soon as one is
+// known not to hold.
+func allOf(conds ...diffCond) diffCond {
+	return func(d *schema.ResourceDiff) (bool, bool) {
+		known := true
+		for _, cond := range conds {
+			holds, k := cond(d)
+			if k && !holds {
+				return false, true
+			}
+			known = known && k
+		}
+		return known, known
+	}
+}
+
+// anyOf holds if any of the conditions holds. It is known as soon as one is
+// known to hold.
+func anyOf(conds ...diffCond) diffCond {
+	return func(d *schema.ResourceDiff) (bool, bool) {
+		known := true
+		for _, cond := range conds {
+			holds, k := cond(d)
+			if k && holds {
+				return true, true
+			}
+			known = known && k
+		}
+		return false, known
+	}
+}
+
+// forbid fails with message if cond holds.
+func forbid(cond diffCond, message string) diffRule {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if holds, known := cond(d); known && holds {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// requireIf fails with message if cond holds and the attribute is not set.
+func requireIf(cond diffCond, key string, message string) diffRule {
+	return forbid(allOf(cond, not(isSet(key))), message)
+}
+
+// forEach checks every element of the list attribute whose values are all
+// known with check, given the attribute values of the element.
+func forEach(key string, check func(values map[string]interface{}, meta interface{}) error) diffRule {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+		list, _ := d.Get(key).([]interface{})
+	elements:
+		for i, elem := range list {
+			values, ok := elem.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for k := range values {
+				if !d.NewValueKnown(fmt.Sprintf("%s.%d.%s", key, i, k)) {
+					continue elements
+				}
+			}
+			if err := check(values, meta); err != nil {
+				return fmt.Errorf("%s %d: %v", key, i, err)
+			}
+		}
+		return nil
+	}
+}
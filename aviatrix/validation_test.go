@@ -0,0 +1,106 @@
+package aviatrix
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestValidateFuncs(t *testing.T) {
+	cases := []struct {
+		name  string
+		f     schema.SchemaValidateFunc
+		valid []interface{}
+		wrong []interface{}
+	}{
+		{"cidr", validateCIDR, []interface{}{"", "10.0.0.0/16"}, []interface{}{"10.0.0.0", "10.0.0.0/33", "x"}},
+		{"cidr list", validateCIDRList, []interface{}{"", "10.0.0.0/16", "10.0.0.0/16, 10.1.0.0/16"},
+			[]interface{}{"10.0.0.0/16,", "10.0.0.1"}},
+		{"ip address", validateIPAddress, []interface{}{"", "40.0.0.1"}, []interface{}{"40.0.0.0/24", "host"}},
+		{"asn", validateASN, []interface{}{"", "65001", 4294967295}, []interface{}{"0", "4294967296", "as65001", -1}},
+		{"port range", validatePortRange, []interface{}{"", "443", "0:65535"},
+			[]interface{}{"65536", "1024:25", "25-1024", "all"}},
+		{"string in", validateStringIn("", "2", "3"), []interface{}{"", "2", "3"}, []interface{}{"1", 2}},
+		{"string not in", validateStringNotIn("a", "b"), []interface{}{"", "c"}, []interface{}{"a", "b", 1}},
+		{"cloud type", validateCloudType(1, 4, 8), []interface{}{1, 4, 8}, []interface{}{0, 2, "1"}},
+		{"tags", validateTags,
+			[]interface{}{map[string]interface{}{}, map[string]interface{}{"Owner": "me", "Url": "https://example.com:8443"}},
+			[]interface{}{map[string]interface{}{"Env:Name": "test"}, map[string]interface{}{"Owner": "me, you"}, "Owner:me"}},
+	}
+	for _, c := range cases {
+		for _, v := range c.valid {
+			if _, errs := c.f(v, "attr"); len(errs) != 0 {
+				t.Errorf("%s: expected %#v to be valid, got %v", c.name, v, errs)
+			}
+		}
+		for _, v := range c.wrong {
+			if _, errs := c.f(v, "attr"); len(errs) == 0 {
+				t.Errorf("%s: expected %#v to be invalid", c.name, v)
+			}
+		}
+	}
+
+	_, errs := validateCloudType(1, 4, 8)(2, "cloud_type")
+	if len(errs) != 1 || errs[0].Error() != "invalid cloud_type 2, it can only be aws (1), gcp (4), or arm (8)" {
+		t.Errorf("unexpected cloud type error %v", errs)
+	}
+}
+
+func TestAviatrixGateway_planValidation(t *testing.T) {
+	srv := testFakeController(t)
+	rName := acctest.RandString(5)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccGatewayConfigPlanValidation(rName, `tags = { "Env:Name" = "test" }`),
+				ExpectError: regexp.MustCompile(`tag key "Env:Name" must not contain ':' or ','`),
+			},
+			{
+				Config:      testAccGatewayConfigPlanValidation(rName, `otp_mode = "4"`),
+				ExpectError: regexp.MustCompile(`otp_mode can only be one of "", "2", "3", got "4"`),
+			},
+			{
+				Config:      testAccGatewayConfigPlanValidation(rName, `vpn_cidr = "192.168.43.0"`),
+				ExpectError: regexp.MustCompile(`vpn_cidr must be a valid CIDR, got "192.168.43.0"`),
+			},
+			{
+				Config:      testAccGatewayConfigPlanValidation(rName, "enable_ldap = true"),
+				ExpectError: regexp.MustCompile("ldap and mfa can't be configured if saml is enabled"),
+			},
+		},
+	})
+	if got := srv.Calls("setup_account_profile"); got != 0 {
+		t.Errorf("expected the plan to fail before the account is created, got %d calls", got)
+	}
+}
+
+func testAccGatewayConfigPlanValidation(rName string, extra string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test" {
+	account_name       = "tf-acc-%[1]s"
+	cloud_type         = 1
+	aws_account_number = "123456789012"
+	aws_iam            = false
+	aws_access_key     = "AKIAFAKEACCESSKEY"
+	aws_secret_key     = "fake-secret-key"
+}
+resource "aviatrix_gateway" "test" {
+	cloud_type   = 1
+	account_name = aviatrix_account.test.account_name
+	gw_name      = "tf-testing-%[1]s"
+	vpc_id       = "vpc-0123456789abcdef0"
+	vpc_reg      = "us-east-1"
+	gw_size      = "t2.micro"
+	subnet       = "10.0.0.0/24"
+	vpn_access   = true
+	saml_enabled = true
+	%[2]s
+}
+	`, rName, extra)
+}
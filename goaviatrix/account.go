@@ -1,11 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
+	"context"
 	"log"
-	"net/url"
 )
 
 type Account struct {
@@ -47,52 +44,24 @@ type AccountResult struct {
 	AccountList []Account `json:"account_list"`
 }
 
-type AccountListResp struct {
-	Return  bool          `json:"return"`
-	Results AccountResult `json:"results"`
-	Reason  string        `json:"reason"`
+func (c *Client) CreateAccount(account *Account) error {
+	return c.CreateAccountContext(c.Context(), account)
 }
 
-func (c *Client) CreateAccount(account *Account) error {
-	account.CID = c.CID
-	account.Action = "setup_account_profile"
-	resp, err := c.Post(c.baseURL, account)
-	if err != nil {
-		return errors.New("HTTP Post setup_account_profile failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode setup_account_profile failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API setup_account_profile Post failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) CreateAccountContext(ctx context.Context, account *Account) error {
+	return c.PostAPIContext(ctx, "setup_account_profile", account, nil)
 }
 
 func (c *Client) GetAccount(account *Account) (*Account, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New("url Parsing failed for list_accounts " + err.Error())
-	}
-	listAccounts := url.Values{}
-	listAccounts.Add("CID", c.CID)
-	listAccounts.Add("action", "list_accounts")
-	Url.RawQuery = listAccounts.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return nil, errors.New("HTTP Get list_accounts failed: " + err.Error())
-	}
-	var data AccountListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode  failed: " + err.Error())
-	}
+	return c.GetAccountContext(c.Context(), account)
+}
 
-	if !data.Return {
-		return nil, errors.New("Rest API list_accounts Get failed: " + data.Reason)
+func (c *Client) GetAccountContext(ctx context.Context, account *Account) (*Account, error) {
+	var data AccountResult
+	if err := c.GetAPIContext(ctx, "list_accounts", nil, &data); err != nil {
+		return nil, err
 	}
-	accList := data.Results.AccountList
+	accList := data.AccountList
 	for i := range accList {
 		if accList[i].AccountName == account.AccountName {
 			log.Printf("[INFO] Found Aviatrix Account %s", account.AccountName)
@@ -104,52 +73,28 @@ func (c *Client) GetAccount(account *Account) (*Account, error) {
 }
 
 func (c *Client) UpdateAccount(account *Account) error {
-	account.CID = c.CID
-	account.Action = "edit_account_profile"
-	resp, err := c.Post(c.baseURL, account)
-	if err != nil {
-		return errors.New("HTTP Post edit_account_profile failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode edit_account_profile failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API edit_account_profile Post failed: " + data.Reason)
-	}
-	return nil
+	return c.UpdateAccountContext(c.Context(), account)
+}
+
+func (c *Client) UpdateAccountContext(ctx context.Context, account *Account) error {
+	return c.PostAPIContext(ctx, "edit_account_profile", account, nil)
 }
 
 func (c *Client) DeleteAccount(account *Account) error {
-	path := c.baseURL + fmt.Sprintf("?action=delete_account_profile&CID=%s&account_name=%s",
-		c.CID, account.AccountName)
-	resp, err := c.Delete(path, nil)
-	if err != nil {
-		return errors.New("HTTP delete_account_profile failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_account_profile failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_account_profile Post failed: " + data.Reason)
+	return c.DeleteAccountContext(c.Context(), account)
+}
+
+func (c *Client) DeleteAccountContext(ctx context.Context, account *Account) error {
+	params := map[string]string{
+		"account_name": account.AccountName,
 	}
-	return nil
+	return c.GetAPIContext(ctx, "delete_account_profile", params, nil)
 }
 
 func (c *Client) UploadGcloudProjectCredentialsFile(account *Account) error {
-	account.CID = c.CID
-	account.Action = "upload_file"
-	resp, err := c.Post(c.baseURL, account)
-	if err != nil {
-		return errors.New("HTTP Post upload_file failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode upload_file failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API upload_file Post failed: " + data.Reason)
-	}
-	return nil
+	return c.UploadGcloudProjectCredentialsFileContext(c.Context(), account)
+}
+
+func (c *Client) UploadGcloudProjectCredentialsFileContext(ctx context.Context, account *Account) error {
+	return c.PostAPIContext(ctx, "upload_file", account, nil)
 }
@@ -1,10 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	"net/url"
 )
 
 type AccountUser struct {
@@ -27,52 +25,23 @@ type AccountUserEdit struct {
 	NewPassword string `form:"new_password,omitempty" json:"new_password,omitempty"`
 }
 
-type AccountUserListResp struct {
-	Return          bool          `json:"return"`
-	AccountUserList []AccountUser `json:"results"`
-	Reason          string        `json:"reason"`
+func (c *Client) CreateAccountUser(user *AccountUser) error {
+	return c.CreateAccountUserContext(c.Context(), user)
 }
 
-func (c *Client) CreateAccountUser(user *AccountUser) error {
-	user.CID = c.CID
-	user.Action = "add_account_user"
-	resp, err := c.Post(c.baseURL, user)
-	if err != nil {
-		return errors.New("HTTP Post add_account_user failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_account_user failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_account_user Post failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) CreateAccountUserContext(ctx context.Context, user *AccountUser) error {
+	return c.PostAPIContext(ctx, "add_account_user", user, nil)
 }
 
 func (c *Client) GetAccountUser(user *AccountUser) (*AccountUser, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_account_users ") + err.Error())
-	}
-	listAccountUsers := url.Values{}
-	listAccountUsers.Add("CID", c.CID)
-	listAccountUsers.Add("action", "list_account_users")
-	Url.RawQuery = listAccountUsers.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return nil, errors.New("HTTP Get list_account_users failed: " + err.Error())
-	}
-	var data AccountUserListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_account_users failed: " + err.Error())
-	}
+	return c.GetAccountUserContext(c.Context(), user)
+}
 
-	if !data.Return {
-		return nil, errors.New("Rest API enable_transit_ha Get failed: " + data.Reason)
+func (c *Client) GetAccountUserContext(ctx context.Context, user *AccountUser) (*AccountUser, error) {
+	var users []AccountUser
+	if err := c.GetAPIContext(ctx, "list_account_users", nil, &users); err != nil {
+		return nil, err
 	}
-	users := data.AccountUserList
 	for i := range users {
 		if users[i].UserName == user.UserName {
 			log.Printf("[INFO] Found Aviatrix user account %s", user.UserName)
@@ -81,47 +50,23 @@ func (c *Client) GetAccountUser(user *AccountUser) (*AccountUser, error) {
 	}
 	log.Printf("Couldn't find Aviatrix user account %s", user.UserName)
 	return nil, ErrNotFound
-
 }
 
 func (c *Client) UpdateAccountUserObject(user *AccountUserEdit) error {
-	user.CID = c.CID
-	user.Action = "edit_account_user"
-	resp, err := c.Post(c.baseURL, user)
-	if err != nil {
-		return errors.New("HTTP Post edit_account_user failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode edit_account_user failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API edit_account_user Post failed: " + data.Reason)
-	}
-	return nil
+	return c.UpdateAccountUserObjectContext(c.Context(), user)
+}
+
+func (c *Client) UpdateAccountUserObjectContext(ctx context.Context, user *AccountUserEdit) error {
+	return c.PostAPIContext(ctx, "edit_account_user", user, nil)
 }
 
 func (c *Client) DeleteAccountUser(user *AccountUser) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for delete_account_user ") + err.Error())
-	}
-	deleteAccountUsers := url.Values{}
-	deleteAccountUsers.Add("CID", c.CID)
-	deleteAccountUsers.Add("action", "delete_account_user")
-	deleteAccountUsers.Add("username", user.UserName)
-	Url.RawQuery = deleteAccountUsers.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteAccountUserContext(c.Context(), user)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get delete_account_user failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_account_user failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_account_user Get failed: " + data.Reason)
+func (c *Client) DeleteAccountUserContext(ctx context.Context, user *AccountUser) error {
+	params := map[string]string{
+		"username": user.UserName,
 	}
-	return nil
+	return c.GetAPIContext(ctx, "delete_account_user", params, nil)
 }
@@ -1,10 +1,9 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"log"
-	"net/url"
 )
 
 // ARMPeer simple struct to hold arm_peer details
@@ -21,82 +20,53 @@ type ARMPeer struct {
 	VNetCidr2    []string
 }
 
-type ArmPeerAPIResp struct {
-	Return  bool   `json:"return"`
-	Reason  string `json:"reason"`
-	Results string `json:"results"`
+type armPeerEnd struct {
+	VpcID       string   `json:"vpc_id"`
+	AccountName string   `json:"account_name"`
+	Region      string   `json:"region"`
+	VpcCidr     []string `json:"vpc_cidr"`
+}
+
+type armPeerPair struct {
+	Requester armPeerEnd `json:"requester"`
+	Accepter  armPeerEnd `json:"accepter"`
 }
 
 func (c *Client) CreateARMPeer(armPeer *ARMPeer) error {
-	armPeer.CID = c.CID
-	armPeer.Action = "arm_peer_vnet_pair"
-	resp, err := c.Post(c.baseURL, armPeer)
-	if err != nil {
-		return errors.New("HTTP Post arm_peer_vnet_pair failed: " + err.Error())
-	}
-	var data ArmPeerAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode arm_peer_vnet_pair failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API arm_peer_vnet_pair Post failed: " + data.Reason)
-	}
+	return c.CreateARMPeerContext(c.Context(), armPeer)
+}
 
-	return nil
+func (c *Client) CreateARMPeerContext(ctx context.Context, armPeer *ARMPeer) error {
+	return c.PostAPIContext(ctx, "arm_peer_vnet_pair", armPeer, nil)
 }
 
 func (c *Client) GetARMPeer(armPeer *ARMPeer) (*ARMPeer, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_arm_peer_vnet_pairs ") + err.Error())
-	}
-	listArmPeering := url.Values{}
-	listArmPeering.Add("CID", c.CID)
-	listArmPeering.Add("action", "list_arm_peer_vnet_pairs")
-	Url.RawQuery = listArmPeering.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetARMPeerContext(c.Context(), armPeer)
+}
 
+func (c *Client) GetARMPeerContext(ctx context.Context, armPeer *ARMPeer) (*ARMPeer, error) {
+	var pairList []armPeerPair
+	err := c.GetAPIContext(ctx, "list_arm_peer_vnet_pairs", nil, &pairList)
 	if err != nil {
-		return nil, errors.New("HTTP Get list_arm_peer_vnet_pairs failed: " + err.Error())
-	}
-	var data map[string]interface{}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_arm_peer_vnet_pairs failed: " + err.Error())
-	}
-	if _, ok := data["reason"]; ok {
-		log.Printf("[INFO] Couldn't find ARM peering between VPCs %s and %s: %s", armPeer.VNet1, armPeer.VNet2, data["reason"])
-		return nil, ErrNotFound
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			log.Printf("[INFO] Couldn't find ARM peering between VPCs %s and %s: %s", armPeer.VNet1, armPeer.VNet2, apiErr.Reason)
+			return nil, ErrNotFound
+		}
+		return nil, err
 	}
-	if val, ok := data["results"]; ok {
-		pairList := val.(interface{}).([]interface{})
-		for i := range pairList {
-			if pairList[i].(map[string]interface{})["requester"].(map[string]interface{})["vpc_id"].(string) == armPeer.VNet1 &&
-				pairList[i].(map[string]interface{})["accepter"].(map[string]interface{})["vpc_id"].(string) == armPeer.VNet2 {
-				armPeer := &ARMPeer{
-					VNet1:        pairList[i].(map[string]interface{})["requester"].(map[string]interface{})["vpc_id"].(string),
-					VNet2:        pairList[i].(map[string]interface{})["accepter"].(map[string]interface{})["vpc_id"].(string),
-					AccountName1: pairList[i].(map[string]interface{})["requester"].(map[string]interface{})["account_name"].(string),
-					AccountName2: pairList[i].(map[string]interface{})["accepter"].(map[string]interface{})["account_name"].(string),
-					Region1:      pairList[i].(map[string]interface{})["requester"].(map[string]interface{})["region"].(string),
-					Region2:      pairList[i].(map[string]interface{})["accepter"].(map[string]interface{})["region"].(string),
-				}
-
-				vnetCidrList1 := pairList[i].(map[string]interface{})["requester"].(map[string]interface{})["vpc_cidr"].([]interface{})
-				var vnetCidr1 []string
-				for i := range vnetCidrList1 {
-					vnetCidr1 = append(vnetCidr1, vnetCidrList1[i].(interface{}).(string))
-				}
-				armPeer.VNetCidr1 = vnetCidr1
-
-				vnetCidrList2 := pairList[i].(map[string]interface{})["accepter"].(map[string]interface{})["vpc_cidr"].([]interface{})
-				var vnetCidr2 []string
-				for i := range vnetCidrList2 {
-					vnetCidr2 = append(vnetCidr2, vnetCidrList2[i].(interface{}).(string))
-				}
-				armPeer.VNetCidr2 = vnetCidr2
-
-				return armPeer, nil
-			}
+	for _, pair := range pairList {
+		if pair.Requester.VpcID == armPeer.VNet1 && pair.Accepter.VpcID == armPeer.VNet2 {
+			return &ARMPeer{
+				VNet1:        pair.Requester.VpcID,
+				VNet2:        pair.Accepter.VpcID,
+				AccountName1: pair.Requester.AccountName,
+				AccountName2: pair.Accepter.AccountName,
+				Region1:      pair.Requester.Region,
+				Region2:      pair.Accepter.Region,
+				VNetCidr1:    pair.Requester.VpcCidr,
+				VNetCidr2:    pair.Accepter.VpcCidr,
+			}, nil
 		}
 	}
 	return nil, ErrNotFound
@@ -107,28 +77,13 @@ func (c *Client) UpdateARMPeer(armPeer *ARMPeer) error {
 }
 
 func (c *Client) DeleteARMPeer(armPeer *ARMPeer) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for arm_unpeer_vnet_pair") + err.Error())
-	}
-	armUnpeerVNetPair := url.Values{}
-	armUnpeerVNetPair.Add("CID", c.CID)
-	armUnpeerVNetPair.Add("action", "arm_unpeer_vnet_pair")
-	armUnpeerVNetPair.Add("vpc_name1", armPeer.VNet1)
-	armUnpeerVNetPair.Add("vpc_name2", armPeer.VNet2)
-	Url.RawQuery = armUnpeerVNetPair.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Post arm_unpeer_vnet_pair failed: " + err.Error())
-	}
-
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode arm_unpeer_vnet_pair failed: " + err.Error())
-	}
+	return c.DeleteARMPeerContext(c.Context(), armPeer)
+}
 
-	if !data.Return {
-		return errors.New("Rest API arm_unpeer_vnet_pair Post failed: " + data.Reason)
+func (c *Client) DeleteARMPeerContext(ctx context.Context, armPeer *ARMPeer) error {
+	params := map[string]string{
+		"vpc_name1": armPeer.VNet1,
+		"vpc_name2": armPeer.VNet2,
 	}
-	return nil
+	return c.GetAPIContext(ctx, "arm_unpeer_vnet_pair", params, nil)
 }
@@ -1,10 +1,9 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"log"
-	"net/url"
 	"regexp"
 )
 
@@ -22,73 +21,58 @@ type AWSPeer struct {
 	RtbList2     string `form:"peer2_rtb_id,omitempty"`
 }
 
-type AwsPeerAPIResp struct {
-	Return  bool              `json:"return"`
-	Reason  string            `json:"reason"`
-	Results map[string]string `json:"results"`
+type awsPeerEnd struct {
+	VpcID       string `json:"vpc_id"`
+	AccountName string `json:"account_name"`
+	Region      string `json:"region"`
+}
+
+type awsPeerList struct {
+	PairList []struct {
+		Requester awsPeerEnd `json:"requester"`
+		Accepter  awsPeerEnd `json:"accepter"`
+	} `json:"pair_list"`
 }
 
 func (c *Client) CreateAWSPeer(awsPeer *AWSPeer) (string, error) {
-	awsPeer.CID = c.CID
-	awsPeer.Action = "create_aws_peering"
-	resp, err := c.Post(c.baseURL, awsPeer)
-	if err != nil {
-		return "", errors.New("HTTP Post create_aws_peering failed: " + err.Error())
-	}
-	var data AwsPeerAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", errors.New("Json Decode create_aws_peering failed: " + err.Error())
-	}
-	if !data.Return {
-		return "", errors.New("Rest API create_aws_peering Post failed: " + data.Reason)
+	return c.CreateAWSPeerContext(c.Context(), awsPeer)
+}
+
+func (c *Client) CreateAWSPeerContext(ctx context.Context, awsPeer *AWSPeer) (string, error) {
+	var data map[string]string
+	if err := c.PostAPIContext(ctx, "create_aws_peering", awsPeer, &data); err != nil {
+		return "", err
 	}
 	r, _ := regexp.Compile(`pcx-\w+`)
-	id := r.FindString(data.Results["text"])
+	id := r.FindString(data["text"])
 	return id, nil
 }
 
 func (c *Client) GetAWSPeer(awsPeer *AWSPeer) (*AWSPeer, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_aws_peerings ") + err.Error())
-	}
-	listAwsPeering := url.Values{}
-	listAwsPeering.Add("CID", c.CID)
-	listAwsPeering.Add("action", "list_aws_peerings")
-	Url.RawQuery = listAwsPeering.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetAWSPeerContext(c.Context(), awsPeer)
+}
 
+func (c *Client) GetAWSPeerContext(ctx context.Context, awsPeer *AWSPeer) (*AWSPeer, error) {
+	var data awsPeerList
+	err := c.GetAPIContext(ctx, "list_aws_peerings", nil, &data)
 	if err != nil {
-		return nil, errors.New("HTTP Get list_aws_peerings failed: " + err.Error())
-	}
-
-	//Output result for this query cannot be unmarshalled
-	//easily into our defined struct AWSPeer.
-	//So using a map of string->interface{}
-	var data map[string]interface{}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_aws_peerings failed: " + err.Error())
-	}
-	if _, ok := data["reason"]; ok {
-		log.Printf("[INFO] Couldn't find AWS peering between VPCs %s and %s: %s", awsPeer.VpcID1, awsPeer.VpcID2, data["reason"])
-		return nil, ErrNotFound
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			log.Printf("[INFO] Couldn't find AWS peering between VPCs %s and %s: %s", awsPeer.VpcID1, awsPeer.VpcID2, apiErr.Reason)
+			return nil, ErrNotFound
+		}
+		return nil, err
 	}
-	if val, ok := data["results"]; ok {
-		if pairList, ok1 := val.(map[string]interface{})["pair_list"].([]interface{}); ok1 {
-			for i := range pairList {
-				if pairList[i].(map[string]interface{})["requester"].(map[string]interface{})["vpc_id"].(string) == awsPeer.VpcID1 &&
-					pairList[i].(map[string]interface{})["accepter"].(map[string]interface{})["vpc_id"].(string) == awsPeer.VpcID2 {
-					awsPeer := &AWSPeer{
-						VpcID1:       pairList[i].(map[string]interface{})["requester"].(map[string]interface{})["vpc_id"].(string),
-						VpcID2:       pairList[i].(map[string]interface{})["accepter"].(map[string]interface{})["vpc_id"].(string),
-						AccountName1: pairList[i].(map[string]interface{})["requester"].(map[string]interface{})["account_name"].(string),
-						AccountName2: pairList[i].(map[string]interface{})["accepter"].(map[string]interface{})["account_name"].(string),
-						Region1:      pairList[i].(map[string]interface{})["requester"].(map[string]interface{})["region"].(string),
-						Region2:      pairList[i].(map[string]interface{})["accepter"].(map[string]interface{})["region"].(string),
-					}
-					return awsPeer, nil
-				}
-			}
+	for _, pair := range data.PairList {
+		if pair.Requester.VpcID == awsPeer.VpcID1 && pair.Accepter.VpcID == awsPeer.VpcID2 {
+			return &AWSPeer{
+				VpcID1:       pair.Requester.VpcID,
+				VpcID2:       pair.Accepter.VpcID,
+				AccountName1: pair.Requester.AccountName,
+				AccountName2: pair.Accepter.AccountName,
+				Region1:      pair.Requester.Region,
+				Region2:      pair.Accepter.Region,
+			}, nil
 		}
 	}
 	log.Printf("[INFO] No AWS peering between VPC %s and %s is present.", awsPeer.VpcID1, awsPeer.VpcID2)
@@ -100,19 +84,9 @@ func (c *Client) UpdateAWSPeer(awsPeer *AWSPeer) error {
 }
 
 func (c *Client) DeleteAWSPeer(awsPeer *AWSPeer) error {
-	awsPeer.CID = c.CID
-	awsPeer.Action = "delete_aws_peering"
-	resp, err := c.Post(c.baseURL, awsPeer)
-	if err != nil {
-		return errors.New("HTTP Post delete_aws_peering failed: " + err.Error())
-	}
+	return c.DeleteAWSPeerContext(c.Context(), awsPeer)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_aws_peering failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_aws_peering Post failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) DeleteAWSPeerContext(ctx context.Context, awsPeer *AWSPeer) error {
+	return c.PostAPIContext(ctx, "delete_aws_peering", awsPeer, nil)
 }
@@ -1,11 +1,10 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	"net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -24,24 +23,6 @@ type AWSTgw struct {
 	ManageVpcAttachment       string
 }
 
-type AWSTgwAPIResp struct {
-	Return  bool     `json:"return"`
-	Results []string `json:"results"`
-	Reason  string   `json:"reason"`
-}
-
-type AWSTgwList struct {
-	Return  bool     `json:"return"`
-	Results []AWSTgw `json:"results"`
-	Reason  string   `json:"reason"`
-}
-
-type RouteDomainAPIResp struct {
-	Return  bool                `json:"return"`
-	Results []RouteDomainDetail `json:"results"`
-	Reason  string              `json:"reason"`
-}
-
 type RouteDomainDetail struct {
 	Associations         []string             `json:"associations"`
 	Name                 string               `json:"name"`
@@ -70,12 +51,6 @@ type RoutesInRouteTable struct {
 	TgwAttachmentId string `json:"tgw_attachment_id"`
 }
 
-type VPCList struct {
-	Return  bool      `json:"return"`
-	Results []VPCInfo `json:"results"`
-	Reason  string    `json:"reason"`
-}
-
 type VPCInfo struct {
 	AccountName string `json:"account_name,omitempty"`
 	CloudType   int    `json:"cloud_type,omitempty"`
@@ -85,12 +60,6 @@ type VPCInfo struct {
 	VPCId       string `json:"vpc_id,omitempty"`
 }
 
-type TGWInfoResp struct {
-	Return  bool        `json:"return"`
-	Results TGWInfoList `json:"results"`
-	Reason  string      `json:"reason"`
-}
-
 type TGWInfoList struct {
 	TgwInfo TgwInfoDetail `json:"tgw_info"`
 	TgwID   string        `json:"_id"`
@@ -103,109 +72,43 @@ type TgwInfoDetail struct {
 	AwsSideAsNumber int    `json:"tgw_aws_asn"`
 }
 
-type listAttachedVpcNamesResp struct {
-	Return  bool     `json:"return"`
-	Results []string `json:"results"`
-	Reason  string   `json:"reason"`
-}
-
 func (c *Client) CreateAWSTgw(awsTgw *AWSTgw) error {
-	awsTgw.CID = c.CID
-	awsTgw.Action = "add_aws_tgw"
-	resp, err := c.Post(c.baseURL, awsTgw)
-	if err != nil {
-		return errors.New("HTTP Post add_aws_tgw failed: " + err.Error())
-	}
-
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_aws_tgw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_aws_tgw Post failed: " + data.Reason)
-	}
+	return c.CreateAWSTgwContext(c.Context(), awsTgw)
+}
 
-	return nil
+func (c *Client) CreateAWSTgwContext(ctx context.Context, awsTgw *AWSTgw) error {
+	return c.PostAPIContext(ctx, "add_aws_tgw", awsTgw, nil)
 }
 
 func (c *Client) GetAWSTgw(awsTgw *AWSTgw) (*AWSTgw, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_route_domain_names") + err.Error())
-	}
-	listRouteDomainNames := url.Values{}
-	listRouteDomainNames.Add("CID", c.CID)
-	listRouteDomainNames.Add("action", "list_route_domain_names")
-	listRouteDomainNames.Add("tgw_name", awsTgw.Name)
-	Url.RawQuery = listRouteDomainNames.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetAWSTgwContext(c.Context(), awsTgw)
+}
 
+func (c *Client) GetAWSTgwContext(ctx context.Context, awsTgw *AWSTgw) (*AWSTgw, error) {
+	routeDomainDetails, err := c.ListRouteDomainDetailsContext(ctx, awsTgw)
 	if err != nil {
-		return nil, errors.New("HTTP Get list_route_domain_names failed: " + err.Error())
-	}
-
-	data := AWSTgwAPIResp{
-		Return:  false,
-		Results: make([]string, 0),
-		Reason:  "",
+		return nil, err
 	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_route_domain_names failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_route_domain_names Get failed: " + data.Reason)
-	}
-
-	connectedDomainList := data.Results
-	connectedDomainList = append([]string{"Aviatrix_Edge_Domain"}, connectedDomainList...)
-
-	for i := range connectedDomainList {
-		dm := connectedDomainList[i]
-
-		viewRouteDomainDetails := url.Values{}
-		viewRouteDomainDetails.Add("CID", c.CID)
-		viewRouteDomainDetails.Add("action", "view_route_domain_details")
-		viewRouteDomainDetails.Add("tgw_name", awsTgw.Name)
-		viewRouteDomainDetails.Add("route_domain_name", dm)
-
-		Url.RawQuery = viewRouteDomainDetails.Encode()
-		resp, err := c.Get(Url.String(), nil)
-
-		if err != nil {
-			return nil, errors.New("HTTP Get view_route_domain_details failed: " + err.Error())
-		}
-
-		var data1 RouteDomainAPIResp
-		if err = json.NewDecoder(resp.Body).Decode(&data1); err != nil {
-			return nil, errors.New("Json Decode view_route_domain_details failed: " + err.Error())
-		}
-		if !data1.Return {
-			return nil, errors.New("Rest API view_route_domain_details Get failed: " + data1.Reason)
-		}
-		routeDomainDetail := data1.Results
 
+	for _, routeDomainDetail := range routeDomainDetails {
 		sdr := SecurityDomainRule{
-			Name: routeDomainDetail[0].Name,
+			Name: routeDomainDetail.Name,
 		}
-		for i := range routeDomainDetail[0].ConnectedRouteDomain {
-			sdr.ConnectedDomain = append(sdr.ConnectedDomain, routeDomainDetail[0].ConnectedRouteDomain[i])
-		}
-
-		attachedVPCs := routeDomainDetail[0].AttachedVPC
-		for i := range attachedVPCs {
+		sdr.ConnectedDomain = append(sdr.ConnectedDomain, routeDomainDetail.ConnectedRouteDomain...)
 
-			if dm != "Aviatrix_Edge_Domain" {
+		for _, attachedVPC := range routeDomainDetail.AttachedVPC {
+			if routeDomainDetail.Name != "Aviatrix_Edge_Domain" {
 				vpcSolo := VPCSolo{
-					Region:      attachedVPCs[i].Region,
-					AccountName: attachedVPCs[i].AccountName,
-					VpcID:       attachedVPCs[i].VPCId,
+					Region:      attachedVPC.Region,
+					AccountName: attachedVPC.AccountName,
+					VpcID:       attachedVPC.VPCId,
 				}
 				sdr.AttachedVPCs = append(sdr.AttachedVPCs, vpcSolo)
 			} else {
 				gateway := &Gateway{
-					VpcID: attachedVPCs[i].VPCId,
+					VpcID: attachedVPC.VPCId,
 				}
-				gateway, err = c.GetTransitGwFromVpcID(gateway)
+				gateway, err = c.GetTransitGwFromVpcIDContext(ctx, gateway)
 				if err != nil {
 					return nil, err
 				}
@@ -219,29 +122,60 @@ func (c *Client) GetAWSTgw(awsTgw *AWSTgw) (*AWSTgw, error) {
 	return awsTgw, nil
 }
 
-func (c *Client) UpdateAWSTgw(awsTgw *AWSTgw) error {
-	return nil
+func (c *Client) getRouteDomainDetails(ctx context.Context, tgwName string, routeDomainName string) ([]RouteDomainDetail, error) {
+	params := map[string]string{
+		"tgw_name":          tgwName,
+		"route_domain_name": routeDomainName,
+	}
+	var routeDomainDetail []RouteDomainDetail
+	if err := c.GetAPIContext(ctx, "view_route_domain_details", params, &routeDomainDetail); err != nil {
+		return nil, err
+	}
+	if len(routeDomainDetail) == 0 {
+		return nil, ErrNotFound
+	}
+	return routeDomainDetail, nil
 }
 
-func (c *Client) DeleteAWSTgw(awsTgw *AWSTgw) error {
-	awsTgw.CID = c.CID
-	awsTgw.Action = "delete_aws_tgw"
-	resp, err := c.Post(c.baseURL, awsTgw)
-	if err != nil {
-		return errors.New("HTTP Post delete_aws_tgw failed: " + err.Error())
-	}
+// ListRouteDomainDetails returns the details of all the security domains of
+// the AWS TGW, starting with Aviatrix_Edge_Domain.
+func (c *Client) ListRouteDomainDetails(awsTgw *AWSTgw) ([]RouteDomainDetail, error) {
+	return c.ListRouteDomainDetailsContext(c.Context(), awsTgw)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_aws_tgw failed: " + err.Error())
+func (c *Client) ListRouteDomainDetailsContext(ctx context.Context, awsTgw *AWSTgw) ([]RouteDomainDetail, error) {
+	params := map[string]string{
+		"tgw_name": awsTgw.Name,
 	}
-	if !data.Return {
-		return errors.New("Rest API delete_aws_tgw Get failed: " + data.Reason)
+	var domainList []string
+	if err := c.GetAPIContext(ctx, "list_route_domain_names", params, &domainList); err != nil {
+		return nil, err
 	}
+	domainList = append([]string{"Aviatrix_Edge_Domain"}, domainList...)
 
+	var routeDomainDetails []RouteDomainDetail
+	for _, dm := range domainList {
+		routeDomainDetail, err := c.getRouteDomainDetails(ctx, awsTgw.Name, dm)
+		if err != nil {
+			return nil, err
+		}
+		routeDomainDetails = append(routeDomainDetails, routeDomainDetail[0])
+	}
+	return routeDomainDetails, nil
+}
+
+func (c *Client) UpdateAWSTgw(awsTgw *AWSTgw) error {
 	return nil
 }
 
+func (c *Client) DeleteAWSTgw(awsTgw *AWSTgw) error {
+	return c.DeleteAWSTgwContext(c.Context(), awsTgw)
+}
+
+func (c *Client) DeleteAWSTgwContext(ctx context.Context, awsTgw *AWSTgw) error {
+	return c.PostAPIContext(ctx, "delete_aws_tgw", awsTgw, nil)
+}
+
 func (c *Client) ValidateAWSTgwDomains(domainsAll []string, domainConnAll [][]string, attachedVPCAll [][]string,
 ) ([]string, [][]string, [][]string, error) {
 
@@ -354,165 +288,101 @@ func (c *Client) ValidateAWSTgwDomains(domainsAll []string, domainConnAll [][]st
 }
 
 func (c *Client) AttachAviatrixTransitGWToAWSTgw(awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
-	transitGw, err := c.GetGateway(gateway)
-	if err != nil {
-		return err
-	}
-
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for attach_vpc_to_tgw") + err.Error())
-	}
-	attachVpcToTgw := url.Values{}
-	attachVpcToTgw.Add("CID", c.CID)
-	attachVpcToTgw.Add("action", "attach_vpc_to_tgw")
-	attachVpcToTgw.Add("region", awsTgw.Region)
-	attachVpcToTgw.Add("vpc_account_name", transitGw.AccountName)
-	attachVpcToTgw.Add("vpc_name", transitGw.VpcID)
-	attachVpcToTgw.Add("gateway_name", transitGw.GwName)
-	attachVpcToTgw.Add("tgw_account_name", awsTgw.AccountName)
-	attachVpcToTgw.Add("tgw_name", awsTgw.Name)
-	attachVpcToTgw.Add("route_domain_name", SecurityDomainName)
-	Url.RawQuery = attachVpcToTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.AttachAviatrixTransitGWToAWSTgwContext(c.Context(), awsTgw, gateway, SecurityDomainName)
+}
 
+func (c *Client) AttachAviatrixTransitGWToAWSTgwContext(ctx context.Context, awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
+	transitGw, err := c.GetGatewayContext(ctx, gateway)
 	if err != nil {
-		return errors.New("HTTP Get attach_vpc_to_tgw failed: " + err.Error())
+		return err
 	}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode attach_vpc_to_tgw failed: " + err.Error())
+	params := map[string]string{
+		"region":            awsTgw.Region,
+		"vpc_account_name":  transitGw.AccountName,
+		"vpc_name":          transitGw.VpcID,
+		"gateway_name":      transitGw.GwName,
+		"tgw_account_name":  awsTgw.AccountName,
+		"tgw_name":          awsTgw.Name,
+		"route_domain_name": SecurityDomainName,
 	}
-	if !data.Return {
-		return errors.New("Rest API attach_vpc_to_tgw Get failed: " + data.Reason)
-	}
-
-	return nil
+	return c.GetAPIContext(ctx, "attach_vpc_to_tgw", params, nil)
 }
 
 func (c *Client) DetachAviatrixTransitGWFromAWSTgw(awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
-	transitGw, err := c.GetGateway(gateway)
+	return c.DetachAviatrixTransitGWFromAWSTgwContext(c.Context(), awsTgw, gateway, SecurityDomainName)
+}
 
+func (c *Client) DetachAviatrixTransitGWFromAWSTgwContext(ctx context.Context, awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
+	transitGw, err := c.GetGatewayContext(ctx, gateway)
 	if err != nil {
 		return err
 	}
 
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for detach_vpc_from_tgw") + err.Error())
-	}
-	detachVpcFromTgw := url.Values{}
-	detachVpcFromTgw.Add("CID", c.CID)
-	detachVpcFromTgw.Add("action", "detach_vpc_from_tgw")
-	detachVpcFromTgw.Add("tgw_name", awsTgw.Name)
-	detachVpcFromTgw.Add("vpc_name", transitGw.VpcID)
-	Url.RawQuery = detachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DetachVpcFromAWSTgwContext(ctx, awsTgw, transitGw.VpcID)
+}
+
+// GetAviatrixTransitGWAttachment returns ErrNotFound unless the Aviatrix
+// transit gateway is attached to the security domain of the AWS TGW.
+func (c *Client) GetAviatrixTransitGWAttachment(awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
+	return c.GetAviatrixTransitGWAttachmentContext(c.Context(), awsTgw, gateway, SecurityDomainName)
+}
 
+func (c *Client) GetAviatrixTransitGWAttachmentContext(ctx context.Context, awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
+	transitGw, err := c.GetGatewayContext(ctx, gateway)
 	if err != nil {
-		return errors.New("HTTP Get detach_vpc_from_tgw failed: " + err.Error())
+		return err
 	}
+	vpcID := strings.Split(transitGw.VpcID, "~~")[0]
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_vpc_from_tgw failed: " + err.Error())
+	routeDomainDetail, err := c.getRouteDomainDetails(ctx, awsTgw.Name, SecurityDomainName)
+	if err != nil {
+		return err
 	}
-	if !data.Return {
-		return errors.New("Rest API detach_vpc_from_tgw Get failed: " + data.Reason)
+	for _, attachedVPC := range routeDomainDetail[0].AttachedVPC {
+		if attachedVPC.VPCId == vpcID {
+			return nil
+		}
 	}
-
-	return nil
+	return ErrNotFound
 }
 
 func (c *Client) AttachVpcToAWSTgw(awsTgw *AWSTgw, vpcSolo VPCSolo, SecurityDomainName string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for attach_vpc_to_tgw") + err.Error())
-	}
-	attachVpcFromTgw := url.Values{}
-	attachVpcFromTgw.Add("CID", c.CID)
-	attachVpcFromTgw.Add("action", "attach_vpc_to_tgw")
-	attachVpcFromTgw.Add("region", awsTgw.Region)
-	attachVpcFromTgw.Add("vpc_account_name", vpcSolo.AccountName)
-	attachVpcFromTgw.Add("vpc_name", vpcSolo.VpcID)
-	attachVpcFromTgw.Add("tgw_name", awsTgw.Name)
-	attachVpcFromTgw.Add("route_domain_name", SecurityDomainName)
-	Url.RawQuery = attachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get attach_vpc_to_tgw failed: " + err.Error())
-	}
+	return c.AttachVpcToAWSTgwContext(c.Context(), awsTgw, vpcSolo, SecurityDomainName)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode attach_vpc_to_tgw failed: " + err.Error())
+func (c *Client) AttachVpcToAWSTgwContext(ctx context.Context, awsTgw *AWSTgw, vpcSolo VPCSolo, SecurityDomainName string) error {
+	params := map[string]string{
+		"region":            awsTgw.Region,
+		"vpc_account_name":  vpcSolo.AccountName,
+		"vpc_name":          vpcSolo.VpcID,
+		"tgw_name":          awsTgw.Name,
+		"route_domain_name": SecurityDomainName,
 	}
-	if !data.Return {
-		return errors.New("Rest API attach_vpc_to_tgw Get failed: " + data.Reason)
-	}
-
-	return nil
+	return c.GetAPIContext(ctx, "attach_vpc_to_tgw", params, nil)
 }
 
 func (c *Client) DetachVpcFromAWSTgw(awsTgw *AWSTgw, vpcID string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for detach_vpc_from_tgw") + err.Error())
-	}
-	detachVpcFromTgw := url.Values{}
-	detachVpcFromTgw.Add("CID", c.CID)
-	detachVpcFromTgw.Add("action", "detach_vpc_from_tgw")
-	detachVpcFromTgw.Add("tgw_name", awsTgw.Name)
-	detachVpcFromTgw.Add("vpc_name", vpcID)
-	Url.RawQuery = detachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get detach_vpc_from_tgw failed: " + err.Error())
-	}
+	return c.DetachVpcFromAWSTgwContext(c.Context(), awsTgw, vpcID)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_vpc_from_tgw failed: " + err.Error())
+func (c *Client) DetachVpcFromAWSTgwContext(ctx context.Context, awsTgw *AWSTgw, vpcID string) error {
+	params := map[string]string{
+		"tgw_name": awsTgw.Name,
+		"vpc_name": vpcID,
 	}
-	if !data.Return {
-		return errors.New("Rest API detach_vpc_from_tgw Get failed: " + data.Reason)
-	}
-
-	return nil
+	return c.GetAPIContext(ctx, "detach_vpc_from_tgw", params, nil)
 }
 
 func (c *Client) GetTransitGwFromVpcID(gateway *Gateway) (*Gateway, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_vpcs_summary") + err.Error())
-	}
-	listVPCsSummary := url.Values{}
-	listVPCsSummary.Add("CID", c.CID)
-	listVPCsSummary.Add("action", "list_vpcs_summary")
-	Url.RawQuery = listVPCsSummary.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return nil, errors.New("HTTP Get list_vpcs_summary failed: " + err.Error())
-	}
-
-	data := VPCList{
-		Return:  false,
-		Results: make([]VPCInfo, 0),
-		Reason:  "",
-	}
+	return c.GetTransitGwFromVpcIDContext(c.Context(), gateway)
+}
 
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_vpcs_summary failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_vpcs_summary Get failed: " + data.Reason)
+func (c *Client) GetTransitGwFromVpcIDContext(ctx context.Context, gateway *Gateway) (*Gateway, error) {
+	var vpcLists []VPCInfo
+	if err := c.GetAPIContext(ctx, "list_vpcs_summary", nil, &vpcLists); err != nil {
+		return nil, err
 	}
-
-	vpcLists := data.Results
 	for i := range vpcLists {
 		vpcId := vpcLists[i].VPCId
 		if vpcLists[i].TransitVpc == "yes" && vpcId != "" {
@@ -531,34 +401,20 @@ func (c *Client) GetTransitGwFromVpcID(gateway *Gateway) (*Gateway, error) {
 }
 
 func (c *Client) ListTgwDetails(awsTgw *AWSTgw) (*AWSTgw, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_tgw_details") + err.Error())
-	}
-	listTgwDetails := url.Values{}
-	listTgwDetails.Add("CID", c.CID)
-	listTgwDetails.Add("action", "list_tgw_details")
-	listTgwDetails.Add("tgw_name", awsTgw.Name)
-
-	Url.RawQuery = listTgwDetails.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return nil, errors.New("HTTP Get list_tgw_details failed: " + err.Error())
-	}
+	return c.ListTgwDetailsContext(c.Context(), awsTgw)
+}
 
-	var data TGWInfoResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_tgw_details failed: " + err.Error())
+func (c *Client) ListTgwDetailsContext(ctx context.Context, awsTgw *AWSTgw) (*AWSTgw, error) {
+	params := map[string]string{
+		"tgw_name": awsTgw.Name,
 	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "does not exist") {
+	var tgwInfoList TGWInfoList
+	if err := c.GetAPIContext(ctx, "list_tgw_details", params, &tgwInfoList); err != nil {
+		if errors.Is(err, ErrNotFound) {
 			return nil, ErrNotFound
 		}
-		return nil, errors.New("Rest API list_tgw_details Get failed: " + data.Reason)
+		return nil, err
 	}
-
-	tgwInfoList := data.Results
 	if tgwInfoList.Name == awsTgw.Name {
 		tgwInfoDetail := tgwInfoList.TgwInfo
 		awsTgw.AccountName = tgwInfoDetail.AccountName
@@ -571,36 +427,17 @@ func (c *Client) ListTgwDetails(awsTgw *AWSTgw) (*AWSTgw, error) {
 }
 
 func (c *Client) IsVpcAttachedToTgw(awsTgw *AWSTgw, vpcSolo *VPCSolo) (bool, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return false, errors.New(("url Parsing failed for list_attached_vpc_names_to_route_domain") + err.Error())
-	}
-	listAttachedVpcNames := url.Values{}
-	listAttachedVpcNames.Add("CID", c.CID)
-	listAttachedVpcNames.Add("action", "list_attached_vpc_names_to_route_domain")
-	listAttachedVpcNames.Add("tgw_name", awsTgw.Name)
-
-	Url.RawQuery = listAttachedVpcNames.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return false, errors.New("HTTP Get list_attached_vpc_names_to_route_domain failed: " + err.Error())
-	}
+	return c.IsVpcAttachedToTgwContext(c.Context(), awsTgw, vpcSolo)
+}
 
-	data := listAttachedVpcNamesResp{
-		Return:  false,
-		Results: make([]string, 0),
-		Reason:  "",
+func (c *Client) IsVpcAttachedToTgwContext(ctx context.Context, awsTgw *AWSTgw, vpcSolo *VPCSolo) (bool, error) {
+	params := map[string]string{
+		"tgw_name": awsTgw.Name,
 	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return false, errors.New("Json Decode list_attached_vpc_names_to_route_domain failed: " + err.Error())
+	var attachedVpcNames []string
+	if err := c.GetAPIContext(ctx, "list_attached_vpc_names_to_route_domain", params, &attachedVpcNames); err != nil {
+		return false, err
 	}
-	if !data.Return {
-		return false, errors.New("Rest API list_attached_vpc_names_to_route_domain Get failed: " + data.Reason)
-	}
-
-	attachedVpcNames := data.Results
-
 	for i := range attachedVpcNames {
 		if strings.Split(attachedVpcNames[i], "~~")[0] == vpcSolo.VpcID {
 			return true, nil
@@ -1,10 +1,9 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"net/url"
 )
 
 type AwsTgwVpcAttachment struct {
@@ -17,59 +16,42 @@ type AwsTgwVpcAttachment struct {
 	VpcID              string `form:"vpc_id"`
 }
 
-type DomainListResp struct {
-	Return  bool     `json:"return,omitempty"`
-	Results []string `json:"results,omitempty"`
-	Reason  string   `json:"reason,omitempty"`
-}
-
 func (c *Client) CreateAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachment) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for attach_vpc_to_tgw") + err.Error())
-	}
-	attachVpcFromTgw := url.Values{}
-	attachVpcFromTgw.Add("CID", c.CID)
-	attachVpcFromTgw.Add("action", "attach_vpc_to_tgw")
-	attachVpcFromTgw.Add("region", awsTgwVpcAttachment.Region)
-	attachVpcFromTgw.Add("vpc_account_name", awsTgwVpcAttachment.VpcAccountName)
-	attachVpcFromTgw.Add("vpc_name", awsTgwVpcAttachment.VpcID)
-	attachVpcFromTgw.Add("tgw_name", awsTgwVpcAttachment.TgwName)
-	attachVpcFromTgw.Add("route_domain_name", awsTgwVpcAttachment.SecurityDomainName)
-	Url.RawQuery = attachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get attach_vpc_to_tgw failed: " + err.Error())
-	}
+	return c.CreateAwsTgwVpcAttachmentContext(c.Context(), awsTgwVpcAttachment)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode attach_vpc_to_tgw failed: " + err.Error())
+func (c *Client) CreateAwsTgwVpcAttachmentContext(ctx context.Context, awsTgwVpcAttachment *AwsTgwVpcAttachment) error {
+	awsTgw := &AWSTgw{
+		Name:   awsTgwVpcAttachment.TgwName,
+		Region: awsTgwVpcAttachment.Region,
 	}
-	if !data.Return {
-		return errors.New("Rest API attach_vpc_to_tgw Get failed: " + data.Reason)
+	vpcSolo := VPCSolo{
+		AccountName: awsTgwVpcAttachment.VpcAccountName,
+		VpcID:       awsTgwVpcAttachment.VpcID,
 	}
-
-	return nil
+	return c.AttachVpcToAWSTgwContext(ctx, awsTgw, vpcSolo, awsTgwVpcAttachment.SecurityDomainName)
 }
 
 func (c *Client) GetAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachment) (*AwsTgwVpcAttachment, error) {
+	return c.GetAwsTgwVpcAttachmentContext(c.Context(), awsTgwVpcAttachment)
+}
+
+func (c *Client) GetAwsTgwVpcAttachmentContext(ctx context.Context, awsTgwVpcAttachment *AwsTgwVpcAttachment) (*AwsTgwVpcAttachment, error) {
 	awsTgw := &AWSTgw{
 		Name: awsTgwVpcAttachment.TgwName,
 	}
-	awsTgw, err := c.ListTgwDetails(awsTgw)
+	awsTgw, err := c.ListTgwDetailsContext(ctx, awsTgw)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't find AWS TGW: %s", awsTgwVpcAttachment.TgwName)
 	}
 	awsTgwVpcAttachment.Region = awsTgw.Region
 
-	err = c.GetAwsTgwDomain(awsTgw, awsTgwVpcAttachment.SecurityDomainName)
+	err = c.GetAwsTgwDomainContext(ctx, awsTgw, awsTgwVpcAttachment.SecurityDomainName)
 	if err != nil {
 		return nil, errors.New("aws tgw does not have security domain: " + err.Error())
 	}
 
-	aTVA, err := c.GetAwsTgwDomainAttachedVpc(awsTgwVpcAttachment)
+	aTVA, err := c.GetAwsTgwDomainAttachedVpcContext(ctx, awsTgwVpcAttachment)
 	if err != nil {
 		if err == ErrNotFound {
 			return nil, err
@@ -85,35 +67,22 @@ func (c *Client) UpdateAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachm
 }
 
 func (c *Client) DeleteAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachment) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for detach_vpc_from_tgw") + err.Error())
-	}
-	detachVpcFromTgw := url.Values{}
-	detachVpcFromTgw.Add("CID", c.CID)
-	detachVpcFromTgw.Add("action", "detach_vpc_from_tgw")
-	detachVpcFromTgw.Add("tgw_name", awsTgwVpcAttachment.TgwName)
-	detachVpcFromTgw.Add("vpc_name", awsTgwVpcAttachment.VpcID)
-	Url.RawQuery = detachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get detach_vpc_from_tgw failed: " + err.Error())
-	}
+	return c.DeleteAwsTgwVpcAttachmentContext(c.Context(), awsTgwVpcAttachment)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_vpc_from_tgw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API detach_vpc_from_tgw Get failed: " + data.Reason)
+func (c *Client) DeleteAwsTgwVpcAttachmentContext(ctx context.Context, awsTgwVpcAttachment *AwsTgwVpcAttachment) error {
+	awsTgw := &AWSTgw{
+		Name: awsTgwVpcAttachment.TgwName,
 	}
-
-	return nil
+	return c.DetachVpcFromAWSTgwContext(ctx, awsTgw, awsTgwVpcAttachment.VpcID)
 }
 
 func (c *Client) GetAwsTgwDetail(awsTgw *AWSTgw) (*AWSTgw, error) {
-	awsTgw, err := c.ListTgwDetails(awsTgw)
+	return c.GetAwsTgwDetailContext(c.Context(), awsTgw)
+}
+
+func (c *Client) GetAwsTgwDetailContext(ctx context.Context, awsTgw *AWSTgw) (*AWSTgw, error) {
+	awsTgw, err := c.ListTgwDetailsContext(ctx, awsTgw)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't find AWS TGW: %s", awsTgw.Name)
 	}
@@ -122,63 +91,34 @@ func (c *Client) GetAwsTgwDetail(awsTgw *AWSTgw) (*AWSTgw, error) {
 }
 
 func (c *Client) GetAwsTgwDomain(awsTgw *AWSTgw, sDM string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for list_route_domain_names") + err.Error())
-	}
-	listRouteDomainNames := url.Values{}
-	listRouteDomainNames.Add("CID", c.CID)
-	listRouteDomainNames.Add("action", "list_route_domain_names")
-	listRouteDomainNames.Add("tgw_name", awsTgw.Name)
-	Url.RawQuery = listRouteDomainNames.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get list_route_domain_names failed: " + err.Error())
-	}
-	data := DomainListResp{
-		Return:  false,
-		Results: make([]string, 0),
-		Reason:  "",
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode list_route_domain_names failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API list_route_domain_names Get failed: " + data.Reason)
+	return c.GetAwsTgwDomainContext(c.Context(), awsTgw, sDM)
+}
+
+func (c *Client) GetAwsTgwDomainContext(ctx context.Context, awsTgw *AWSTgw, sDM string) error {
+	params := map[string]string{
+		"tgw_name": awsTgw.Name,
 	}
-	mDomain := make(map[string]bool)
-	for i := range data.Results {
-		mDomain[data.Results[i]] = true
+	var domainList []string
+	if err := c.GetAPIContext(ctx, "list_route_domain_names", params, &domainList); err != nil {
+		return err
 	}
-	if !mDomain[sDM] {
-		return errors.New(awsTgw.Name + " does not have security domain: " + sDM)
+	for i := range domainList {
+		if domainList[i] == sDM {
+			return nil
+		}
 	}
-
-	return nil
+	return errors.New(awsTgw.Name + " does not have security domain: " + sDM)
 }
 
 func (c *Client) GetAwsTgwDomainAttachedVpc(awsTgwVpcAttachment *AwsTgwVpcAttachment) (*AwsTgwVpcAttachment, error) {
-	Url, err := url.Parse(c.baseURL)
-	viewRouteDomainDetails := url.Values{}
-	viewRouteDomainDetails.Add("CID", c.CID)
-	viewRouteDomainDetails.Add("action", "view_route_domain_details")
-	viewRouteDomainDetails.Add("tgw_name", awsTgwVpcAttachment.TgwName)
-	viewRouteDomainDetails.Add("route_domain_name", awsTgwVpcAttachment.SecurityDomainName)
-	Url.RawQuery = viewRouteDomainDetails.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetAwsTgwDomainAttachedVpcContext(c.Context(), awsTgwVpcAttachment)
+}
 
+func (c *Client) GetAwsTgwDomainAttachedVpcContext(ctx context.Context, awsTgwVpcAttachment *AwsTgwVpcAttachment) (*AwsTgwVpcAttachment, error) {
+	routeDomainDetail, err := c.getRouteDomainDetails(ctx, awsTgwVpcAttachment.TgwName, awsTgwVpcAttachment.SecurityDomainName)
 	if err != nil {
-		return nil, errors.New("HTTP Get view_route_domain_details failed: " + err.Error())
-	}
-
-	var data RouteDomainAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return awsTgwVpcAttachment, errors.New("Json Decode view_route_domain_details failed: " + err.Error())
-	}
-	if !data.Return {
-		return awsTgwVpcAttachment, errors.New("Rest API view_route_domain_details Get failed: " + data.Reason)
+		return awsTgwVpcAttachment, err
 	}
-	routeDomainDetail := data.Results
 	attachedVPCs := routeDomainDetail[0].AttachedVPC
 	for i := range attachedVPCs {
 		if attachedVPCs[i].VPCId == awsTgwVpcAttachment.VpcID {
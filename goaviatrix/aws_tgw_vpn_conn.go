@@ -1,7 +1,7 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"log"
 	"net/url"
@@ -39,37 +39,17 @@ type AwsTgwVpnConnEdit struct {
 	PreSharedKeyTun2 string   `json:"pre_shared_key_tun_2,omitempty"`
 }
 
-type AwsTgwVpnConnCreateResp struct {
-	Return  bool    `json:"return"`
-	Results vpnInfo `json:"results"`
-	Reason  string  `json:"reason"`
-}
-
 type vpnInfo struct {
 	Text  string `json:"text,omitempty"`
 	VpnID string `json:"vpn_id,omitempty"`
 }
 
-type AwsTgwVpnConnResp struct {
-	Return  bool                `json:"return"`
-	Results []AwsTgwVpnConnEdit `json:"results"`
-	Reason  string              `json:"reason"`
-}
-
-type GetAwsTgwVpnConnVpnIdResp struct {
-	Return  bool     `json:"return"`
-	Results []string `json:"results"`
-	Reason  string   `json:"reason"`
+func (c *Client) CreateAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (string, error) {
+	return c.CreateAwsTgwVpnConnContext(c.Context(), awsTgwVpnConn)
 }
 
-func (c *Client) CreateAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (string, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return "", errors.New(("url Parsing failed for connect_transit_gw_to_vgw") + err.Error())
-	}
+func (c *Client) CreateAwsTgwVpnConnContext(ctx context.Context, awsTgwVpnConn *AwsTgwVpnConn) (string, error) {
 	attachEdgeVpnToTgw := url.Values{}
-	attachEdgeVpnToTgw.Add("CID", c.CID)
-	attachEdgeVpnToTgw.Add("action", "attach_edge_vpn_to_tgw")
 	attachEdgeVpnToTgw.Add("tgw_name", awsTgwVpnConn.TgwName)
 	attachEdgeVpnToTgw.Add("route_domain_name", awsTgwVpnConn.RouteDomainName)
 	attachEdgeVpnToTgw.Add("connection_name", awsTgwVpnConn.ConnName)
@@ -89,57 +69,30 @@ func (c *Client) CreateAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (string, erro
 		attachEdgeVpnToTgw.Add("pre_shared_key_tun_2", awsTgwVpnConn.PreSharedKeyTun2)
 	}
 
-	Url.RawQuery = attachEdgeVpnToTgw.Encode()
-
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return "", errors.New("HTTP Get attach_edge_vpn_to_tgw failed: " + err.Error())
-	}
-
-	var data AwsTgwVpnConnCreateResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", errors.New("Json Decode attach_edge_vpn_to_tgw failed: " + err.Error())
+	var data vpnInfo
+	if err := c.GetAPIContext(ctx, "attach_edge_vpn_to_tgw", attachEdgeVpnToTgw, &data); err != nil {
+		return "", err
 	}
-
-	if !data.Return {
-		return "", errors.New("Rest API attach_edge_vpn_to_tgw Get failed: " + data.Reason)
-	}
-
-	if data.Results.VpnID == "" {
+	if data.VpnID == "" {
 		return "", errors.New("could not get vpn_id information")
 	}
 
-	return data.Results.VpnID, nil
+	return data.VpnID, nil
 }
 
 func (c *Client) GetAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (*AwsTgwVpnConn, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_all_tgw_attachments") + err.Error())
-	}
-	listAllTgwAttachments := url.Values{}
-	listAllTgwAttachments.Add("CID", c.CID)
-	listAllTgwAttachments.Add("action", "list_all_tgw_attachments")
-	listAllTgwAttachments.Add("tgw_name", awsTgwVpnConn.TgwName)
-	listAllTgwAttachments.Add("resource_type", "vpn")
-
-	Url.RawQuery = listAllTgwAttachments.Encode()
+	return c.GetAwsTgwVpnConnContext(c.Context(), awsTgwVpnConn)
+}
 
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return nil, errors.New("HTTP Get list_all_tgw_attachments failed: " + err.Error())
+func (c *Client) GetAwsTgwVpnConnContext(ctx context.Context, awsTgwVpnConn *AwsTgwVpnConn) (*AwsTgwVpnConn, error) {
+	params := map[string]string{
+		"tgw_name":      awsTgwVpnConn.TgwName,
+		"resource_type": "vpn",
 	}
-
-	var data AwsTgwVpnConnResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_all_tgw_attachments failed: " + err.Error())
+	var allAwsTgwVpnConn []AwsTgwVpnConnEdit
+	if err := c.GetAPIContext(ctx, "list_all_tgw_attachments", params, &allAwsTgwVpnConn); err != nil {
+		return nil, err
 	}
-
-	if !data.Return {
-		return nil, errors.New("Rest API list_all_tgw_attachments Get failed: " + data.Reason)
-	}
-
-	allAwsTgwVpnConn := data.Results
 	for i := range allAwsTgwVpnConn {
 		if allAwsTgwVpnConn[i].TgwName == awsTgwVpnConn.TgwName && allAwsTgwVpnConn[i].VpnID == awsTgwVpnConn.VpnID {
 			awsTgwVpnConn.RouteDomainName = allAwsTgwVpnConn[i].RouteDomainName
@@ -170,22 +123,9 @@ func (c *Client) GetAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (*AwsTgwVpnConn,
 }
 
 func (c *Client) DeleteAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) error {
-	awsTgwVpnConn.CID = c.CID
-	awsTgwVpnConn.Action = "detach_vpn_from_tgw"
-	resp, err := c.Post(c.baseURL, awsTgwVpnConn)
-	if err != nil {
-		return errors.New("HTTP Post detach_vpn_from_tgw failed: " + err.Error())
-	}
-
-	var data APIResp
-
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_vpn_from_tgw failed: " + err.Error())
-	}
-
-	if !data.Return {
-		return errors.New("Rest API detach_vpn_from_tgw Post failed: " + data.Reason)
-	}
+	return c.DeleteAwsTgwVpnConnContext(c.Context(), awsTgwVpnConn)
+}
 
-	return nil
+func (c *Client) DeleteAwsTgwVpnConnContext(ctx context.Context, awsTgwVpnConn *AwsTgwVpnConn) error {
+	return c.PostAPIContext(ctx, "detach_vpn_from_tgw", awsTgwVpnConn, nil)
 }
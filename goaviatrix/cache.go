@@ -0,0 +1,137 @@
+package goaviatrix
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultReadCacheTTL is how long the provider keeps the replies of
+// cachedReadActions.
+const DefaultReadCacheTTL = 30 * time.Second
+
+// cachedReadActions are the read actions returning a whole inventory, which
+// the Get methods download once for every object they look up.
+var cachedReadActions = map[string]bool{
+	"list_accounts":         true,
+	"list_custom_vpcs":      true,
+	"list_fqdn_filter_tags": true,
+	"list_vpcs_summary":     true,
+}
+
+// readCache keeps the replies of cachedReadActions for a short time.
+// Concurrent requests for the same reply share a single call, and any
+// mutating action drops all the replies.
+type readCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	gen     uint64
+	entries map[string]*cacheEntry
+}
+
+// cacheEntry is a cached reply. done is closed once the call returned;
+// expires is zero while it is in progress.
+type cacheEntry struct {
+	done     chan struct{}
+	expires  time.Time
+	resp     *http.Response
+	envelope *apiEnvelope
+	err      error
+}
+
+type fetchFunc func() (*http.Response, *apiEnvelope, error)
+
+// SetReadCacheTTL makes the client keep the replies of the actions listing
+// the whole inventory of accounts, gateways, VPCs and FQDN filter tags for
+// the given duration, so that looking up many objects does not download the
+// inventory every time. Actions changing the controller's configuration
+// drop the cached replies. Zero or less disables the cache, which is the
+// default.
+func (c *Client) SetReadCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		c.cache = nil
+		return
+	}
+	c.cache = &readCache{ttl: ttl, entries: make(map[string]*cacheEntry)}
+}
+
+// InvalidateReadCache drops the replies kept by the read cache, e.g. after
+// the controller's configuration was changed by another client.
+func (c *Client) InvalidateReadCache() {
+	if c.cache != nil {
+		c.cache.invalidate()
+	}
+}
+
+// cached sends the action through fetch, using the read cache if enabled.
+func (c *Client) cached(ctx context.Context, verb string, action string, params url.Values,
+	fetch fetchFunc) (*http.Response, *apiEnvelope, error) {
+	rc := c.cache
+	if rc == nil {
+		return fetch()
+	}
+	if !IsReadAction(action, params) {
+		rc.invalidate()
+		defer rc.invalidate()
+		return fetch()
+	}
+	if !cachedReadActions[action] {
+		return fetch()
+	}
+	return rc.get(ctx, cacheKey(verb, action, params), fetch)
+}
+
+// cacheKey identifies the reply to an action, ignoring the CID.
+func cacheKey(verb string, action string, params url.Values) string {
+	p := url.Values{}
+	for k, v := range params {
+		if k != "CID" && k != "action" {
+			p[k] = v
+		}
+	}
+	return verb + " " + action + "?" + p.Encode()
+}
+
+func (rc *readCache) get(ctx context.Context, key string, fetch fetchFunc) (*http.Response, *apiEnvelope, error) {
+	rc.mu.Lock()
+	if e, ok := rc.entries[key]; ok && (e.expires.IsZero() || time.Now().Before(e.expires)) {
+		rc.mu.Unlock()
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+		if e.err == nil {
+			return e.resp, e.envelope, nil
+		}
+		// The error may be specific to the request that made the call, e.g.
+		// its context was cancelled.
+		return fetch()
+	}
+	e := &cacheEntry{done: make(chan struct{})}
+	rc.entries[key] = e
+	gen := rc.gen
+	rc.mu.Unlock()
+
+	e.resp, e.envelope, e.err = fetch()
+
+	rc.mu.Lock()
+	e.expires = time.Now().Add(rc.ttl)
+	if (e.err != nil || rc.gen != gen) && rc.entries[key] == e {
+		delete(rc.entries, key)
+	}
+	rc.mu.Unlock()
+	close(e.done)
+	return e.resp, e.envelope, e.err
+}
+
+// invalidate drops all the replies. Calls in progress still complete but
+// their replies are not kept.
+func (rc *readCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.gen++
+	rc.entries = make(map[string]*cacheEntry)
+}
@@ -0,0 +1,73 @@
+package goaviatrix
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/fakecontroller"
+)
+
+func TestReadCache(t *testing.T) {
+	srv := fakecontroller.New()
+	defer srv.Close()
+	client, err := NewClient(srv.Username, srv.Password, srv.Host(), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	account := &Account{AccountName: "aws", CloudType: 1, AwsAccountNumber: "123456789012", AwsIam: "true"}
+	if err := client.CreateAccount(account); err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	lookup := func(n int) {
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.GetAccount(&Account{AccountName: "aws"}); err != nil {
+					t.Errorf("GetAccount: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	expectCalls := func(want int) {
+		t.Helper()
+		if got := srv.Calls("list_accounts"); got != want {
+			t.Errorf("expected %d calls to list_accounts, got %d", want, got)
+		}
+	}
+
+	lookup(2)
+	expectCalls(2)
+
+	client.SetReadCacheTTL(time.Minute)
+	lookup(10)
+	expectCalls(3)
+
+	account.AwsIam = "false"
+	account.AwsAccessKey = "AKIA"
+	account.AwsSecretKey = "secret"
+	if err := client.UpdateAccount(account); err != nil {
+		t.Fatalf("UpdateAccount: %v", err)
+	}
+	got, err := client.GetAccount(&Account{AccountName: "aws"})
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if got.AwsAccessKey != "AKIA" {
+		t.Errorf("expected the update to invalidate the cache, got %#v", got)
+	}
+	expectCalls(4)
+
+	client.InvalidateReadCache()
+	lookup(1)
+	expectCalls(5)
+
+	client.SetReadCacheTTL(time.Millisecond)
+	lookup(1)
+	time.Sleep(5 * time.Millisecond)
+	lookup(1)
+	expectCalls(7)
+}
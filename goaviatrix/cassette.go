@@ -0,0 +1,229 @@
+package goaviatrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder records or replays traffic.
+type RecorderMode int
+
+const (
+	// RecorderModeRecord sends the requests to the controller and records
+	// them with their responses.
+	RecorderModeRecord RecorderMode = iota
+	// RecorderModeReplay answers the requests from a cassette without
+	// sending them.
+	RecorderModeReplay
+)
+
+// Interaction is a request to the controller and the response it got.
+type Interaction struct {
+	Method   string     `json:"method"`
+	Action   string     `json:"action"`
+	Params   url.Values `json:"params"`
+	Status   int        `json:"status"`
+	Response string     `json:"response"`
+}
+
+// Cassette is the content of a cassette file: the interactions in the order
+// they happened and named values the recording depends on, such as the
+// random names used by a test.
+type Cassette struct {
+	Vars         map[string]string `json:"vars,omitempty"`
+	Interactions []*Interaction    `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper recording the traffic of a Client to a
+// cassette file, or replaying it from one. Credentials and CIDs are scrubbed
+// from the cassette, as Redact does for log output.
+//
+// On replay, a request is answered with the first interaction not replayed
+// yet that has the same method, action and parameters. Secret parameters and
+// the CID are not compared, so a cassette can be replayed with any
+// credentials.
+type Recorder struct {
+	// Transport sends the requests when recording. Nil means
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	mode     RecorderMode
+	path     string
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// NewRecorder creates a Recorder for the given cassette file.
+// Arguments:
+//    path - the cassette file
+//    mode - whether to record or replay
+// Returns:
+//    Recorder - the recorder, to be used as transport of Client.HTTPClient
+//    error - if the cassette to replay could not be read
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+	if mode == RecorderModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette %s: %v", path, err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %s: %v", path, err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns whether the recorder records or replays.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Var returns the value recorded under name when replaying. When recording,
+// it records value under name and returns it. A nil Recorder returns value,
+// so tests can use Var whether a cassette is in use or not.
+func (r *Recorder) Var(name string, value string) string {
+	if r == nil {
+		return value
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == RecorderModeReplay {
+		return r.cassette.Vars[name]
+	}
+	if r.cassette.Vars == nil {
+		r.cassette.Vars = make(map[string]string)
+	}
+	r.cassette.Vars[name] = value
+	return value
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory if needed. It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode == RecorderModeReplay {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	r.mu.Lock()
+	err := enc.Encode(&r.cassette)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, buf.Bytes(), 0644)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	params, err := requestParams(req)
+	if err != nil {
+		return nil, err
+	}
+	scrubParams(params)
+	if r.mode == RecorderModeReplay {
+		return r.replay(req, params)
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Method:   req.Method,
+		Action:   params.Get("action"),
+		Params:   params,
+		Status:   resp.StatusCode,
+		Response: Redact(string(body)),
+	})
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, params url.Values) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	action := params.Get("action")
+	for i, in := range r.cassette.Interactions {
+		if r.replayed[i] || in.Method != req.Method || in.Action != action || !reflect.DeepEqual(in.Params, params) {
+			continue
+		}
+		r.replayed[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+			StatusCode:    in.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response)),
+			ContentLength: int64(len(in.Response)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no interaction left in cassette %s for %s %s with parameters %s",
+		r.path, req.Method, action, params.Encode())
+}
+
+// requestParams returns the parameters of a request to the controller, from
+// the query string or the form encoded body. The body is left readable.
+func requestParams(req *http.Request) (url.Values, error) {
+	params := req.URL.Query()
+	if req.Body == nil || req.Body == http.NoBody {
+		return params, nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request body: %v", err)
+	}
+	for k, v := range form {
+		params[k] = append(params[k], v...)
+	}
+	return params, nil
+}
+
+// scrubParams masks the values of secretParams in params.
+func scrubParams(params url.Values) {
+	for _, name := range secretParams {
+		if v, ok := params[name]; ok {
+			for i := range v {
+				v[i] = redacted
+			}
+		}
+	}
+}
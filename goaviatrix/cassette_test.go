@@ -0,0 +1,86 @@
+package goaviatrix
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/fakecontroller"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "account.json")
+	account := &Account{
+		AccountName:      "aws",
+		CloudType:        1,
+		AwsAccountNumber: "123456789012",
+		AwsIam:           "false",
+		AwsAccessKey:     "AKIA",
+		AwsSecretKey:     "s3cret",
+	}
+	run := func(client *Client) *Account {
+		if err := client.CreateAccount(account); err != nil {
+			t.Fatalf("CreateAccount: %v", err)
+		}
+		got, err := client.GetAccount(&Account{AccountName: "aws"})
+		if err != nil {
+			t.Fatalf("GetAccount: %v", err)
+		}
+		if err := client.DeleteAccount(account); err != nil {
+			t.Fatalf("DeleteAccount: %v", err)
+		}
+		return got
+	}
+
+	srv := fakecontroller.New()
+	srv.Password = "pa55word"
+	rec, err := NewRecorder(path, RecorderModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Transport = srv.Client().Transport
+	client, err := NewClient(srv.Username, srv.Password, srv.Host(), &http.Client{Transport: rec})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	recorded := run(client)
+	if got := rec.Var("name", "recorded"); got != "recorded" {
+		t.Errorf("expected Var to return the recorded value, got %q", got)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	srv.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{srv.Password, "s3cret", "fake-cid"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+
+	rec, err = NewRecorder(path, RecorderModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err = NewClient(srv.Username, "other", "controller.invalid", &http.Client{Transport: rec})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.SetRetryPolicy(&RetryPolicy{MaxAttempts: 1})
+	if got := rec.Var("name", "replayed"); got != "recorded" {
+		t.Errorf("expected Var to return the recorded value, got %q", got)
+	}
+	if replayed := run(client); *replayed != *recorded {
+		t.Errorf("replayed account %#v differs from recorded %#v", replayed, recorded)
+	}
+	if _, err := client.GetAccount(&Account{AccountName: "aws"}); err == nil ||
+		!strings.Contains(err.Error(), "no interaction left") {
+		t.Errorf("expected a request not in the cassette to fail, got %v", err)
+	}
+}
@@ -1,23 +1,107 @@
 package goaviatrix
 
 import (
-	"strings"
+	"context"
+	"fmt"
+	"sync"
 
 	"github.com/pkg/errors"
 )
 
+// versionCheck keeps the controller version once it was read, and whether
+// features may be used without checking it.
+type versionCheck struct {
+	mu      sync.Mutex
+	skip    bool
+	version *AviatrixVersion
+}
+
+// ControllerVersionValidation checks that the controller runs a version in
+// the supported range, written as in ParseVersionRange.
 func (c *Client) ControllerVersionValidation(supportedVersion string) error {
-	suppVersion := strings.Split(supportedVersion, ".")
+	return c.ControllerVersionValidationContext(c.Context(), supportedVersion)
+}
+
+func (c *Client) ControllerVersionValidationContext(ctx context.Context, supportedVersion string) error {
+	supported, err := ParseVersionRange(supportedVersion)
+	if err != nil {
+		return err
+	}
 
-	currentVersion, _, err := c.GetCurrentVersion()
+	currentVersion, err := c.ControllerVersionContext(ctx)
 	if err != nil {
 		return err
 	}
-	currVersion := strings.Split(currentVersion, ".")
-	if suppVersion[0] != currVersion[0] || suppVersion[1] != currVersion[1] {
-		return errors.New("current Terraform branch supports controller version: UserConnect-" + supportedVersion +
+	if !supported.Contains(currentVersion) {
+		return errors.New("current Terraform branch supports controller version: UserConnect-" + supported.String() +
+			", controller runs UserConnect-" + currentVersion.String() +
 			". Please upgrade/downgrade controller or change Terraform branch.")
 	}
 
 	return nil
 }
+
+// ControllerVersion returns the version the controller runs. It is read once
+// and kept by the client.
+func (c *Client) ControllerVersion() (*AviatrixVersion, error) {
+	return c.ControllerVersionContext(c.Context())
+}
+
+func (c *Client) ControllerVersionContext(ctx context.Context) (*AviatrixVersion, error) {
+	c.versions.mu.Lock()
+	defer c.versions.mu.Unlock()
+	if c.versions.version == nil {
+		_, version, err := c.GetCurrentVersionContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.versions.version = version
+	}
+	v := *c.versions.version
+	return &v, nil
+}
+
+// SkipVersionChecks makes RequireVersion accept all features whatever the
+// controller version.
+func (c *Client) SkipVersionChecks(skip bool) {
+	c.versions.mu.Lock()
+	defer c.versions.mu.Unlock()
+	c.versions.skip = skip
+}
+
+// forgetControllerVersion makes the client read the controller version
+// again, after an upgrade.
+func (c *Client) forgetControllerVersion() {
+	c.versions.mu.Lock()
+	defer c.versions.mu.Unlock()
+	c.versions.version = nil
+}
+
+// RequireVersion checks that the controller supports the named feature,
+// which needs minVersion or later.
+func (c *Client) RequireVersion(feature string, minVersion string) error {
+	return c.RequireVersionContext(c.Context(), feature, minVersion)
+}
+
+func (c *Client) RequireVersionContext(ctx context.Context, feature string, minVersion string) error {
+	c.versions.mu.Lock()
+	skip := c.versions.skip
+	c.versions.mu.Unlock()
+	if skip {
+		return nil
+	}
+
+	_, required, err := ParseVersion(minVersion)
+	if err != nil {
+		return err
+	}
+	current, err := c.ControllerVersionContext(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to check the controller version needed by %s: %v", feature, err)
+	}
+	if current.Compare(required) < 0 {
+		return fmt.Errorf("%s requires controller version UserConnect-%s or later, controller runs UserConnect-%s",
+			feature, required, current)
+	}
+	return nil
+}
@@ -2,13 +2,13 @@ package goaviatrix
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 	"time"
@@ -42,9 +42,30 @@ type Client struct {
 	HTTPClient   *http.Client
 	Username     string
 	Password     string
-	CID          string
 	ControllerIP string
-	baseURL      string
+	endpoints    []string
+	ctx          context.Context
+	retryPolicy  *RetryPolicy
+	queue        *OperationQueue
+	cache        *readCache
+	session      session
+	versions     versionCheck
+	defaultTags  map[string]string
+}
+
+// SetContext sets the context used by the client methods that do not take
+// one.  Cancelling it aborts all in-flight requests made through them.
+func (c *Client) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// Context returns the context used by the client methods that do not take
+// one.  It defaults to context.Background().
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
 }
 
 // Login to the Aviatrix controller with the username/password provided in
@@ -54,26 +75,44 @@ type Client struct {
 // Returns:
 //    error - if any
 func (c *Client) Login() error {
+	return c.LoginContext(c.Context())
+}
+
+// LoginContext is the same as Login but uses the given context for the
+// request.
+func (c *Client) LoginContext(ctx context.Context) error {
+	return c.refreshSession(ctx, c.CID(), false)
+}
+
+// login performs the login action on the given controller and returns the
+// new CID.
+func (c *Client) login(ctx context.Context, controllerIP string) (string, error) {
 	account := make(map[string]interface{})
 	account["action"] = "login"
 	account["username"] = c.Username
 	account["password"] = c.Password
 
 	log.Printf("[INFO] Parsed Aviatrix login: %#v", account["username"])
-	resp, err := c.Post(c.baseURL, account)
+	resp, err := c.PostContext(ctx, apiURL(controllerIP), account)
 	if err != nil {
-		return err
+		if isCertificateError(err) {
+			return "", &CertificateError{Host: controllerIP, Err: err}
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return "", NewAPIError("login", "Post", resp.StatusCode, "")
 	}
 	var data LoginResp
 	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return err
+		return "", err
 	}
 	if !data.Return {
-		return errors.New(data.Reason)
+		return "", fmt.Errorf("login to controller %s failed: %s", controllerIP, data.Reason)
 	}
-	log.Printf("[TRACE] CID is '%s'.", data.CID)
-	c.CID = data.CID
-	return nil
+	log.Printf("[TRACE] Logged in to controller %s", controllerIP)
+	return data.CID, nil
 }
 
 // NewClient creates a Client object using the arguments provided.
@@ -88,29 +127,57 @@ func (c *Client) Login() error {
 // See Also:
 //   init()
 func NewClient(username string, password string, controllerIP string, HTTPClient *http.Client) (*Client, error) {
-	client := &Client{Username: username, Password: password, HTTPClient: HTTPClient, ControllerIP: controllerIP}
-	return client.init(controllerIP)
+	return NewClientContext(context.Background(), username, password, controllerIP, HTTPClient)
 }
 
-// init initializes the new client with the given controller IP/host.  Logs
-// in to the controller and sets up the http client.
+// NewClientContext is the same as NewClient but logs in using the given
+// context, which also becomes the client's default context.
+// See Also:
+//   SetContext()
+func NewClientContext(ctx context.Context, username string, password string, controllerIP string,
+	HTTPClient *http.Client) (*Client, error) {
+	return NewClientEndpointsContext(ctx, username, password, []string{controllerIP}, HTTPClient)
+}
+
+// NewClientEndpointsContext is the same as NewClientContext but takes an
+// ordered list of controllers, e.g. a primary and a standby restored from
+// its backup.  The client logs in to the first healthy one and fails over
+// to the next ones when the controller it uses becomes unreachable.
+// ControllerIP is set to the first controller.
+// See Also:
+//   Endpoint()
+func NewClientEndpointsContext(ctx context.Context, username string, password string, controllerIPs []string,
+	HTTPClient *http.Client) (*Client, error) {
+	client := &Client{Username: username, Password: password, HTTPClient: HTTPClient, ctx: ctx}
+	return client.init(controllerIPs)
+}
+
+// init initializes the new client with the given controller IPs/hosts.  Logs
+// in to the controller and sets up the http client.  When no http client is
+// given, one verifying the controller certificate against the system roots
+// is used.
 // Arguments:
-//    controllerIP - the controller host/IP
+//    controllerIPs - the controller hosts/IPs, in order of preference
 // Returns:
 //   Client - the updated client object
 //   error - if any
-func (c *Client) init(controllerIP string) (*Client, error) {
-	if len(controllerIP) == 0 {
+func (c *Client) init(controllerIPs []string) (*Client, error) {
+	for _, ip := range controllerIPs {
+		if len(ip) == 0 {
+			return nil, fmt.Errorf("Aviatrix: Client: Controller IP is not set")
+		}
+	}
+	if len(controllerIPs) == 0 {
 		return nil, fmt.Errorf("Aviatrix: Client: Controller IP is not set")
 	}
 
-	c.baseURL = "https://" + controllerIP + "/v1/api"
+	c.ControllerIP = controllerIPs[0]
+	c.endpoints = controllerIPs
 
 	if c.HTTPClient == nil {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
+		tr, err := NewTransport(nil)
+		if err != nil {
+			return nil, err
 		}
 		c.HTTPClient = &http.Client{Transport: tr}
 	}
@@ -122,22 +189,42 @@ func (c *Client) init(controllerIP string) (*Client, error) {
 }
 
 func (c *Client) Get(path string, i interface{}) (*http.Response, error) {
-	return c.Request("GET", path, i)
+	return c.GetContext(c.Context(), path, i)
+}
+
+// GetContext issues an HTTP GET request bound to the given context.
+func (c *Client) GetContext(ctx context.Context, path string, i interface{}) (*http.Response, error) {
+	return c.RequestContext(ctx, "GET", path, i)
 }
 
 // Post issues an HTTP POST request with the given interface form-encoded.
 func (c *Client) Post(path string, i interface{}) (*http.Response, error) {
-	return c.Request("POST", path, i)
+	return c.PostContext(c.Context(), path, i)
+}
+
+// PostContext issues an HTTP POST request bound to the given context.
+func (c *Client) PostContext(ctx context.Context, path string, i interface{}) (*http.Response, error) {
+	return c.RequestContext(ctx, "POST", path, i)
 }
 
 // Put issues an HTTP PUT request with the given interface form-encoded.
 func (c *Client) Put(path string, i interface{}) (*http.Response, error) {
-	return c.Request("PUT", path, i)
+	return c.PutContext(c.Context(), path, i)
+}
+
+// PutContext issues an HTTP PUT request bound to the given context.
+func (c *Client) PutContext(ctx context.Context, path string, i interface{}) (*http.Response, error) {
+	return c.RequestContext(ctx, "PUT", path, i)
 }
 
 // Delete issues an HTTP DELETE request.
 func (c *Client) Delete(path string, i interface{}) (*http.Response, error) {
-	return c.Request("GET", path, i)
+	return c.DeleteContext(c.Context(), path, i)
+}
+
+// DeleteContext issues an HTTP DELETE request bound to the given context.
+func (c *Client) DeleteContext(ctx context.Context, path string, i interface{}) (*http.Response, error) {
+	return c.RequestContext(ctx, "GET", path, i)
 }
 
 // Do performs the HTTP request.
@@ -149,94 +236,260 @@ func (c *Client) Delete(path string, i interface{}) (*http.Response, error) {
 //   []byte - the body string as a byte array
 //   error - if any
 func (c *Client) Do(verb string, req interface{}) (*http.Response, []byte, error) {
+	return c.DoContext(c.Context(), verb, req)
+}
+
+// DoContext is the same as Do but aborts the request once the given context
+// is done.
+func (c *Client) DoContext(ctx context.Context, verb string, req interface{}) (*http.Response, []byte, error) {
+	var params url.Values
 	var err error
-	var resp *http.Response
-	var url string
-	var body []byte
-	respdata := new(APIResp)
-
-	// do request
-	var loop int
-	for {
-		url = c.baseURL
-		loop = loop + 1
-		if verb == "GET" {
-			// prepare query string
-			v, _ := query.Values(req)
-			url = url + "?" + v.Encode()
-			resp, err = c.Request(verb, url, nil)
-		} else {
-			resp, err = c.Request(verb, url, req)
+	if verb == "GET" {
+		params, err = query.Values(req)
+	} else {
+		params, err = form.EncodeToValues(req)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, envelope, err := c.dispatch(ctx, verb, requestAction(req), params)
+	if err != nil {
+		return resp, nil, err
+	}
+	return resp, envelope.body, nil
+}
+
+// apiEnvelope is the reply every action is wrapped in.
+type apiEnvelope struct {
+	Return  bool            `json:"return"`
+	Reason  string          `json:"reason"`
+	Results json.RawMessage `json:"results"`
+	body    []byte
+}
+
+// PostAPI sends an action to the controller as a form encoded POST request.
+// Arguments:
+//    action - the REST API action
+//    params - a struct with form tags, url.Values or map[string]string, may be nil
+//    v - if not nil, the "results" of the reply are decoded into it
+// Returns:
+//    error - an *APIError if the controller rejected the action
+func (c *Client) PostAPI(action string, params interface{}, v interface{}) error {
+	return c.PostAPIContext(c.Context(), action, params, v)
+}
+
+// PostAPIContext is the same as PostAPI but bound to the given context.
+func (c *Client) PostAPIContext(ctx context.Context, action string, params interface{}, v interface{}) error {
+	return c.callAPI(ctx, "POST", action, params, v)
+}
+
+// GetAPI sends an action to the controller as a GET request with the
+// parameters in the query string.
+// Arguments:
+//    action - the REST API action
+//    params - a struct with form tags, url.Values or map[string]string, may be nil
+//    v - if not nil, the "results" of the reply are decoded into it
+// Returns:
+//    error - an *APIError if the controller rejected the action
+func (c *Client) GetAPI(action string, params interface{}, v interface{}) error {
+	return c.GetAPIContext(c.Context(), action, params, v)
+}
+
+// GetAPIContext is the same as GetAPI but bound to the given context.
+func (c *Client) GetAPIContext(ctx context.Context, action string, params interface{}, v interface{}) error {
+	return c.callAPI(ctx, "GET", action, params, v)
+}
+
+func (c *Client) callAPI(ctx context.Context, verb string, action string, params interface{}, v interface{}) error {
+	values, err := encodeParams(params)
+	if err != nil {
+		return fmt.Errorf("encoding parameters of %s failed: %v", action, err)
+	}
+	_, envelope, err := c.dispatch(ctx, verb, action, values)
+	if err != nil {
+		return err
+	}
+	if v != nil && len(envelope.Results) != 0 {
+		if err = json.Unmarshal(envelope.Results, v); err != nil {
+			return fmt.Errorf("Json Decode %s results failed: %v", action, err)
 		}
+	}
+	return nil
+}
 
-		// check response for error
-		if err != nil {
-			if loop > 2 {
-				return resp, nil, err
-			} else {
-				continue // try again
+// dispatch is the single path every action takes to the controller.  It
+// sets the CID and action, logs in again once if the CID has expired and
+// decodes the reply envelope, turning a rejection into an *APIError.
+func (c *Client) dispatch(ctx context.Context, verb string, action string, params url.Values) (*http.Response, *apiEnvelope, error) {
+	return c.cached(ctx, verb, action, params, func() (*http.Response, *apiEnvelope, error) {
+		var resp *http.Response
+		var envelope *apiEnvelope
+		err := c.retryAction(ctx, IsReadAction(action, params), func() error {
+			release, err := c.enqueue(ctx, action, params)
+			if err != nil {
+				return err
 			}
-		}
+			defer release()
+			resp, envelope, err = c.send(ctx, verb, action, params)
+			return err
+		})
+		return resp, envelope, err
+	})
+}
+
+// send performs a single action, logging in again once if the controller
+// reports that the CID expired.
+func (c *Client) send(ctx context.Context, verb string, action string, params url.Values) (*http.Response, *apiEnvelope, error) {
+	method := strings.Title(strings.ToLower(verb))
+	failovers := 0
+	for attempt := 0; ; attempt++ {
+		cid, controllerIP := c.sessionState()
+		params.Set("CID", cid)
+		params.Set("action", action)
 
-		log.Printf("[TRACE] %s %s: %d", verb, url, resp.StatusCode)
-		// decode the json response and look for errors to retry
-		defer resp.Body.Close()
-		if resp.StatusCode == http.StatusOK {
-			body, _ = ioutil.ReadAll(resp.Body)
-			if err = json.Unmarshal(body, respdata); err != nil {
-				return resp, body, err
+		var resp *http.Response
+		var err error
+		if verb == "GET" || verb == "DELETE" {
+			resp, err = c.request(ctx, verb, apiURL(controllerIP)+"?"+params.Encode(), "")
+		} else {
+			resp, err = c.request(ctx, verb, apiURL(controllerIP), params.Encode())
+		}
+		if c.shouldFailover(ctx, resp, err) && failovers < len(c.endpoints)-1 {
+			failovers++
+			if resp != nil {
+				resp.Body.Close()
+				err = NewAPIError(action, method, resp.StatusCode, "")
 			}
-			// Check if the CID has expired; if so re-login
-			if respdata.Reason == "CID is invalid or expired." && loop < 2 {
-				log.Printf("[TRACE] re-login (expired CID)")
-				time.Sleep(500 * time.Millisecond)
-				if err = c.Login(); err != nil {
-					return resp, body, err
-				}
-				// update the CID value in the object passed
-				s := reflect.ValueOf(req).Elem()
-				f := s.FieldByName("CID")
-				if f.IsValid() && f.CanSet() {
-					f.SetString(c.CID)
-				}
-				// loop around again using new CID
-			} else if !respdata.Return {
-				return resp, body, errors.New(respdata.Reason)
-			} else {
-				return resp, body, nil
+			log.Printf("[WARN] controller %s failed %s: %v", controllerIP, action, err)
+			if ferr := c.refreshSession(ctx, cid, true); ferr != nil {
+				return nil, nil, fmt.Errorf("HTTP %s %s failed: %w", method, action, err)
 			}
-		} else {
-			return resp, body, errors.New("Status code")
+			// The request can only be sent again if it only reads state or
+			// did not reach the failed controller.
+			if IsReadAction(action, params) || isDialError(err) {
+				attempt--
+				continue
+			}
+			return nil, nil, fmt.Errorf("HTTP %s %s failed: %w", method, action, err)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("HTTP %s %s failed: %w", method, action, err)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return resp, nil, fmt.Errorf("HTTP %s %s failed: %w", method, action, err)
+		}
+		log.Printf("[TRACE] %s %s: %d", verb, action, resp.StatusCode)
+
+		envelope := &apiEnvelope{body: body}
+		if err = json.Unmarshal(body, envelope); err != nil {
+			if resp.StatusCode != http.StatusOK {
+				return resp, nil, NewAPIError(action, method, resp.StatusCode, "")
+			}
+			return resp, nil, fmt.Errorf("Json Decode %s failed: %v", action, err)
+		}
+		if envelope.Return {
+			return resp, envelope, nil
+		}
+
+		apiErr := NewAPIError(action, method, resp.StatusCode, envelope.Reason)
+		if apiErr.Kind != ErrorKindSessionExpired || attempt > 0 {
+			return resp, envelope, apiErr
+		}
+		log.Printf("[TRACE] re-login (expired CID)")
+		if err = SleepContext(ctx, 500*time.Millisecond); err != nil {
+			return resp, envelope, err
+		}
+		if err = c.refreshSession(ctx, cid, false); err != nil {
+			return resp, envelope, err
 		}
 	}
+}
 
-	return resp, body, err
+// encodeParams converts the parameters of an action to url.Values.  The
+// returned values are a copy and may be modified.
+func encodeParams(params interface{}) (url.Values, error) {
+	values := url.Values{}
+	switch p := params.(type) {
+	case nil:
+	case url.Values:
+		for k, v := range p {
+			values[k] = append([]string(nil), v...)
+		}
+	case map[string]string:
+		for k, v := range p {
+			values.Set(k, v)
+		}
+	default:
+		return form.EncodeToValues(params)
+	}
+	return values, nil
+}
+
+// requestAction returns the value of the Action field of the given request
+// struct, if any.
+func requestAction(req interface{}) string {
+	s := reflect.Indirect(reflect.ValueOf(req))
+	if s.Kind() != reflect.Struct {
+		return ""
+	}
+	f := s.FieldByName("Action")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
 }
 
 // Request makes an HTTP request with the given interface being encoded as
 // form data.
 func (c *Client) Request(verb string, path string, i interface{}) (*http.Response, error) {
-	log.Printf("[TRACE] %s %s", verb, path)
+	return c.RequestContext(c.Context(), verb, path, i)
+}
+
+// RequestContext is the same as Request but the HTTP request is bound to the
+// given context, so cancelling it or reaching its deadline aborts the request.
+func (c *Client) RequestContext(ctx context.Context, verb string, path string, i interface{}) (*http.Response, error) {
+	log.Printf("[TRACE] %s %s", verb, Redact(path))
+	if i == nil {
+		return c.request(ctx, verb, path, "")
+	}
+	buf := new(bytes.Buffer)
+	if err := form.NewEncoder(buf).Encode(i); err != nil {
+		return nil, err
+	}
+	return c.request(ctx, verb, path, buf.String())
+}
+
+// request sends the given form encoded body, if any, to path.
+func (c *Client) request(ctx context.Context, verb string, path string, body string) (*http.Response, error) {
 	var req *http.Request
 	var err error
-	if i != nil {
-		buf := new(bytes.Buffer)
-		if err = form.NewEncoder(buf).Encode(i); err != nil {
-			return nil, err
-		}
-		body := buf.String()
-		log.Printf("[TRACE] %s %s Body: %s", verb, path, body)
-		reader := strings.NewReader(body)
-		req, err = http.NewRequest(verb, path, reader)
+	if body != "" {
+		log.Printf("[TRACE] %s %s Body: %s", verb, Redact(path), Redact(body))
+		req, err = http.NewRequestWithContext(ctx, verb, path, strings.NewReader(body))
 		if err == nil {
 			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		}
 	} else {
-		req, err = http.NewRequest(verb, path, nil)
+		req, err = http.NewRequestWithContext(ctx, verb, path, nil)
 	}
-
 	if err != nil {
 		return nil, err
 	}
 	return c.HTTPClient.Do(req)
 }
+
+// SleepContext pauses for the given duration or until the context is done,
+// whichever happens first.  It returns the context's error if it was
+// interrupted.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,191 @@
+package goaviatrix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// hangingServer is a controller stub accepting logins and never answering
+// any other action before the request is cancelled.
+func hangingServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Form.Get("action") == "login" {
+			fmt.Fprint(w, `{"return":true,"CID":"cid"}`)
+			return
+		}
+		<-r.Context().Done()
+	}))
+}
+
+func TestContextAbortsRequests(t *testing.T) {
+	srv := hangingServer()
+	defer srv.Close()
+
+	client, err := NewClient("admin", "password", strings.TrimPrefix(srv.URL, "https://"), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.GetAccountContext(ctx, &Account{AccountName: "aws"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to abort the read, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the read to be aborted without retries, took %s", elapsed)
+	}
+
+	// The methods without a context use the one set on the client.
+	ctx, cancel = context.WithCancel(context.Background())
+	client.SetContext(ctx)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	if err := client.CreateDomainConnection(&AWSTgw{Name: "tgw"}, "a", "b"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelling the client context to abort the action, got %v", err)
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	if err := SleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("SleepContext: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	if err := SleepContext(ctx, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected SleepContext to return the context error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected SleepContext to return once the context is done, took %s", elapsed)
+	}
+}
+
+// echoServer is a controller stub replying to every action with its
+// parameters as results, and rejecting the reject action.
+type echoServer struct {
+	mu     sync.Mutex
+	method string
+	query  url.Values
+	form   url.Values
+}
+
+func (s *echoServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch r.Form.Get("action") {
+	case "login":
+		fmt.Fprint(w, `{"return":true,"CID":"cid"}`)
+		return
+	case "reject":
+		fmt.Fprint(w, `{"return":false,"reason":"Gateway gw1 does not exist"}`)
+		return
+	}
+	s.mu.Lock()
+	s.method, s.query, s.form = r.Method, r.URL.Query(), r.PostForm
+	s.mu.Unlock()
+	results := make(map[string]string)
+	for k := range r.Form {
+		results[k] = r.Form.Get(k)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"return": true, "results": results})
+}
+
+func TestDispatch(t *testing.T) {
+	stub := &echoServer{}
+	srv := httptest.NewTLSServer(stub)
+	defer srv.Close()
+
+	client, err := NewClient("admin", "password", strings.TrimPrefix(srv.URL, "https://"), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	params := url.Values{"gw_name": {"gw1"}}
+	var results map[string]string
+	if err := client.GetAPI("get_gateway_info", params, &results); err != nil {
+		t.Fatalf("GetAPI: %v", err)
+	}
+	want := map[string]string{"CID": "cid", "action": "get_gateway_info", "gw_name": "gw1"}
+	if fmt.Sprint(results) != fmt.Sprint(want) {
+		t.Errorf("expected results %v, got %v", want, results)
+	}
+	if stub.method != "GET" || stub.query.Get("gw_name") != "gw1" || len(stub.form) != 0 {
+		t.Errorf("expected the parameters in the query string of a GET, got %s %v %v", stub.method, stub.query, stub.form)
+	}
+	if len(params) != 1 {
+		t.Errorf("expected the caller's parameters to be left alone, got %v", params)
+	}
+
+	type request struct {
+		Action string `form:"action,omitempty"`
+		GwName string `form:"gw_name,omitempty"`
+	}
+	if err := client.PostAPI("enable_snat", &request{GwName: "gw1"}, nil); err != nil {
+		t.Fatalf("PostAPI: %v", err)
+	}
+	if stub.method != "POST" || stub.form.Get("gw_name") != "gw1" || stub.form.Get("action") != "enable_snat" ||
+		len(stub.query) != 0 {
+		t.Errorf("expected the parameters in the body of a POST, got %s %v %v", stub.method, stub.query, stub.form)
+	}
+
+	_, body, err := client.Do("POST", &request{Action: "disable_snat", GwName: "gw1"})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if stub.form.Get("action") != "disable_snat" || !strings.Contains(string(body), `"gw_name":"gw1"`) {
+		t.Errorf("expected Do to send the action of the request, got %v and %s", stub.form, body)
+	}
+
+	err = client.PostAPI("reject", map[string]string{"gw_name": "gw1"}, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Action != "reject" || apiErr.Method != "Post" || !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a NotFound APIError for reject, got %#v", err)
+	}
+}
+
+func TestEncodeParams(t *testing.T) {
+	values := url.Values{"a": {"1", "2"}}
+	got, err := encodeParams(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.Add("a", "3")
+	got.Set("CID", "cid")
+	if len(values) != 1 || len(values["a"]) != 2 {
+		t.Errorf("expected encodeParams to copy url.Values, the original became %v", values)
+	}
+
+	got, err = encodeParams(map[string]string{"b": "1"})
+	if err != nil || got.Encode() != "b=1" {
+		t.Errorf("encodeParams(map) = %v, %v", got, err)
+	}
+	got, err = encodeParams(nil)
+	if err != nil || len(got) != 0 {
+		t.Errorf("encodeParams(nil) = %v, %v", got, err)
+	}
+	got, err = encodeParams(&struct {
+		GwName string `form:"gw_name,omitempty"`
+		Empty  string `form:"empty,omitempty"`
+	}{GwName: "gw1"})
+	if err != nil || got.Encode() != "gw_name=gw1" {
+		t.Errorf("encodeParams(struct) = %v, %v", got, err)
+	}
+}
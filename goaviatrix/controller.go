@@ -1,26 +1,10 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
+	"context"
 	"log"
-	"net/url"
 )
 
-// Controller Http Access enabled get result struct
-type ControllerHttpAccessResp struct {
-	Return bool   `json:"return"`
-	Result string `json:"results"`
-	Reason string `json:"reason"`
-}
-
-type GetSecurityGroupManagementResp struct {
-	Return  bool              `json:"return"`
-	Results SecurityGroupInfo `json:"results"`
-	Reason  string            `json:"reason"`
-}
-
 type SecurityGroupInfo struct {
 	State       string `json:"state"`
 	AccountName string `json:"account_name"`
@@ -28,214 +12,102 @@ type SecurityGroupInfo struct {
 }
 
 func (c *Client) EnableHttpAccess() error {
-	url := "?CID=%s&action=config_http_access&operation=enable"
-	path := c.baseURL + fmt.Sprintf(url, c.CID)
-	resp, err := c.Get(path, nil)
-	if err != nil {
-		return err
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return err
-	}
-	if !data.Return {
-		log.Printf("[ERROR] Error invoking controller %s", data.Reason)
-		return errors.New(data.Reason)
-	}
-	return nil
+	return c.EnableHttpAccessContext(c.Context())
+}
+
+func (c *Client) EnableHttpAccessContext(ctx context.Context) error {
+	return c.configHttpAccess(ctx, "enable", nil)
 }
 
 func (c *Client) DisableHttpAccess() error {
-	url := "?CID=%s&action=config_http_access&operation=disable"
-	path := c.baseURL + fmt.Sprintf(url, c.CID)
-	resp, err := c.Get(path, nil)
-	if err != nil {
-		return err
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return err
-	}
-	if !data.Return {
-		log.Printf("[ERROR] Error invoking controller %s", data.Reason)
-		return errors.New(data.Reason)
-	}
-	return nil
+	return c.DisableHttpAccessContext(c.Context())
+}
+
+func (c *Client) DisableHttpAccessContext(ctx context.Context) error {
+	return c.configHttpAccess(ctx, "disable", nil)
 }
 
 func (c *Client) GetHttpAccessEnabled() (string, error) {
-	url := "?CID=%s&action=config_http_access&operation=get"
-	path := c.baseURL + fmt.Sprintf(url, c.CID)
-	resp, err := c.Get(path, nil)
-	if err != nil {
-		return "", err
-	}
-	var data ControllerHttpAccessResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
+	return c.GetHttpAccessEnabledContext(c.Context())
+}
+
+func (c *Client) GetHttpAccessEnabledContext(ctx context.Context) (string, error) {
+	var result string
+	if err := c.configHttpAccess(ctx, "get", &result); err != nil {
 		return "", err
 	}
-	if !data.Return {
-		log.Printf("[ERROR] Error invoking controller %s", data.Reason)
-		return "", errors.New(data.Reason)
-	}
-	result := data.Result
 	return result, nil
 }
 
-func (c *Client) EnableExceptionRule() error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for enable_fqdn_exception_rule " + err.Error())
+func (c *Client) configHttpAccess(ctx context.Context, operation string, v interface{}) error {
+	params := map[string]string{
+		"operation": operation,
 	}
-	enableFqdnExceptionRule := url.Values{}
-	enableFqdnExceptionRule.Add("CID", c.CID)
-	enableFqdnExceptionRule.Add("action", "enable_fqdn_exception_rule")
-	Url.RawQuery = enableFqdnExceptionRule.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
+	err := c.GetAPIContext(ctx, "config_http_access", params, v)
 	if err != nil {
-		return errors.New("HTTP Get enable_fqdn_exception_rule failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_fqdn_filter_tag_from_gw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API detach_fqdn_filter_tag_from_gw Get failed: " + data.Reason)
+		log.Printf("[ERROR] Error invoking controller %s", err)
 	}
+	return err
+}
 
-	return nil
+func (c *Client) EnableExceptionRule() error {
+	return c.EnableExceptionRuleContext(c.Context())
 }
 
-func (c *Client) DisableExceptionRule() error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for disable_fqdn_exception_rule " + err.Error())
-	}
-	disableFqdnExceptionRule := url.Values{}
-	disableFqdnExceptionRule.Add("CID", c.CID)
-	disableFqdnExceptionRule.Add("action", "disable_fqdn_exception_rule")
-	Url.RawQuery = disableFqdnExceptionRule.Encode()
-	resp, err := c.Get(Url.String(), nil)
+func (c *Client) EnableExceptionRuleContext(ctx context.Context) error {
+	return c.GetAPIContext(ctx, "enable_fqdn_exception_rule", nil, nil)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_fqdn_exception_rule failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_fqdn_exception_rule failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_fqdn_exception_rule Get failed: " + data.Reason)
-	}
+func (c *Client) DisableExceptionRule() error {
+	return c.DisableExceptionRuleContext(c.Context())
+}
 
-	return nil
+func (c *Client) DisableExceptionRuleContext(ctx context.Context) error {
+	return c.GetAPIContext(ctx, "disable_fqdn_exception_rule", nil, nil)
 }
 
 func (c *Client) GetExceptionRuleStatus() (bool, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return false, errors.New("url Parsing failed for get_fqdn_exception_rule_status " + err.Error())
-	}
-	getFqdnExceptionRuleStatus := url.Values{}
-	getFqdnExceptionRuleStatus.Add("CID", c.CID)
-	getFqdnExceptionRuleStatus.Add("action", "get_fqdn_exception_rule_status")
-	Url.RawQuery = getFqdnExceptionRuleStatus.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetExceptionRuleStatusContext(c.Context())
+}
 
-	if err != nil {
-		return false, errors.New("HTTP Get get_fqdn_exception_rule_status failed: " + err.Error())
-	}
-	data := GetFqdnExceptionRuleResp{
-		Return:  false,
-		Results: "",
-		Reason:  "",
+func (c *Client) GetExceptionRuleStatusContext(ctx context.Context) (bool, error) {
+	var status string
+	if err := c.GetAPIContext(ctx, "get_fqdn_exception_rule_status", nil, &status); err != nil {
+		return false, err
 	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return false, errors.New("Json Decode get_fqdn_exception_rule_status failed: " + err.Error())
-	}
-	if !data.Return {
-		return false, errors.New("Rest API get_fqdn_exception_rule_status Get failed: " + data.Reason)
-	}
-
-	if data.Results == "disabled" {
+	if status == "disabled" {
 		return false, nil
 	}
 	return true, nil
 }
 
 func (c *Client) EnableSecurityGroupManagement(account string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for enable_controller_security_group_management " + err.Error())
-	}
-	enableSecurityGroupManagement := url.Values{}
-	enableSecurityGroupManagement.Add("CID", c.CID)
-	enableSecurityGroupManagement.Add("action", "enable_controller_security_group_management")
-	enableSecurityGroupManagement.Add("access_account_name", account)
-	Url.RawQuery = enableSecurityGroupManagement.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.EnableSecurityGroupManagementContext(c.Context(), account)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_controller_security_group_management failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_controller_security_group_management failed: " + err.Error())
+func (c *Client) EnableSecurityGroupManagementContext(ctx context.Context, account string) error {
+	params := map[string]string{
+		"access_account_name": account,
 	}
-	if !data.Return {
-		return errors.New("Rest API enable_controller_security_group_management Get failed: " + data.Reason)
-	}
-
-	return nil
+	return c.GetAPIContext(ctx, "enable_controller_security_group_management", params, nil)
 }
 
 func (c *Client) DisableSecurityGroupManagement() error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for disable_controller_security_group_management " + err.Error())
-	}
-	disableSecurityGroupManagement := url.Values{}
-	disableSecurityGroupManagement.Add("CID", c.CID)
-	disableSecurityGroupManagement.Add("action", "disable_controller_security_group_management")
-	Url.RawQuery = disableSecurityGroupManagement.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get disable_controller_security_group_management failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_controller_security_group_management failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_controller_security_group_management Get failed: " + data.Reason)
-	}
+	return c.DisableSecurityGroupManagementContext(c.Context())
+}
 
-	return nil
+func (c *Client) DisableSecurityGroupManagementContext(ctx context.Context) error {
+	return c.GetAPIContext(ctx, "disable_controller_security_group_management", nil, nil)
 }
 
 func (c *Client) GetSecurityGroupManagementStatus() (*SecurityGroupInfo, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New("url Parsing failed for get_controller_security_group_management_status " + err.Error())
-	}
-	getSecurityGroupManagementStatus := url.Values{}
-	getSecurityGroupManagementStatus.Add("CID", c.CID)
-	getSecurityGroupManagementStatus.Add("action", "get_controller_security_group_management_status")
-	Url.RawQuery = getSecurityGroupManagementStatus.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetSecurityGroupManagementStatusContext(c.Context())
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get get_controller_security_group_management_status failed: " + err.Error())
-	}
-	var data GetSecurityGroupManagementResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode get_controller_security_group_management_status failed: " + err.Error())
+func (c *Client) GetSecurityGroupManagementStatusContext(ctx context.Context) (*SecurityGroupInfo, error) {
+	var data SecurityGroupInfo
+	if err := c.GetAPIContext(ctx, "get_controller_security_group_management_status", nil, &data); err != nil {
+		return nil, err
 	}
-	if !data.Return {
-		return nil, errors.New("Rest API get_controller_security_group_management_status Get failed: " + data.Reason)
-	}
-
-	return &data.Results, nil
+	return &data, nil
 }
@@ -0,0 +1,132 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// healthCheckTimeout bounds the probe of a controller before logging in to
+// it.
+const healthCheckTimeout = 10 * time.Second
+
+// apiURL returns the URL of the REST API of the given controller.
+func apiURL(controllerIP string) string {
+	return "https://" + controllerIP + "/v1/api"
+}
+
+// Endpoint returns the controller the client is logged in to, which differs
+// from ControllerIP after a failover.
+func (c *Client) Endpoint() string {
+	_, controllerIP := c.sessionState()
+	return controllerIP
+}
+
+// Endpoints returns the controllers the client may use, in order of
+// preference.
+func (c *Client) Endpoints() []string {
+	return append([]string(nil), c.endpoints...)
+}
+
+// connect logs in to the first healthy controller. A re-login tries the
+// current controller first, a failover skips it. With a single controller,
+// it is logged in to without a health check.
+func (c *Client) connect(ctx context.Context, current int, failover bool) (string, int, error) {
+	if len(c.endpoints) == 1 {
+		cid, err := c.login(ctx, c.endpoints[0])
+		return cid, 0, err
+	}
+
+	order := make([]int, 0, len(c.endpoints))
+	if !failover {
+		order = append(order, current)
+	}
+	for i := range c.endpoints {
+		if i != current {
+			order = append(order, i)
+		}
+	}
+	var failures []string
+	for _, i := range order {
+		controllerIP := c.endpoints[i]
+		err := c.healthCheck(ctx, controllerIP)
+		if err == nil {
+			var cid string
+			cid, err = c.login(ctx, controllerIP)
+			if err == nil {
+				if i != current || failover {
+					log.Printf("[WARN] Failed over to controller %s", controllerIP)
+				}
+				return cid, i, nil
+			}
+			if !isUnavailable(err) {
+				return "", 0, err
+			}
+		}
+		if ctx.Err() != nil {
+			return "", 0, ctx.Err()
+		}
+		log.Printf("[WARN] Controller %s is unavailable: %v", controllerIP, err)
+		failures = append(failures, controllerIP+": "+err.Error())
+	}
+	return "", 0, fmt.Errorf("no healthy controller available: %s", strings.Join(failures, "; "))
+}
+
+// healthCheck probes the controller with a request that does not need a
+// login. Any reply but a server error shows that it is up.
+func (c *Client) healthCheck(ctx context.Context, controllerIP string) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL(controllerIP)+"?action=list_version_info", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		if isCertificateError(err) {
+			return &CertificateError{Host: controllerIP, Err: err}
+		}
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("HTTP status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// shouldFailover reports whether the reply, or the error, of a request to
+// the current controller shows that it is unavailable, so that another one
+// should be used.
+func (c *Client) shouldFailover(ctx context.Context, resp *http.Response, err error) bool {
+	if len(c.endpoints) < 2 || ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isUnavailable(err)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// isUnavailable reports whether err shows that a controller could not be
+// reached or failed, as opposed to rejecting the request.
+func isUnavailable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && !isCertificateError(err)
+}
+
+// isDialError reports whether err occurred while connecting, so the
+// request never reached the controller.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,139 @@
+package goaviatrix
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/fakecontroller"
+)
+
+// trustingClient returns an HTTP client trusting the certificates of all the
+// given fake controllers.
+func trustingClient(servers ...*fakecontroller.Server) *http.Client {
+	pool := x509.NewCertPool()
+	for _, srv := range servers {
+		pool.AddCert(srv.Certificate())
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+}
+
+func TestFailoverOnLogin(t *testing.T) {
+	primary := fakecontroller.New()
+	primary.Close()
+	standby := fakecontroller.New()
+	defer standby.Close()
+
+	broken := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upgrade in progress", http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+	brokenIP := strings.TrimPrefix(broken.URL, "https://")
+
+	httpClient := trustingClient(primary, standby)
+	httpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs.AddCert(broken.Certificate())
+	client, err := NewClientEndpointsContext(context.Background(), standby.Username, standby.Password,
+		[]string{primary.Host(), brokenIP, standby.Host()}, httpClient)
+	if err != nil {
+		t.Fatalf("NewClientEndpointsContext: %v", err)
+	}
+	if got := client.Endpoint(); got != standby.Host() {
+		t.Errorf("expected to be logged in to %s, got %s", standby.Host(), got)
+	}
+	if client.ControllerIP != primary.Host() {
+		t.Errorf("expected ControllerIP to stay %s, got %s", primary.Host(), client.ControllerIP)
+	}
+
+	_, err = NewClientEndpointsContext(context.Background(), standby.Username, "wrong",
+		[]string{primary.Host(), standby.Host()}, httpClient)
+	if err == nil || !strings.Contains(err.Error(), "login to controller "+standby.Host()+" failed") {
+		t.Errorf("expected the login to the standby to fail, got %v", err)
+	}
+}
+
+func TestFailoverDuringRun(t *testing.T) {
+	primary := fakecontroller.New()
+	defer primary.Close()
+	standby := fakecontroller.New()
+	defer standby.Close()
+
+	client, err := NewClientEndpointsContext(context.Background(), primary.Username, primary.Password,
+		[]string{primary.Host(), standby.Host()}, trustingClient(primary, standby))
+	if err != nil {
+		t.Fatalf("NewClientEndpointsContext: %v", err)
+	}
+	if got := client.Endpoint(); got != primary.Host() {
+		t.Fatalf("expected to be logged in to %s, got %s", primary.Host(), got)
+	}
+
+	primary.Close()
+	if _, err := client.GetAccount(&Account{AccountName: "aws"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the standby to answer with ErrNotFound, got %v", err)
+	}
+	if got := client.Endpoint(); got != standby.Host() {
+		t.Errorf("expected to have failed over to %s, got %s", standby.Host(), got)
+	}
+	if got := standby.Logins(); got != 1 {
+		t.Errorf("expected 1 login to the standby, got %d", got)
+	}
+}
+
+func TestFailoverOnlyResendsReads(t *testing.T) {
+	for _, tc := range []struct {
+		action string
+		call   func(*Client) error
+		resent bool
+	}{
+		{
+			action: "list_accounts",
+			call: func(client *Client) error {
+				_, err := client.GetAccount(&Account{AccountName: "aws"})
+				return err
+			},
+			resent: true,
+		},
+		{
+			action: "add_connection_between_route_domains",
+			call: func(client *Client) error {
+				return client.CreateDomainConnection(&AWSTgw{Name: "tgw"}, "a", "b")
+			},
+		},
+	} {
+		t.Run(tc.action, func(t *testing.T) {
+			primary := httptest.NewTLSServer(&flakyServer{
+				calls:   make(map[string]int),
+				reasons: map[string]string{"list_version_info": "CID is invalid or expired."},
+			})
+			defer primary.Close()
+			standby := fakecontroller.New()
+			defer standby.Close()
+
+			httpClient := trustingClient(standby)
+			httpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs.AddCert(primary.Certificate())
+			client, err := NewClientEndpointsContext(context.Background(), standby.Username, standby.Password,
+				[]string{strings.TrimPrefix(primary.URL, "https://"), standby.Host()}, httpClient)
+			if err != nil {
+				t.Fatalf("NewClientEndpointsContext: %v", err)
+			}
+
+			err = tc.call(client)
+			if got := client.Endpoint(); got != standby.Host() {
+				t.Errorf("expected to have failed over to %s, got %s", standby.Host(), got)
+			}
+			want := 0
+			if tc.resent {
+				want = 1
+			} else if !errors.Is(err, ErrBusy) {
+				t.Errorf("expected the 504 of the primary, got %v", err)
+			}
+			if got := standby.Calls(tc.action); got != want {
+				t.Errorf("expected %d %s calls to the standby, got %d", want, tc.action, got)
+			}
+		})
+	}
+}
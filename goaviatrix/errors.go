@@ -0,0 +1,149 @@
+package goaviatrix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorKind classifies why the controller rejected an action.
+type ErrorKind int
+
+const (
+	// ErrorKindUnknown is used when the reason could not be classified.
+	ErrorKindUnknown ErrorKind = iota
+	// ErrorKindNotFound means the object the action refers to does not exist.
+	ErrorKindNotFound
+	// ErrorKindAlreadyExists means the object the action creates already exists.
+	ErrorKindAlreadyExists
+	// ErrorKindSessionExpired means the CID is no longer valid.
+	ErrorKindSessionExpired
+	// ErrorKindBusy means the controller is running a conflicting operation.
+	ErrorKindBusy
+	// ErrorKindValidation means the controller rejected the given parameters.
+	ErrorKindValidation
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindNotFound:
+		return "NotFound"
+	case ErrorKindAlreadyExists:
+		return "AlreadyExists"
+	case ErrorKindSessionExpired:
+		return "SessionExpired"
+	case ErrorKindBusy:
+		return "Busy"
+	case ErrorKindValidation:
+		return "Validation"
+	}
+	return "Unknown"
+}
+
+// Sentinel errors matching the kinds of APIError with errors.Is. ErrNotFound
+// is declared in utils.go.
+var (
+	ErrAlreadyExists  = errors.New("ErrAlreadyExists")
+	ErrSessionExpired = errors.New("ErrSessionExpired")
+	ErrBusy           = errors.New("ErrBusy")
+	ErrValidation     = errors.New("ErrValidation")
+)
+
+// reasonKinds maps fragments of controller reasons to their kind. The first
+// matching entry wins, so more specific fragments come first.
+var reasonKinds = []struct {
+	fragment string
+	kind     ErrorKind
+}{
+	{"cid is invalid or expired", ErrorKindSessionExpired},
+	{"in progress", ErrorKindBusy},
+	{"is busy", ErrorKindBusy},
+	{"try again later", ErrorKindBusy},
+	{"already exist", ErrorKindAlreadyExists},
+	{"already enabled", ErrorKindAlreadyExists},
+	{"duplicate", ErrorKindAlreadyExists},
+	{"does not exist", ErrorKindNotFound},
+	{"not found", ErrorKindNotFound},
+	{"invalid vpn username", ErrorKindNotFound},
+	{"invalid", ErrorKindValidation},
+	{"must be", ErrorKindValidation},
+	{"is required", ErrorKindValidation},
+	{"missing", ErrorKindValidation},
+	{"not allowed", ErrorKindValidation},
+	{"not supported", ErrorKindValidation},
+}
+
+// APIError is returned when the controller rejects an action or replies
+// with an unexpected HTTP status.
+type APIError struct {
+	// Action is the REST API action, e.g. "connect_container".
+	Action string
+	// Method is the HTTP method used for the action.
+	Method string
+	// StatusCode is the HTTP status of the reply, 0 if unknown.
+	StatusCode int
+	// Reason is the reason given by the controller.
+	Reason string
+	// Kind is the classification of Reason and StatusCode.
+	Kind ErrorKind
+}
+
+// NewAPIError creates an APIError for the given action and classifies it.
+func NewAPIError(action string, method string, statusCode int, reason string) *APIError {
+	return &APIError{
+		Action:     action,
+		Method:     method,
+		StatusCode: statusCode,
+		Reason:     reason,
+		Kind:       classifyError(statusCode, reason),
+	}
+}
+
+func (e *APIError) Error() string {
+	if e.Reason == "" && e.StatusCode != 0 && e.StatusCode != http.StatusOK {
+		return fmt.Sprintf("Rest API %s %s failed: HTTP status %d", e.Action, e.Method, e.StatusCode)
+	}
+	return "Rest API " + e.Action + " " + e.Method + " failed: " + e.Reason
+}
+
+// Is makes errors.Is(err, ErrNotFound) and the other sentinel errors match
+// an APIError of the corresponding kind.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Kind == ErrorKindNotFound
+	case ErrAlreadyExists:
+		return e.Kind == ErrorKindAlreadyExists
+	case ErrSessionExpired:
+		return e.Kind == ErrorKindSessionExpired
+	case ErrBusy:
+		return e.Kind == ErrorKindBusy
+	case ErrValidation:
+		return e.Kind == ErrorKindValidation
+	}
+	return false
+}
+
+// ReasonContains reports whether err is an APIError whose reason contains
+// the given fragment.
+func ReasonContains(err error, fragment string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && strings.Contains(apiErr.Reason, fragment)
+}
+
+func classifyError(statusCode int, reason string) ErrorKind {
+	lower := strings.ToLower(reason)
+	for _, rk := range reasonKinds {
+		if strings.Contains(lower, rk.fragment) {
+			return rk.kind
+		}
+	}
+	switch statusCode {
+	case http.StatusNotFound:
+		return ErrorKindNotFound
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrorKindBusy
+	}
+	return ErrorKindUnknown
+}
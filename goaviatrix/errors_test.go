@@ -0,0 +1,90 @@
+package goaviatrix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		reason     string
+		want       ErrorKind
+	}{
+		{http.StatusOK, "CID is invalid or expired.", ErrorKindSessionExpired},
+		{http.StatusOK, "Another operation is in progress.", ErrorKindBusy},
+		{http.StatusOK, "The controller is busy, try again later.", ErrorKindBusy},
+		{http.StatusOK, "Account aws already exists.", ErrorKindAlreadyExists},
+		{http.StatusOK, "Gateway gw1 does not exist", ErrorKindNotFound},
+		{http.StatusOK, "Invalid VPN username user1", ErrorKindNotFound},
+		{http.StatusOK, "Invalid CIDR 10.0.0.0/33", ErrorKindValidation},
+		{http.StatusOK, "gw_size is required", ErrorKindValidation},
+		{http.StatusOK, "something else went wrong", ErrorKindUnknown},
+		// Reasons are classified before the status.
+		{http.StatusServiceUnavailable, "Gateway gw1 does not exist", ErrorKindNotFound},
+		{http.StatusNotFound, "", ErrorKindNotFound},
+		{http.StatusTooManyRequests, "", ErrorKindBusy},
+		{http.StatusBadGateway, "", ErrorKindBusy},
+		{http.StatusServiceUnavailable, "", ErrorKindBusy},
+		{http.StatusGatewayTimeout, "", ErrorKindBusy},
+		{http.StatusInternalServerError, "", ErrorKindUnknown},
+	} {
+		if got := classifyError(tc.statusCode, tc.reason); got != tc.want {
+			t.Errorf("classifyError(%d, %q) = %s, want %s", tc.statusCode, tc.reason, got, tc.want)
+		}
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{ErrNotFound, ErrAlreadyExists, ErrSessionExpired, ErrBusy, ErrValidation}
+	for _, tc := range []struct {
+		err  *APIError
+		want error
+	}{
+		{NewAPIError("get_gateway_info", "Get", http.StatusOK, "Gateway gw1 does not exist"), ErrNotFound},
+		{NewAPIError("setup_account_profile", "Post", http.StatusOK, "Account aws already exists."), ErrAlreadyExists},
+		{NewAPIError("list_vpcs_summary", "Get", http.StatusOK, "CID is invalid or expired."), ErrSessionExpired},
+		{NewAPIError("list_vpcs_summary", "Get", http.StatusGatewayTimeout, ""), ErrBusy},
+		{NewAPIError("connect_container", "Post", http.StatusOK, "Invalid gw_size"), ErrValidation},
+		{NewAPIError("connect_container", "Post", http.StatusOK, "something else went wrong"), nil},
+	} {
+		wrapped := fmt.Errorf("failed to create gateway: %w", tc.err)
+		for _, sentinel := range sentinels {
+			if got := errors.Is(wrapped, sentinel); got != (sentinel == tc.want) {
+				t.Errorf("errors.Is(%q, %v) = %t", tc.err, sentinel, got)
+			}
+		}
+		var apiErr *APIError
+		if !errors.As(wrapped, &apiErr) || apiErr != tc.err {
+			t.Errorf("errors.As(%q) did not return the APIError", tc.err)
+		}
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	for _, tc := range []struct {
+		err  *APIError
+		want string
+	}{
+		{NewAPIError("connect_container", "Post", http.StatusOK, "Invalid gw_size"),
+			"Rest API connect_container Post failed: Invalid gw_size"},
+		{NewAPIError("list_vpcs_summary", "Get", http.StatusGatewayTimeout, ""),
+			"Rest API list_vpcs_summary Get failed: HTTP status 504"},
+		{NewAPIError("list_vpcs_summary", "Get", 0, "no reply"),
+			"Rest API list_vpcs_summary Get failed: no reply"},
+	} {
+		if got := tc.err.Error(); got != tc.want {
+			t.Errorf("Error() = %q, want %q", got, tc.want)
+		}
+	}
+
+	err := fmt.Errorf("wrapped: %w", NewAPIError("add_vpn_user", "Post", http.StatusOK, "User user1 already exists"))
+	if !ReasonContains(err, "already exists") {
+		t.Errorf("expected ReasonContains to find the reason of %v", err)
+	}
+	if ReasonContains(errors.New("User user1 already exists"), "already exists") {
+		t.Error("expected ReasonContains to only match an APIError")
+	}
+}
@@ -0,0 +1,209 @@
+package fakecontroller
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// accountFields maps the parameters of setup_account_profile to the fields
+// of list_accounts. Secrets are never listed.
+var accountFields = map[string]string{
+	"account_name":              "account_name",
+	"aws_account_number":        "account_number",
+	"aws_iam":                   "aws_iam",
+	"aws_access_key":            "account_access_key",
+	"aws_role_arn":              "aws_role_arn",
+	"aws_role_ec2":              "aws_role_ec2",
+	"arm_subscription_id":       "arm_subscription_id",
+	"arm_application_endpoint":  "arm_ad_tenant_id",
+	"arm_application_client_id": "arm_ad_client_id",
+	"gcloud_project_name":       "project",
+	"awsgov_account_number":     "awsgov_account_number",
+	"awsgov_access_key":         "awsgov_access_key",
+	"awschina_account_number":   "awschina_account_number",
+	"awschina_access_key":       "awschinacloud_access_key",
+}
+
+// accountUserFields maps the parameters of add_account_user to the fields
+// of list_account_users.
+var accountUserFields = map[string]string{
+	"username":     "user_name",
+	"account_name": "acct_names",
+	"email":        "user_email",
+}
+
+func (s *Server) registerAccounts() {
+	s.handlers["setup_account_profile"] = s.setupAccountProfile
+	s.handlers["edit_account_profile"] = s.editAccountProfile
+	s.handlers["list_accounts"] = s.listAccounts
+	s.handlers["delete_account_profile"] = s.deleteAccountProfile
+	s.handlers["upload_file"] = s.uploadFile
+	s.handlers["add_account_user"] = s.addAccountUser
+	s.handlers["edit_account_user"] = s.editAccountUser
+	s.handlers["list_account_users"] = s.listAccountUsers
+	s.handlers["delete_account_user"] = s.deleteAccountUser
+}
+
+func (s *Server) setupAccountProfile(params url.Values) (interface{}, error) {
+	if err := require(params, "account_name", "cloud_type"); err != nil {
+		return nil, err
+	}
+	name := params.Get("account_name")
+	if _, ok := s.accounts.get(name); ok {
+		return nil, alreadyExists("Account", name)
+	}
+	cloudType, err := strconv.Atoi(params.Get("cloud_type"))
+	if err != nil {
+		return nil, fmt.Errorf("cloud_type %q is invalid", params.Get("cloud_type"))
+	}
+	switch cloudType {
+	case 1:
+		if err := require(params, "aws_account_number"); err != nil {
+			return nil, err
+		}
+		if params.Get("aws_iam") == "true" {
+			s.setDefaultRoles(params)
+		} else if err := require(params, "aws_access_key", "aws_secret_key"); err != nil {
+			return nil, err
+		}
+	case 4:
+		if err := require(params, "gcloud_project_name"); err != nil {
+			return nil, err
+		}
+	case 8:
+		if err := require(params, "arm_subscription_id", "arm_application_endpoint",
+			"arm_application_client_id", "arm_application_client_secret"); err != nil {
+			return nil, err
+		}
+	}
+	account := object{"cloud_type": cloudType}
+	account.set(params, accountFields)
+	s.accounts.put(name, account)
+	return "An email with instructions has been sent to the account email.", nil
+}
+
+// setDefaultRoles fills in the roles the controller assumes for IAM role
+// based AWS accounts.
+func (s *Server) setDefaultRoles(params url.Values) {
+	arn := "arn:aws:iam::" + params.Get("aws_account_number") + ":role/"
+	if params.Get("aws_role_arn") == "" {
+		params.Set("aws_role_arn", arn+"aviatrix-role-app")
+	}
+	if params.Get("aws_role_ec2") == "" {
+		params.Set("aws_role_ec2", arn+"aviatrix-role-ec2")
+	}
+}
+
+func (s *Server) editAccountProfile(params url.Values) (interface{}, error) {
+	name := params.Get("account_name")
+	account, ok := s.accounts.get(name)
+	if !ok {
+		return nil, notFound("Account", name)
+	}
+	if params.Get("aws_iam") == "true" {
+		s.setDefaultRoles(params)
+		delete(account, "account_access_key")
+	} else if params.Get("aws_iam") == "false" {
+		delete(account, "aws_role_arn")
+		delete(account, "aws_role_ec2")
+	}
+	account.set(params, accountFields)
+	return "Account " + name + " has been updated.", nil
+}
+
+func (s *Server) listAccounts(params url.Values) (interface{}, error) {
+	return map[string]interface{}{"account_list": s.accounts.list()}, nil
+}
+
+func (s *Server) deleteAccountProfile(params url.Values) (interface{}, error) {
+	name := params.Get("account_name")
+	if _, ok := s.accounts.get(name); !ok {
+		return nil, notFound("Account", name)
+	}
+	for _, gw := range s.gateways.list() {
+		if gw.str("account_name") == name {
+			return nil, fmt.Errorf("Account %s is in use by gateway %s", name, gw.str("vpc_name"))
+		}
+	}
+	for _, tgw := range s.tgws.list() {
+		if tgw.str("acct_name") == name {
+			return nil, fmt.Errorf("Account %s is in use by AWS TGW %s", name, tgw.str("name"))
+		}
+	}
+	s.accounts.delete(name)
+	return "Account " + name + " has been deleted.", nil
+}
+
+func (s *Server) uploadFile(params url.Values) (interface{}, error) {
+	if err := require(params, "filename", "contents"); err != nil {
+		return nil, err
+	}
+	return "File " + params.Get("filename") + " has been uploaded.", nil
+}
+
+// accessAccount reports whether users can be added to the named account,
+// which is either a cloud account or the built-in admin account.
+func (s *Server) accessAccount(name string) bool {
+	if name == "admin" {
+		return true
+	}
+	_, ok := s.accounts.get(name)
+	return ok
+}
+
+func (s *Server) addAccountUser(params url.Values) (interface{}, error) {
+	if err := require(params, "username", "account_name", "email", "password"); err != nil {
+		return nil, err
+	}
+	name := params.Get("username")
+	if _, ok := s.users.get(name); ok {
+		return nil, alreadyExists("User", name)
+	}
+	if !s.accessAccount(params.Get("account_name")) {
+		return nil, notFound("Account", params.Get("account_name"))
+	}
+	user := object{}
+	user.set(params, accountUserFields)
+	s.users.put(name, user)
+	return "User " + name + " has been added.", nil
+}
+
+func (s *Server) editAccountUser(params url.Values) (interface{}, error) {
+	name := params.Get("username")
+	user, ok := s.users.get(name)
+	if !ok {
+		return nil, notFound("User", name)
+	}
+	switch what := params.Get("what"); what {
+	case "email":
+		if err := require(params, "email"); err != nil {
+			return nil, err
+		}
+		user["user_email"] = params.Get("email")
+	case "account_name":
+		if !s.accessAccount(params.Get("account_name")) {
+			return nil, notFound("Account", params.Get("account_name"))
+		}
+		user["acct_names"] = params.Get("account_name")
+	case "password":
+		if err := require(params, "old_password", "new_password"); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("what %q is invalid", what)
+	}
+	return "User " + name + " has been updated.", nil
+}
+
+func (s *Server) listAccountUsers(params url.Values) (interface{}, error) {
+	return s.users.list(), nil
+}
+
+func (s *Server) deleteAccountUser(params url.Values) (interface{}, error) {
+	name := params.Get("username")
+	if !s.users.delete(name) {
+		return nil, notFound("User", name)
+	}
+	return "User " + name + " has been deleted.", nil
+}
@@ -0,0 +1,362 @@
+package fakecontroller
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Security domains every AWS TGW is created with. The edge domain holds the
+// attachments of Aviatrix transit gateways and is not listed by
+// list_route_domain_names.
+const (
+	edgeDomain          = "Aviatrix_Edge_Domain"
+	defaultDomain       = "Default_Domain"
+	sharedServiceDomain = "Shared_Service_Domain"
+)
+
+var defaultDomains = []string{edgeDomain, defaultDomain, sharedServiceDomain}
+
+// tgw is an AWS TGW with its security domains and attachments.
+type tgw struct {
+	id          string
+	name        string
+	accountName string
+	region      string
+	asn         int
+	domains     *table
+	routeTables int
+}
+
+func (s *Server) registerAwsTgw() {
+	s.handlers["add_aws_tgw"] = s.addAwsTgw
+	s.handlers["list_tgw_details"] = s.listTgwDetails
+	s.handlers["delete_aws_tgw"] = s.deleteAwsTgw
+	s.handlers["add_route_domain"] = s.addRouteDomain
+	s.handlers["list_route_domain_names"] = s.listRouteDomainNames
+	s.handlers["view_route_domain_details"] = s.viewRouteDomainDetails
+	s.handlers["delete_route_domain"] = s.deleteRouteDomain
+	s.handlers["add_connection_between_route_domains"] = s.connectRouteDomains(true)
+	s.handlers["delete_connection_between_route_domains"] = s.connectRouteDomains(false)
+	s.handlers["attach_vpc_to_tgw"] = s.attachVpcToTgw
+	s.handlers["detach_vpc_from_tgw"] = s.detachVpcFromTgw
+	s.handlers["list_attached_vpc_names_to_route_domain"] = s.listAttachedVpcNames
+}
+
+func (s *Server) tgw(params url.Values) (*tgw, error) {
+	name := params.Get("tgw_name")
+	if name == "" {
+		return nil, fmt.Errorf("tgw_name is required")
+	}
+	o, ok := s.tgws.get(name)
+	if !ok {
+		return nil, notFound("AWS TGW", name)
+	}
+	return o["tgw"].(*tgw), nil
+}
+
+func (t *tgw) domain(name string) (object, error) {
+	if name == "" {
+		return nil, fmt.Errorf("route_domain_name is required")
+	}
+	domain, ok := t.domains.get(name)
+	if !ok {
+		return nil, notFound("Security domain", name)
+	}
+	return domain, nil
+}
+
+func (t *tgw) addDomain(name string) {
+	t.routeTables++
+	t.domains.put(name, object{
+		"name":           name,
+		"route_table_id": fmt.Sprintf("tgw-rtb-%017x", t.routeTables),
+		"connected":      map[string]bool{},
+		"attached":       newTable(),
+	})
+}
+
+// attachment returns the domain the VPC is attached to and its attachment.
+func (t *tgw) attachment(vpcID string) (object, object) {
+	for _, domain := range t.domains.list() {
+		if a, ok := domain["attached"].(*table).get(vpcID); ok {
+			return domain, a
+		}
+	}
+	return nil, nil
+}
+
+func connected(domain object) map[string]bool {
+	return domain["connected"].(map[string]bool)
+}
+
+func (s *Server) addAwsTgw(params url.Values) (interface{}, error) {
+	if err := require(params, "tgw_name", "account_name", "region", "aws_side_asn"); err != nil {
+		return nil, err
+	}
+	name := params.Get("tgw_name")
+	if _, ok := s.tgws.get(name); ok {
+		return nil, alreadyExists("AWS TGW", name)
+	}
+	if _, ok := s.accounts.get(params.Get("account_name")); !ok {
+		return nil, notFound("Account", params.Get("account_name"))
+	}
+	asn, err := strconv.Atoi(params.Get("aws_side_asn"))
+	if err != nil || asn < 64512 || asn > 4294967294 {
+		return nil, fmt.Errorf("aws_side_asn %q is invalid", params.Get("aws_side_asn"))
+	}
+	t := &tgw{
+		id:          fmt.Sprintf("tgw-%017x", len(s.tgws.names)+1),
+		name:        name,
+		accountName: params.Get("account_name"),
+		region:      params.Get("region"),
+		asn:         asn,
+		domains:     newTable(),
+	}
+	for _, domain := range defaultDomains {
+		t.addDomain(domain)
+	}
+	for _, a := range defaultDomains {
+		for _, b := range defaultDomains {
+			if a != b {
+				connected(t.domains.rows[a])[b] = true
+			}
+		}
+	}
+	s.tgws.put(name, object{"name": name, "acct_name": t.accountName, "tgw": t})
+	return "AWS TGW " + name + " has been created.", nil
+}
+
+func (s *Server) listTgwDetails(params url.Values) (interface{}, error) {
+	t, err := s.tgw(params)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"_id":  t.id,
+		"name": t.name,
+		"tgw_info": map[string]interface{}{
+			"acct_name":   t.accountName,
+			"region":      t.region,
+			"tgw_aws_asn": t.asn,
+		},
+	}, nil
+}
+
+func (s *Server) deleteAwsTgw(params url.Values) (interface{}, error) {
+	t, err := s.tgw(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, domain := range t.domains.list() {
+		if attached := domain["attached"].(*table); len(attached.names) != 0 {
+			return nil, fmt.Errorf("AWS TGW %s still has VPC %s attached", t.name, attached.names[0])
+		}
+	}
+	s.tgws.delete(t.name)
+	return "AWS TGW " + t.name + " has been deleted.", nil
+}
+
+func (s *Server) addRouteDomain(params url.Values) (interface{}, error) {
+	t, err := s.tgw(params)
+	if err != nil {
+		return nil, err
+	}
+	name := params.Get("route_domain_name")
+	if name == "" {
+		return nil, fmt.Errorf("route_domain_name is required")
+	}
+	if _, ok := t.domains.get(name); ok {
+		return nil, alreadyExists("Security domain", name)
+	}
+	t.addDomain(name)
+	return "Security domain " + name + " has been created.", nil
+}
+
+func (s *Server) listRouteDomainNames(params url.Values) (interface{}, error) {
+	t, err := s.tgw(params)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, name := range t.domains.names {
+		if name != edgeDomain {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (s *Server) viewRouteDomainDetails(params url.Values) (interface{}, error) {
+	t, err := s.tgw(params)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := t.domain(params.Get("route_domain_name"))
+	if err != nil {
+		return nil, err
+	}
+	attached := domain["attached"].(*table)
+	var routes []object
+	for _, a := range attached.list() {
+		for _, cidr := range a["vpc_cidr"].([]string) {
+			routes = append(routes, object{
+				"vpc_id":            a["vpc_id"],
+				"cidr_block":        cidr,
+				"type":              "propagated",
+				"state":             "active",
+				"tgw_attachment_id": a["attachment_id"],
+			})
+		}
+	}
+	return []object{{
+		"name":                   domain["name"],
+		"associations":           attached.names,
+		"connected_route_domain": sortedKeys(connected(domain)),
+		"attached_vpc":           attached.list(),
+		"routes_in_route_table":  routes,
+		"route_table_id":         domain["route_table_id"],
+	}}, nil
+}
+
+func (s *Server) deleteRouteDomain(params url.Values) (interface{}, error) {
+	t, err := s.tgw(params)
+	if err != nil {
+		return nil, err
+	}
+	name := params.Get("route_domain_name")
+	domain, err := t.domain(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range defaultDomains {
+		if name == d {
+			return nil, fmt.Errorf("security domain %s is not allowed to be deleted", name)
+		}
+	}
+	if attached := domain["attached"].(*table); len(attached.names) != 0 {
+		return nil, fmt.Errorf("Security domain %s still has VPC %s attached", name, attached.names[0])
+	}
+	for other := range connected(domain) {
+		delete(connected(t.domains.rows[other]), name)
+	}
+	t.domains.delete(name)
+	return "Security domain " + name + " has been deleted.", nil
+}
+
+func (s *Server) connectRouteDomains(connect bool) HandlerFunc {
+	return func(params url.Values) (interface{}, error) {
+		t, err := s.tgw(params)
+		if err != nil {
+			return nil, err
+		}
+		source, err := t.domain(params.Get("source_route_domain_name"))
+		if err != nil {
+			return nil, err
+		}
+		destination, err := t.domain(params.Get("destination_route_domain_name"))
+		if err != nil {
+			return nil, err
+		}
+		a, b := source.str("name"), destination.str("name")
+		if a == b {
+			return nil, fmt.Errorf("connection of security domain %s to itself is not allowed", a)
+		}
+		conn := "Connection between " + a + " and " + b
+		if connect {
+			if connected(source)[b] {
+				return nil, fmt.Errorf("%s already exists", conn)
+			}
+			connected(source)[b] = true
+			connected(destination)[a] = true
+			return conn + " has been created.", nil
+		}
+		if !connected(source)[b] {
+			return nil, fmt.Errorf("%s does not exist", conn)
+		}
+		delete(connected(source), b)
+		delete(connected(destination), a)
+		return conn + " has been deleted.", nil
+	}
+}
+
+func (s *Server) attachVpcToTgw(params url.Values) (interface{}, error) {
+	if err := require(params, "tgw_name", "vpc_account_name", "vpc_name", "route_domain_name"); err != nil {
+		return nil, err
+	}
+	t, err := s.tgw(params)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := t.domain(params.Get("route_domain_name"))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.accounts.get(params.Get("vpc_account_name")); !ok {
+		return nil, notFound("Account", params.Get("vpc_account_name"))
+	}
+	vpcID := params.Get("vpc_name")
+	if gwName := params.Get("gateway_name"); gwName != "" {
+		gw, ok := s.gateways.get(gwName)
+		if !ok {
+			return nil, notFound("Gateway", gwName)
+		}
+		if domain.str("name") != edgeDomain {
+			return nil, fmt.Errorf("Aviatrix transit gateways must be attached to %s", edgeDomain)
+		}
+		vpcID = strings.Split(gw.str("vpc_id"), "~~")[0]
+	} else if domain.str("name") == edgeDomain {
+		return nil, fmt.Errorf("only Aviatrix transit gateways are allowed in %s", edgeDomain)
+	}
+	if d, _ := t.attachment(vpcID); d != nil {
+		return nil, fmt.Errorf("VPC %s is already attached to security domain %s", vpcID, d.str("name"))
+	}
+	region := params.Get("region")
+	if region == "" {
+		region = t.region
+	}
+	s.attachments++
+	domain["attached"].(*table).put(vpcID, object{
+		"tgw_name":      t.name,
+		"region":        region,
+		"vpc_name":      vpcID,
+		"attachment_id": fmt.Sprintf("tgw-attach-%017x", s.attachments),
+		"route_domain":  domain["name"],
+		"vpc_cidr":      []string{fmt.Sprintf("10.%d.0.0/16", s.attachments%256)},
+		"vpc_id":        vpcID,
+		"account_name":  params.Get("vpc_account_name"),
+	})
+	return "VPC " + vpcID + " has been attached to AWS TGW " + t.name + ".", nil
+}
+
+func (s *Server) detachVpcFromTgw(params url.Values) (interface{}, error) {
+	t, err := s.tgw(params)
+	if err != nil {
+		return nil, err
+	}
+	vpcID := params.Get("vpc_name")
+	domain, _ := t.attachment(vpcID)
+	if domain == nil {
+		return nil, notFound("Attachment of VPC", vpcID)
+	}
+	domain["attached"].(*table).delete(vpcID)
+	return "VPC " + vpcID + " has been detached from AWS TGW " + t.name + ".", nil
+}
+
+func (s *Server) listAttachedVpcNames(params url.Values) (interface{}, error) {
+	t, err := s.tgw(params)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, domain := range t.domains.list() {
+		if domain.str("name") == edgeDomain {
+			continue
+		}
+		for _, a := range domain["attached"].(*table).list() {
+			names = append(names, a.str("vpc_id")+"~~"+a.str("account_name"))
+		}
+	}
+	return names, nil
+}
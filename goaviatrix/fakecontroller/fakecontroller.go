@@ -0,0 +1,328 @@
+// Package fakecontroller runs an in-process stand-in for the REST API of an
+// Aviatrix controller, backed by an in-memory model instead of a controller
+// and cloud accounts. It lets goaviatrix and the provider resources be tested
+// end to end without network access.
+//
+// The fake implements the actions used to manage accounts, account users,
+// gateways, resource tags, VPN profiles, site2cloud connections and AWS
+// TGWs. It checks the parameters the controller requires and keeps the
+// objects they create, but does not model any cloud resource: VPCs are
+// taken as they are given and gateways come up immediately. Other actions
+// are rejected unless a handler is registered for them with Handle.
+package fakecontroller
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultUsername is the username accepted by a new Server.
+	DefaultUsername = "admin"
+	// DefaultPassword is the password accepted by a new Server.
+	DefaultPassword = "password"
+	// DefaultVersion is the controller version reported by a new Server.
+	DefaultVersion = "UserConnect-4.7.520"
+)
+
+// HandlerFunc implements an action. It is given the parameters of the
+// request and returns the "results" of the reply, or an error whose message
+// becomes the "reason" of a rejection. Handlers run with the Server locked,
+// so they must not call its methods.
+type HandlerFunc func(params url.Values) (interface{}, error)
+
+// Server is a fake controller listening on a local TLS port. The zero value
+// is not usable; create one with New.
+type Server struct {
+	// Username and Password are the credentials accepted by login.
+	Username string
+	Password string
+	// Version is the controller version reported by list_version_info.
+	Version string
+
+	srv      *httptest.Server
+	mu       sync.Mutex
+	logins   int
+	sessions map[string]bool
+	handlers map[string]HandlerFunc
+	calls    map[string]int
+
+	accounts    *table
+	users       *table
+	gateways    *table
+	tags        *table
+	profiles    *table
+	site2clouds *table
+	tgws        *table
+	attachments int
+}
+
+// New starts a fake controller with the default credentials and version and
+// an empty model. Close it when done.
+func New() *Server {
+	s := &Server{
+		Username:    DefaultUsername,
+		Password:    DefaultPassword,
+		Version:     DefaultVersion,
+		sessions:    make(map[string]bool),
+		calls:       make(map[string]int),
+		accounts:    newTable(),
+		users:       newTable(),
+		gateways:    newTable(),
+		tags:        newTable(),
+		profiles:    newTable(),
+		site2clouds: newTable(),
+		tgws:        newTable(),
+	}
+	s.handlers = map[string]HandlerFunc{
+		"list_version_info": s.listVersionInfo,
+	}
+	s.registerAccounts()
+	s.registerGateways()
+	s.registerProfiles()
+	s.registerSite2Cloud()
+	s.registerAwsTgw()
+	s.srv = httptest.NewTLSServer(s)
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Host returns the address of the server, to be used as controller IP.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.srv.URL, "https://")
+}
+
+// URL returns the URL of the REST API.
+func (s *Server) URL() string {
+	return s.srv.URL + "/v1/api"
+}
+
+// Client returns an HTTP client trusting the server's certificate.
+func (s *Server) Client() *http.Client {
+	return s.srv.Client()
+}
+
+// CAPEM returns the PEM encoded certificate of the server, which is valid
+// for 127.0.0.1.
+func (s *Server) CAPEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.srv.Certificate().Raw}))
+}
+
+// Certificate returns the certificate of the server.
+func (s *Server) Certificate() *x509.Certificate {
+	return s.srv.Certificate()
+}
+
+// Handle registers the handler for an action, replacing the built-in one if
+// any. It can be used to add actions or to inject failures.
+func (s *Server) Handle(action string, h HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[action] = h
+}
+
+// Calls returns the number of requests received for an action, including
+// rejected ones.
+func (s *Server) Calls(action string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[action]
+}
+
+// Logins returns the number of successful logins.
+func (s *Server) Logins() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logins
+}
+
+// Expire invalidates all the sessions, as a controller restart would.
+func (s *Server) Expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]bool)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/api" {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := r.Form
+	action := params.Get("action")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[action]++
+
+	if action == "login" {
+		s.login(w, params)
+		return
+	}
+	if !s.sessions[params.Get("CID")] {
+		reply(w, false, nil, "CID is invalid or expired.")
+		return
+	}
+	h, ok := s.handlers[action]
+	if !ok {
+		reply(w, false, nil, fmt.Sprintf("action %s is not supported by the fake controller", action))
+		return
+	}
+	results, err := h(params)
+	if err != nil {
+		reply(w, false, nil, err.Error())
+		return
+	}
+	reply(w, true, results, "")
+}
+
+func (s *Server) login(w http.ResponseWriter, params url.Values) {
+	if params.Get("username") != s.Username || params.Get("password") != s.Password {
+		reply(w, false, nil, "Invalid username or password.")
+		return
+	}
+	s.logins++
+	cid := fmt.Sprintf("fake-cid-%d", s.logins)
+	s.sessions[cid] = true
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"return":  true,
+		"results": "User login:" + s.Username + " in account:" + s.Username + " has been authorized successfully",
+		"CID":     cid,
+	})
+}
+
+func (s *Server) listVersionInfo(params url.Values) (interface{}, error) {
+	return map[string]string{
+		"current_version": s.Version,
+		"latest_version":  s.Version,
+	}, nil
+}
+
+func reply(w http.ResponseWriter, ok bool, results interface{}, reason string) {
+	body := map[string]interface{}{"return": ok}
+	if ok {
+		body["results"] = results
+	} else {
+		body["reason"] = reason
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+// require returns an error naming the first of the given parameters that is
+// missing.
+func require(params url.Values, names ...string) error {
+	for _, name := range names {
+		if params.Get(name) == "" {
+			return fmt.Errorf("%s is required", name)
+		}
+	}
+	return nil
+}
+
+func notFound(kind string, name string) error {
+	return fmt.Errorf("%s %s does not exist", kind, name)
+}
+
+func alreadyExists(kind string, name string) error {
+	return fmt.Errorf("%s %s already exists", kind, name)
+}
+
+// object is a controller object as it appears in the replies.
+type object map[string]interface{}
+
+// set copies the given parameters, when present, into o under the names the
+// controller uses in its replies.
+func (o object) set(params url.Values, names map[string]string) {
+	for param, field := range names {
+		if v, ok := params[param]; ok && len(v) > 0 {
+			o[field] = v[0]
+		}
+	}
+}
+
+func (o object) str(field string) string {
+	v, _ := o[field].(string)
+	return v
+}
+
+// table holds objects by name, listing them in the order they were created.
+type table struct {
+	names []string
+	rows  map[string]object
+}
+
+func newTable() *table {
+	return &table{rows: make(map[string]object)}
+}
+
+func (t *table) get(name string) (object, bool) {
+	o, ok := t.rows[name]
+	return o, ok
+}
+
+func (t *table) put(name string, o object) {
+	if _, ok := t.rows[name]; !ok {
+		t.names = append(t.names, name)
+	}
+	t.rows[name] = o
+}
+
+func (t *table) delete(name string) bool {
+	if _, ok := t.rows[name]; !ok {
+		return false
+	}
+	delete(t.rows, name)
+	for i := range t.names {
+		if t.names[i] == name {
+			t.names = append(t.names[:i], t.names[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (t *table) list() []object {
+	list := make([]object, 0, len(t.names))
+	for _, name := range t.names {
+		list = append(list, t.rows[name])
+	}
+	return list
+}
+
+// splitList splits a comma separated list, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,169 @@
+package fakecontroller_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/fakecontroller"
+)
+
+func newClient(t *testing.T, srv *fakecontroller.Server) *goaviatrix.Client {
+	client, err := goaviatrix.NewClient(srv.Username, srv.Password, srv.Host(), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestLogin(t *testing.T) {
+	srv := fakecontroller.New()
+	defer srv.Close()
+
+	if _, err := goaviatrix.NewClient(srv.Username, "wrong", srv.Host(), srv.Client()); err == nil {
+		t.Error("expected login with a wrong password to fail")
+	}
+	client := newClient(t, srv)
+	if err := client.ControllerVersionValidation("4.7"); err != nil {
+		t.Errorf("ControllerVersionValidation: %v", err)
+	}
+
+	srv.Expire()
+	if _, err := client.GetAccount(&goaviatrix.Account{AccountName: "missing"}); err != goaviatrix.ErrNotFound {
+		t.Errorf("expected ErrNotFound after re-login, got %v", err)
+	}
+	if got := srv.Logins(); got != 2 {
+		t.Errorf("expected 2 logins, got %d", got)
+	}
+}
+
+func TestAccountLifecycle(t *testing.T) {
+	srv := fakecontroller.New()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	account := &goaviatrix.Account{
+		AccountName:      "aws",
+		CloudType:        1,
+		AwsAccountNumber: "123456789012",
+		AwsIam:           "false",
+		AwsAccessKey:     "AKIA",
+		AwsSecretKey:     "secret",
+	}
+	if err := client.CreateAccount(account); err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	if err := client.CreateAccount(account); !errors.Is(err, goaviatrix.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+
+	got, err := client.GetAccount(&goaviatrix.Account{AccountName: "aws"})
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if got.CloudType != 1 || got.AwsAccountNumber != "123456789012" || got.AwsAccessKey != "AKIA" {
+		t.Errorf("unexpected account %#v", got)
+	}
+	if got.AwsSecretKey != "" {
+		t.Error("expected the secret key not to be listed")
+	}
+
+	if err := client.DeleteAccount(account); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+	if err := client.DeleteAccount(account); !errors.Is(err, goaviatrix.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAwsTgwDomains(t *testing.T) {
+	srv := fakecontroller.New()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	if err := client.CreateAccount(&goaviatrix.Account{AccountName: "aws", CloudType: 1,
+		AwsAccountNumber: "123456789012", AwsIam: "true"}); err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	awsTgw := &goaviatrix.AWSTgw{Name: "tgw", AccountName: "aws", Region: "us-east-1", AwsSideAsNumber: "64512"}
+	if err := client.CreateAWSTgw(awsTgw); err != nil {
+		t.Fatalf("CreateAWSTgw: %v", err)
+	}
+	if err := client.CreateSecurityDomain(&goaviatrix.SecurityDomain{Name: "prod", AwsTgwName: "tgw"}); err != nil {
+		t.Fatalf("CreateSecurityDomain: %v", err)
+	}
+	if err := client.CreateDomainConnection(awsTgw, "prod", "Shared_Service_Domain"); err != nil {
+		t.Fatalf("CreateDomainConnection: %v", err)
+	}
+	if err := client.GetDomainConnection(awsTgw, "Shared_Service_Domain", "prod"); err != nil {
+		t.Errorf("GetDomainConnection: %v", err)
+	}
+	if err := client.GetDomainConnection(awsTgw, "prod", "Default_Domain"); !errors.Is(err, goaviatrix.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unconnected domains, got %v", err)
+	}
+	if err := client.AttachVpcToAWSTgw(awsTgw, goaviatrix.VPCSolo{AccountName: "aws", VpcID: "vpc-1"}, "prod"); err != nil {
+		t.Fatalf("AttachVpcToAWSTgw: %v", err)
+	}
+
+	got, err := client.GetAWSTgw(&goaviatrix.AWSTgw{Name: "tgw"})
+	if err != nil {
+		t.Fatalf("GetAWSTgw: %v", err)
+	}
+	domains := make(map[string]goaviatrix.SecurityDomainRule)
+	for _, sd := range got.SecurityDomains {
+		domains[sd.Name] = sd
+	}
+	if len(domains) != 4 {
+		t.Fatalf("expected 4 security domains, got %v", got.SecurityDomains)
+	}
+	prod := domains["prod"]
+	if len(prod.ConnectedDomain) != 1 || prod.ConnectedDomain[0] != "Shared_Service_Domain" {
+		t.Errorf("unexpected connections of prod: %v", prod.ConnectedDomain)
+	}
+	if len(prod.AttachedVPCs) != 1 || prod.AttachedVPCs[0].VpcID != "vpc-1" {
+		t.Errorf("unexpected attachments of prod: %v", prod.AttachedVPCs)
+	}
+
+	details, err := client.ListRouteDomainDetails(&goaviatrix.AWSTgw{Name: "tgw"})
+	if err != nil {
+		t.Fatalf("ListRouteDomainDetails: %v", err)
+	}
+	if len(details) != 4 || details[0].Name != "Aviatrix_Edge_Domain" || details[3].Name != "prod" {
+		t.Fatalf("unexpected security domains: %v", details)
+	}
+	if routes := details[3].RoutesInRouteTable; len(routes) != 1 || routes[0].VPCId != "vpc-1" {
+		t.Errorf("unexpected routes of prod: %v", routes)
+	}
+
+	if err := client.DeleteAWSTgw(awsTgw); err == nil {
+		t.Error("expected deleting a TGW with attachments to fail")
+	}
+	if err := client.DetachVpcFromAWSTgw(awsTgw, "vpc-1"); err != nil {
+		t.Fatalf("DetachVpcFromAWSTgw: %v", err)
+	}
+	if err := client.DeleteAWSTgw(awsTgw); err != nil {
+		t.Fatalf("DeleteAWSTgw: %v", err)
+	}
+	if _, err := client.ListTgwDetails(&goaviatrix.AWSTgw{Name: "tgw"}); err != goaviatrix.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	srv := fakecontroller.New()
+	defer srv.Close()
+	client := newClient(t, srv)
+	client.SetRetryPolicy(&goaviatrix.RetryPolicy{MaxAttempts: 1})
+
+	srv.Handle("list_accounts", func(params url.Values) (interface{}, error) {
+		return nil, errors.New("Controller is busy, try again later")
+	})
+	_, err := client.GetAccount(&goaviatrix.Account{AccountName: "aws"})
+	if !errors.Is(err, goaviatrix.ErrBusy) {
+		t.Errorf("expected ErrBusy, got %v", err)
+	}
+	if got := srv.Calls("list_accounts"); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
@@ -0,0 +1,351 @@
+package fakecontroller
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// gatewayFields maps the parameters of connect_container to the fields of
+// list_vpcs_summary.
+var gatewayFields = map[string]string{
+	"account_name":            "account_name",
+	"gw_name":                 "vpc_name",
+	"vpc_id":                  "vpc_id",
+	"vpc_reg":                 "vpc_region",
+	"vpc_size":                "vpc_size",
+	"vpc_net":                 "public_subnet",
+	"zone":                    "gateway_zone",
+	"enable_nat":              "enable_nat",
+	"cidr":                    "vpn_cidr",
+	"max_conn":                "max_connections",
+	"split_tunnel":            "split_tunnel",
+	"saml_enabled":            "saml_enabled",
+	"insane_mode":             "high_perf",
+	"okta_url":                "okta_url",
+	"okta_username_suffix":    "okta_username_suffix",
+	"duo_integration_key":     "duo_integration_key",
+	"duo_api_hostname":        "duo_api_hostname",
+	"duo_push_mode":           "duo_push_mode",
+	"ldap_server":             "ldap_server",
+	"ldap_bind_dn":            "ldap_bind_dn",
+	"ldap_base_dn":            "ldap_base_dn",
+	"ldap_username_attribute": "ldap_username_attribute",
+}
+
+// transitGatewayFields maps the parameters of create_transit_gw to the fields
+// of list_vpcs_summary.
+var transitGatewayFields = map[string]string{
+	"account_name":  "account_name",
+	"gw_name":       "vpc_name",
+	"vpc_id":        "vpc_id",
+	"region":        "vpc_region",
+	"gw_size":       "vpc_size",
+	"public_subnet": "public_subnet",
+	"nat_enabled":   "enable_nat",
+}
+
+// The tag the controller puts on the cloud resources it creates.
+const (
+	defaultTagKey   = "Aviatrix-Created-Resource"
+	defaultTagValue = "Do-Not-Delete-Aviatrix-Created-Resource"
+)
+
+func (s *Server) registerGateways() {
+	s.handlers["connect_container"] = s.connectContainer
+	s.handlers["create_transit_gw"] = s.createTransitGw
+	s.handlers["list_vpcs_summary"] = s.listVpcsSummary
+	s.handlers["list_vpc_by_name"] = s.listVpcByName
+	s.handlers["edit_gw_config"] = s.editGwConfig
+	s.handlers["enable_snat"] = s.setSnat("yes")
+	s.handlers["disable_snat"] = s.setSnat("no")
+	s.handlers["enable_single_az_ha"] = s.setSingleAZ("yes")
+	s.handlers["disable_single_az_ha"] = s.setSingleAZ("no")
+	s.handlers["create_peering_ha_gateway"] = s.createPeeringHaGateway
+	s.handlers["delete_container"] = s.deleteContainer
+	s.handlers["add_resource_tags"] = s.addResourceTags
+	s.handlers["list_resource_tags"] = s.listResourceTags
+	s.handlers["delete_resource_tags"] = s.deleteResourceTags
+}
+
+// addGateway creates a running gateway instance with the given fields.
+func (s *Server) addGateway(name string, gw object) {
+	n := len(s.gateways.names) + 1
+	gw["vpc_name"] = name
+	gw["vpc_state"] = "up"
+	gw["inst_state"] = "running"
+	gw["private_ip"] = fmt.Sprintf("10.0.0.%d", n)
+	if gw.str("public_ip") == "" {
+		gw["public_ip"] = fmt.Sprintf("192.0.2.%d", n)
+	}
+	gw["cloudn_gateway_inst_id"] = fmt.Sprintf("i-%017x", n)
+	gw["gw_security_group_id"] = fmt.Sprintf("sg-%017x", n)
+	s.gateways.put(name, gw)
+	s.tags.put("gw/"+name, object{defaultTagKey: defaultTagValue})
+}
+
+func (s *Server) connectContainer(params url.Values) (interface{}, error) {
+	if err := require(params, "cloud_type", "account_name", "gw_name", "vpc_id", "vpc_reg", "vpc_size"); err != nil {
+		return nil, err
+	}
+	name := params.Get("gw_name")
+	if _, ok := s.gateways.get(name); ok {
+		return nil, alreadyExists("Gateway", name)
+	}
+	if _, ok := s.accounts.get(params.Get("account_name")); !ok {
+		return nil, notFound("Account", params.Get("account_name"))
+	}
+	cloudType, err := strconv.Atoi(params.Get("cloud_type"))
+	if err != nil {
+		return nil, fmt.Errorf("cloud_type %q is invalid", params.Get("cloud_type"))
+	}
+	gw := object{
+		"cloud_type":          cloudType,
+		"enable_nat":          "no",
+		"vpn_status":          "disabled",
+		"split_tunnel":        "no",
+		"saml_enabled":        "no",
+		"single_az_ha":        "no",
+		"elb_state":           "disabled",
+		"newly_allocated_eip": params.Get("allocate_new_eip") != "off",
+		"enable_ldap":         params.Get("enable_ldap") == "yes",
+	}
+	gw.set(params, gatewayFields)
+	if params.Get("vpn_access") == "yes" {
+		gw["vpn_status"] = "enabled"
+		if params.Get("enable_elb") == "yes" {
+			gw["elb_state"] = "enabled"
+			gw["lb_name"] = params.Get("elb_name")
+			if gw.str("lb_name") == "" {
+				gw["lb_name"] = "elb-" + name
+			}
+		}
+	}
+	switch params.Get("otp_mode") {
+	case "2":
+		gw["auth_method"] = "duo_auth"
+	case "3":
+		gw["auth_method"] = "okta_auth"
+	}
+	if eip := params.Get("eip"); eip != "" {
+		gw["public_ip"] = eip
+	}
+	s.addGateway(name, gw)
+	return "Gateway " + name + " has been created.", nil
+}
+
+func (s *Server) createTransitGw(params url.Values) (interface{}, error) {
+	if err := require(params, "cloud_type", "account_name", "gw_name", "vpc_id", "region", "gw_size"); err != nil {
+		return nil, err
+	}
+	name := params.Get("gw_name")
+	if _, ok := s.gateways.get(name); ok {
+		return nil, alreadyExists("Gateway", name)
+	}
+	if _, ok := s.accounts.get(params.Get("account_name")); !ok {
+		return nil, notFound("Account", params.Get("account_name"))
+	}
+	cloudType, err := strconv.Atoi(params.Get("cloud_type"))
+	if err != nil {
+		return nil, fmt.Errorf("cloud_type %q is invalid", params.Get("cloud_type"))
+	}
+	gw := object{
+		"cloud_type":          cloudType,
+		"transit_vpc":         "yes",
+		"enable_nat":          "no",
+		"vpn_status":          "disabled",
+		"single_az_ha":        "no",
+		"connected_transit":   params.Get("connected_transit"),
+		"tgw_enabled":         params.Get("enable_hybrid_connection") == "true",
+		"newly_allocated_eip": params.Get("reuse_eip") != "on",
+	}
+	gw.set(params, transitGatewayFields)
+	if eip := params.Get("eip"); eip != "" {
+		gw["public_ip"] = eip
+	}
+	s.addGateway(name, gw)
+	return "Transit gateway " + name + " has been created.", nil
+}
+
+func (s *Server) listVpcsSummary(params url.Values) (interface{}, error) {
+	return s.gateways.list(), nil
+}
+
+func (s *Server) listVpcByName(params url.Values) (interface{}, error) {
+	name := params.Get("vpc_name")
+	gw, ok := s.gateways.get(name)
+	if !ok {
+		return nil, notFound("Gateway", name)
+	}
+	return object{
+		"account_name": gw["account_name"],
+		"vpc_name":     name,
+		"dmz_enabled":  false,
+	}, nil
+}
+
+func (s *Server) gateway(params url.Values, param string) (object, error) {
+	name := params.Get(param)
+	if name == "" {
+		return nil, fmt.Errorf("%s is required", param)
+	}
+	gw, ok := s.gateways.get(name)
+	if !ok {
+		return nil, notFound("Gateway", name)
+	}
+	return gw, nil
+}
+
+func (s *Server) editGwConfig(params url.Values) (interface{}, error) {
+	gw, err := s.gateway(params, "gw_name")
+	if err != nil {
+		return nil, err
+	}
+	if err := require(params, "gw_size"); err != nil {
+		return nil, err
+	}
+	gw["vpc_size"] = params.Get("gw_size")
+	return "Gateway " + gw.str("vpc_name") + " has been resized.", nil
+}
+
+func (s *Server) setSnat(enabled string) HandlerFunc {
+	return func(params url.Values) (interface{}, error) {
+		gw, err := s.gateway(params, "gateway_name")
+		if err != nil {
+			return nil, err
+		}
+		gw["enable_nat"] = enabled
+		return "SNAT has been updated.", nil
+	}
+}
+
+func (s *Server) setSingleAZ(enabled string) HandlerFunc {
+	return func(params url.Values) (interface{}, error) {
+		gw, err := s.gateway(params, "gw_name")
+		if err != nil {
+			return nil, err
+		}
+		gw["single_az_ha"] = enabled
+		return "Single AZ HA has been updated.", nil
+	}
+}
+
+func (s *Server) createPeeringHaGateway(params url.Values) (interface{}, error) {
+	gw, err := s.gateway(params, "gw_name")
+	if err != nil {
+		return nil, err
+	}
+	name := gw.str("vpc_name") + "-hagw"
+	if _, ok := s.gateways.get(name); ok {
+		return nil, fmt.Errorf("HA GW already exists")
+	}
+	ha := object{
+		"cloud_type":    gw["cloud_type"],
+		"account_name":  gw["account_name"],
+		"vpc_id":        gw["vpc_id"],
+		"vpc_region":    gw["vpc_region"],
+		"vpc_size":      gw["vpc_size"],
+		"public_subnet": params.Get("public_subnet"),
+		"gateway_zone":  params.Get("new_zone"),
+		"is_hagw":       "yes",
+		"enable_nat":    "no",
+	}
+	if eip := params.Get("eip"); eip != "" {
+		ha["public_ip"] = eip
+	}
+	s.addGateway(name, ha)
+	return "Peering HA gateway " + name + " has been created.", nil
+}
+
+func (s *Server) deleteContainer(params url.Values) (interface{}, error) {
+	gw, err := s.gateway(params, "gw_name")
+	if err != nil {
+		return nil, err
+	}
+	name := gw.str("vpc_name")
+	if _, ok := s.gateways.get(name + "-hagw"); ok {
+		return nil, fmt.Errorf("Gateway %s has a peering HA gateway, delete %s-hagw first", name, name)
+	}
+	for _, conn := range s.site2clouds.list() {
+		if conn.str("gw_name") == name {
+			return nil, fmt.Errorf("Gateway %s is in use by site2cloud connection %s", name, conn.str("name"))
+		}
+	}
+	s.gateways.delete(name)
+	s.tags.delete("gw/" + name)
+	return "Gateway " + name + " has been deleted.", nil
+}
+
+// resourceTags returns the tags of the resource the parameters refer to.
+func (s *Server) resourceTags(params url.Values) (object, error) {
+	if err := require(params, "resource_type", "resource_name"); err != nil {
+		return nil, err
+	}
+	key := params.Get("resource_type") + "/" + params.Get("resource_name")
+	tags, ok := s.tags.get(key)
+	if !ok {
+		if params.Get("resource_type") == "gw" {
+			return nil, notFound("Gateway", params.Get("resource_name"))
+		}
+		tags = object{}
+		s.tags.put(key, tags)
+	}
+	return tags, nil
+}
+
+// parseTags parses a list of key:value pairs separated by commas, where the
+// first colon of a pair separates the key from the value and the colons of
+// the value are escaped with two backslashes.
+func parseTags(list string) (map[string]string, error) {
+	tags := make(map[string]string)
+	if list == "" {
+		return tags, nil
+	}
+	for _, tag := range strings.Split(list, ",") {
+		kv := strings.SplitN(tag, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("tag %q is invalid", tag)
+		}
+		tags[kv[0]] = strings.Replace(kv[1], `\\:`, ":", -1)
+	}
+	return tags, nil
+}
+
+func (s *Server) addResourceTags(params url.Values) (interface{}, error) {
+	tags, err := s.resourceTags(params)
+	if err != nil {
+		return nil, err
+	}
+	add, err := parseTags(params.Get("new_tag_list"))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range add {
+		tags[k] = v
+	}
+	return "Tags have been added.", nil
+}
+
+func (s *Server) listResourceTags(params url.Values) (interface{}, error) {
+	tags, err := s.resourceTags(params)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]object{"tags": tags}, nil
+}
+
+func (s *Server) deleteResourceTags(params url.Values) (interface{}, error) {
+	tags, err := s.resourceTags(params)
+	if err != nil {
+		return nil, err
+	}
+	del, err := parseTags(params.Get("del_tag_list"))
+	if err != nil {
+		return nil, err
+	}
+	for k := range del {
+		delete(tags, k)
+	}
+	return "Tags have been deleted.", nil
+}
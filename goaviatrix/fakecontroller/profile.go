@@ -0,0 +1,144 @@
+package fakecontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// profileRule is a rule of a VPN profile policy, as sent by
+// update_profile_policy and listed by list_profile_policies.
+type profileRule struct {
+	Protocol string `json:"protocol"`
+	Target   string `json:"target"`
+	Port     string `json:"port"`
+	Action   string `json:"action"`
+}
+
+func (s *Server) registerProfiles() {
+	s.handlers["add_user_profile"] = s.addUserProfile
+	s.handlers["get_profile_base_policy"] = s.getProfileBasePolicy
+	s.handlers["update_profile_policy"] = s.updateProfilePolicy
+	s.handlers["list_profile_policies"] = s.listProfilePolicies
+	s.handlers["add_profile_member"] = s.addProfileMember
+	s.handlers["del_profile_member"] = s.delProfileMember
+	s.handlers["list_user_profile_names"] = s.listUserProfileNames
+	s.handlers["del_user_profile"] = s.delUserProfile
+}
+
+func (s *Server) profile(params url.Values) (object, error) {
+	name := params.Get("profile_name")
+	if name == "" {
+		return nil, fmt.Errorf("profile_name is required")
+	}
+	profile, ok := s.profiles.get(name)
+	if !ok {
+		return nil, notFound("Profile", name)
+	}
+	return profile, nil
+}
+
+func (s *Server) addUserProfile(params url.Values) (interface{}, error) {
+	if err := require(params, "profile_name", "base_policy"); err != nil {
+		return nil, err
+	}
+	name := params.Get("profile_name")
+	if _, ok := s.profiles.get(name); ok {
+		return nil, alreadyExists("Profile", name)
+	}
+	base := params.Get("base_policy")
+	if base != "allow_all" && base != "deny_all" {
+		return nil, fmt.Errorf("base_policy must be allow_all or deny_all")
+	}
+	s.profiles.put(name, object{
+		"base_policy": base,
+		"policy":      []profileRule{},
+		"users":       map[string]bool{},
+	})
+	return "Profile " + name + " has been created.", nil
+}
+
+func (s *Server) getProfileBasePolicy(params url.Values) (interface{}, error) {
+	profile, err := s.profile(params)
+	if err != nil {
+		return nil, err
+	}
+	return "Base policy: " + strings.Replace(profile.str("base_policy"), "_", " ", 1), nil
+}
+
+func (s *Server) updateProfilePolicy(params url.Values) (interface{}, error) {
+	profile, err := s.profile(params)
+	if err != nil {
+		return nil, err
+	}
+	var policy []profileRule
+	if p := params.Get("policy"); p != "" && p != "null" {
+		if err := json.Unmarshal([]byte(p), &policy); err != nil {
+			return nil, fmt.Errorf("policy is invalid: %v", err)
+		}
+	}
+	for _, rule := range policy {
+		if rule.Action != "allow" && rule.Action != "deny" {
+			return nil, fmt.Errorf("action %q is invalid", rule.Action)
+		}
+	}
+	if policy == nil {
+		policy = []profileRule{}
+	}
+	profile["policy"] = policy
+	return "Profile policy has been updated.", nil
+}
+
+func (s *Server) listProfilePolicies(params url.Values) (interface{}, error) {
+	profile, err := s.profile(params)
+	if err != nil {
+		return nil, err
+	}
+	return profile["policy"], nil
+}
+
+func (s *Server) addProfileMember(params url.Values) (interface{}, error) {
+	profile, err := s.profile(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := require(params, "username"); err != nil {
+		return nil, err
+	}
+	users := profile["users"].(map[string]bool)
+	if users[params.Get("username")] {
+		return nil, alreadyExists("Profile member", params.Get("username"))
+	}
+	users[params.Get("username")] = true
+	return "User has been added to the profile.", nil
+}
+
+func (s *Server) delProfileMember(params url.Values) (interface{}, error) {
+	profile, err := s.profile(params)
+	if err != nil {
+		return nil, err
+	}
+	users := profile["users"].(map[string]bool)
+	if !users[params.Get("username")] {
+		return nil, notFound("Profile member", params.Get("username"))
+	}
+	delete(users, params.Get("username"))
+	return "User has been removed from the profile.", nil
+}
+
+func (s *Server) listUserProfileNames(params url.Values) (interface{}, error) {
+	names := make(map[string][]string)
+	for i, profile := range s.profiles.list() {
+		names[s.profiles.names[i]] = sortedKeys(profile["users"].(map[string]bool))
+	}
+	return names, nil
+}
+
+func (s *Server) delUserProfile(params url.Values) (interface{}, error) {
+	if _, err := s.profile(params); err != nil {
+		return nil, err
+	}
+	s.profiles.delete(params.Get("profile_name"))
+	return "Profile " + params.Get("profile_name") + " has been deleted.", nil
+}
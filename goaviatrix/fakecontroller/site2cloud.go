@@ -0,0 +1,253 @@
+package fakecontroller
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Default IPsec algorithms of site2cloud connections.
+var site2CloudAlgorithms = map[string]string{
+	"phase1_auth":       "SHA-1",
+	"phase1_dh_group":   "2",
+	"phase1_encryption": "AES-256-CBC",
+	"phase2_auth":       "HMAC-SHA-1",
+	"phase2_dh_group":   "2",
+	"phase2_encryption": "AES-256-CBC",
+}
+
+const defaultSslServerPool = "192.168.44.0/24"
+
+func (s *Server) registerSite2Cloud() {
+	s.handlers["add_site2cloud"] = s.addSite2Cloud
+	s.handlers["list_site2cloud_conn"] = s.listSite2CloudConn
+	s.handlers["get_site2cloud_conn_detail"] = s.getSite2CloudConnDetail
+	s.handlers["edit_site2cloud_conn"] = s.editSite2CloudConn
+	s.handlers["enable_dpd_config"] = s.setDeadPeerDetection("enable")
+	s.handlers["disable_dpd_config"] = s.setDeadPeerDetection("disable")
+	s.handlers["delete_site2cloud_connection"] = s.deleteSite2CloudConnection
+}
+
+func site2CloudKey(vpcID string, name string) string {
+	return name + "~" + vpcID
+}
+
+func (s *Server) site2Cloud(vpcID string, name string) (object, error) {
+	if vpcID == "" || name == "" {
+		return nil, fmt.Errorf("vpc_id and connection name are required")
+	}
+	conn, ok := s.site2clouds.get(site2CloudKey(vpcID, name))
+	if !ok {
+		return nil, notFound("Site2Cloud connection", name)
+	}
+	return conn, nil
+}
+
+func (s *Server) addSite2Cloud(params url.Values) (interface{}, error) {
+	if err := require(params, "vpc_id", "connection_name", "connection_type", "tunnel_type",
+		"primary_cloud_gateway_name", "remote_gateway_ip", "remote_subnet_cidr"); err != nil {
+		return nil, err
+	}
+	vpcID, name := params.Get("vpc_id"), params.Get("connection_name")
+	if _, ok := s.site2clouds.get(site2CloudKey(vpcID, name)); ok {
+		return nil, alreadyExists("Site2Cloud connection", name)
+	}
+	connType := params.Get("connection_type")
+	if connType != "mapped" && connType != "unmapped" {
+		return nil, fmt.Errorf("connection_type must be mapped or unmapped")
+	}
+	if connType == "mapped" {
+		if err := require(params, "local_subnet_cidr", "virtual_remote_subnet_cidr", "virtual_local_subnet_cidr"); err != nil {
+			return nil, err
+		}
+	}
+	gwName := params.Get("primary_cloud_gateway_name")
+	gw, ok := s.gateways.get(gwName)
+	if !ok {
+		return nil, notFound("Gateway", gwName)
+	}
+	if strings.Split(gw.str("vpc_id"), "~~")[0] != vpcID {
+		return nil, fmt.Errorf("Gateway %s is not in VPC %s", gwName, vpcID)
+	}
+	ha := params.Get("ha_enabled") == "yes"
+	if ha {
+		if err := require(params, "backup_gateway_name", "backup_remote_gateway_ip"); err != nil {
+			return nil, err
+		}
+		if _, ok := s.gateways.get(params.Get("backup_gateway_name")); !ok {
+			return nil, notFound("Gateway", params.Get("backup_gateway_name"))
+		}
+	}
+
+	algorithms := make(map[string][]string)
+	for param, def := range site2CloudAlgorithms {
+		v := params.Get(param)
+		if v == "" {
+			v = def
+		}
+		algorithms[param] = []string{v}
+	}
+	sslServerPool := params.Get("ssl_server_pool")
+	if sslServerPool == "" {
+		sslServerPool = defaultSslServerPool
+	}
+	var routeTables []string
+	if params.Get("private_route_encryption") == "true" {
+		for k, v := range params {
+			if strings.HasPrefix(k, "route_table_list[") {
+				routeTables = append(routeTables, v...)
+			}
+		}
+		sort.Strings(routeTables)
+	}
+
+	conn := object{
+		"vpc_id":           vpcID,
+		"name":             name,
+		"type":             connType,
+		"tunnel_type":      params.Get("tunnel_type"),
+		"peer_type":        params.Get("remote_gateway_type"),
+		"gw_name":          gwName,
+		"peer_ip":          params.Get("remote_gateway_ip"),
+		"remote_cidr":      params.Get("remote_subnet_cidr"),
+		"local_cidr":       params.Get("local_subnet_cidr"),
+		"virt_remote_cidr": params.Get("virtual_remote_subnet_cidr"),
+		"virt_local_cidr":  params.Get("virtual_local_subnet_cidr"),
+		"ha_status":        "disabled",
+		"algorithms":       algorithms,
+		"ssl_server_pool":  sslServerPool,
+		"rtbls":            routeTables,
+		"dpd_config":       "enable",
+	}
+	if local := conn.str("local_cidr"); local == "" {
+		conn["local_cidr"] = gw.str("public_subnet")
+	}
+	if ha {
+		conn["ha_status"] = "enabled"
+		conn["backup_gateway_name"] = params.Get("backup_gateway_name")
+		conn["backup_remote_gateway_ip"] = params.Get("backup_remote_gateway_ip")
+	}
+	s.site2clouds.put(site2CloudKey(vpcID, name), conn)
+	return "Site2Cloud connection " + name + " has been created.", nil
+}
+
+// site2CloudSummary returns the fields of conn listed by list_site2cloud_conn.
+func site2CloudSummary(conn object) object {
+	summary := object{}
+	for _, k := range []string{"vpc_id", "name", "type", "tunnel_type", "gw_name", "peer_ip", "remote_cidr",
+		"local_cidr", "ha_status"} {
+		summary[k] = conn[k]
+	}
+	return summary
+}
+
+func (s *Server) listSite2CloudConn(params url.Values) (interface{}, error) {
+	connections := []object{}
+	for _, conn := range s.site2clouds.list() {
+		if name := params.Get("connection_name"); name != "" && conn.str("name") != name {
+			continue
+		}
+		connections = append(connections, site2CloudSummary(conn))
+	}
+	return map[string]interface{}{"connections": connections}, nil
+}
+
+func (s *Server) getSite2CloudConnDetail(params url.Values) (interface{}, error) {
+	conn, err := s.site2Cloud(params.Get("vpc_id"), params.Get("conn_name"))
+	if err != nil {
+		return nil, err
+	}
+	tunnels := []object{{
+		"status":        "up",
+		"name":          conn["name"],
+		"gw_name":       conn["gw_name"],
+		"peer_ip":       conn["peer_ip"],
+		"tunnel_status": "up",
+	}}
+	backupGwName := conn.str("backup_gateway_name")
+	if backupGwName != "" {
+		tunnels = append(tunnels, object{
+			"status":        "up",
+			"name":          conn["name"],
+			"gw_name":       backupGwName,
+			"peer_ip":       conn["backup_remote_gateway_ip"],
+			"tunnel_status": "up",
+		})
+	}
+	algorithms := conn["algorithms"].(map[string][]string)
+	detail := object{
+		"vpc_id":          []string{conn.str("vpc_id")},
+		"name":            []string{conn.str("name")},
+		"type":            conn["type"],
+		"tunnel_type":     []string{conn.str("tunnel_type")},
+		"gw_name":         []string{conn.str("gw_name")},
+		"tunnels":         tunnels,
+		"ha_status":       conn["ha_status"],
+		"peer_type":       conn["peer_type"],
+		"remote_cidr":     conn["remote_cidr"],
+		"local_cidr":      conn["local_cidr"],
+		"ssl_server_pool": []string{conn.str("ssl_server_pool")},
+		"rtbls":           conn["rtbls"],
+		"dpd_config":      conn["dpd_config"],
+		"algorithm": map[string][]string{
+			"ph1_auth": algorithms["phase1_auth"],
+			"ph1_dh":   algorithms["phase1_dh_group"],
+			"ph1_encr": algorithms["phase1_encryption"],
+			"ph2_auth": algorithms["phase2_auth"],
+			"ph2_dh":   algorithms["phase2_dh_group"],
+			"ph2_encr": algorithms["phase2_encryption"],
+		},
+	}
+	if backupGwName != "" {
+		detail["backup_gateway_name"] = []string{backupGwName}
+	}
+	if conn.str("type") == "mapped" {
+		detail["real_remote_cidr"] = conn["remote_cidr"]
+		detail["real_local_cidr"] = conn["local_cidr"]
+		detail["virt_remote_cidr"] = conn["virt_remote_cidr"]
+		detail["virt_local_cidr"] = conn["virt_local_cidr"]
+		detail["remote_cidr"] = conn["virt_remote_cidr"]
+		detail["local_cidr"] = conn["virt_local_cidr"]
+	}
+	return map[string]interface{}{"connections": detail}, nil
+}
+
+func (s *Server) editSite2CloudConn(params url.Values) (interface{}, error) {
+	conn, err := s.site2Cloud(params.Get("vpc_id"), params.Get("conn_name"))
+	if err != nil {
+		return nil, err
+	}
+	if err := require(params, "network_type", "cloud_subnet_cidr"); err != nil {
+		return nil, err
+	}
+	switch params.Get("network_type") {
+	case "1":
+		conn["local_cidr"] = params.Get("cloud_subnet_cidr")
+	case "2":
+		conn["remote_cidr"] = params.Get("cloud_subnet_cidr")
+	default:
+		return nil, fmt.Errorf("network_type must be 1 or 2")
+	}
+	return "Site2Cloud connection " + conn.str("name") + " has been updated.", nil
+}
+
+func (s *Server) setDeadPeerDetection(config string) HandlerFunc {
+	return func(params url.Values) (interface{}, error) {
+		conn, err := s.site2Cloud(params.Get("vpc_id"), params.Get("connection_name"))
+		if err != nil {
+			return nil, err
+		}
+		conn["dpd_config"] = config
+		return "Dead peer detection has been updated.", nil
+	}
+}
+
+func (s *Server) deleteSite2CloudConnection(params url.Values) (interface{}, error) {
+	conn, err := s.site2Cloud(params.Get("vpc_id"), params.Get("connection_name"))
+	if err != nil {
+		return nil, err
+	}
+	s.site2clouds.delete(site2CloudKey(conn.str("vpc_id"), conn.str("name")))
+	return "Site2Cloud connection " + conn.str("name") + " has been deleted.", nil
+}
@@ -1,11 +1,11 @@
 package goaviatrix
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
-	"net/url"
 )
 
 type Policy struct {
@@ -27,100 +27,59 @@ type Firewall struct {
 	PolicyList     []*Policy `form:"new_policy[],omitempty" json:"security_rules,omitempty"`
 }
 
-type FirewallResp struct {
-	Return  bool     `json:"return"`
-	Results Firewall `json:"results"`
-	Reason  string   `json:"reason"`
-}
-
 func (c *Client) SetBasePolicy(firewall *Firewall) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for attach_spoke_to_transit_gw") + err.Error())
-	}
-	setVpcBasePolicy := url.Values{}
-	setVpcBasePolicy.Add("CID", c.CID)
-	setVpcBasePolicy.Add("action", "set_vpc_base_policy")
-	setVpcBasePolicy.Add("vpc_name", firewall.GwName)
-	setVpcBasePolicy.Add("base_policy", firewall.BasePolicy)
-	setVpcBasePolicy.Add("base_policy_log_enable", firewall.BaseLogEnabled)
-	Url.RawQuery = setVpcBasePolicy.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.SetBasePolicyContext(c.Context(), firewall)
+}
 
+func (c *Client) SetBasePolicyContext(ctx context.Context, firewall *Firewall) error {
 	log.Printf("[INFO] Setting Base Policy: %#v", firewall)
-
-	if err != nil {
-		return errors.New("HTTP Get set_vpc_base_policy failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_vpc_base_policy failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API set_vpc_base_policy Get failed: " + data.Reason)
+	params := map[string]string{
+		"vpc_name":               firewall.GwName,
+		"base_policy":            firewall.BasePolicy,
+		"base_policy_log_enable": firewall.BaseLogEnabled,
 	}
-	return nil
+	return c.GetAPIContext(ctx, "set_vpc_base_policy", params, nil)
 }
 
 func (c *Client) UpdatePolicy(firewall *Firewall) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for update_access_policy") + err.Error())
-	}
-	updateAccessPolicy := url.Values{}
-	updateAccessPolicy.Add("CID", c.CID)
-	updateAccessPolicy.Add("action", "update_access_policy")
-	updateAccessPolicy.Add("vpc_name", firewall.GwName)
+	return c.UpdatePolicyContext(c.Context(), firewall)
+}
 
+func (c *Client) UpdatePolicyContext(ctx context.Context, firewall *Firewall) error {
 	log.Printf("[INFO] Updating Aviatrix firewall for gateway: %#v", firewall)
 
 	args, err := json.Marshal(firewall.PolicyList)
 	if err != nil {
 		return err
 	}
-	updateAccessPolicy.Add("new_policy", string(args))
-	Url.RawQuery = updateAccessPolicy.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get update_access_policy failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode update_access_policy failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API update_access_policy Get failed: " + data.Reason)
+	params := map[string]string{
+		"vpc_name":   firewall.GwName,
+		"new_policy": string(args),
 	}
-	return nil
+	return c.GetAPIContext(ctx, "update_access_policy", params, nil)
 }
 
 func (c *Client) GetPolicy(firewall *Firewall) (*Firewall, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for vpc_access_policy") + err.Error())
-	}
-	vpcAccessPolicy := url.Values{}
-	vpcAccessPolicy.Add("CID", c.CID)
-	vpcAccessPolicy.Add("action", "vpc_access_policy")
-	vpcAccessPolicy.Add("vpc_name", firewall.GwName)
-	Url.RawQuery = vpcAccessPolicy.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetPolicyContext(c.Context(), firewall)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get vpc_access_policy failed: " + err.Error())
-	}
-	var data FirewallResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode vpc_access_policy failed: " + err.Error())
+func (c *Client) GetPolicyContext(ctx context.Context, firewall *Firewall) (*Firewall, error) {
+	params := map[string]string{
+		"vpc_name": firewall.GwName,
 	}
-	if !data.Return {
-		log.Printf("[INFO] Couldn't find Aviatrix Firewall policies for gateway %s: %s", firewall.GwName,
-			data.Reason)
-		return nil, ErrNotFound
+	var data Firewall
+	err := c.GetAPIContext(ctx, "vpc_access_policy", params, &data)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			log.Printf("[INFO] Couldn't find Aviatrix Firewall policies for gateway %s: %s", firewall.GwName,
+				apiErr.Reason)
+			return nil, ErrNotFound
+		}
+		return nil, err
 	}
 
-	return &data.Results, nil
+	return &data, nil
 }
 
 func (c *Client) ValidatePolicy(policy *Policy) error {
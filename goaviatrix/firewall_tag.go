@@ -1,12 +1,11 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	"net/http"
-	"strings"
+	"net/url"
 )
 
 type CIDRMember struct {
@@ -22,94 +21,53 @@ type FirewallTag struct {
 	CIDRList []CIDRMember `form:"new_policies,omitempty" json:"members,omitempty"`
 }
 
-type FirewallTagResp struct {
-	Return  bool        `json:"return"`
-	Results FirewallTag `json:"results"`
-	Reason  string      `json:"reason"`
+func (c *Client) CreateFirewallTag(firewall_tag *FirewallTag) error {
+	return c.CreateFirewallTagContext(c.Context(), firewall_tag)
 }
 
-func (c *Client) CreateFirewallTag(firewall_tag *FirewallTag) error {
-	firewall_tag.CID = c.CID
-	firewall_tag.Action = "add_policy_tag"
+func (c *Client) CreateFirewallTagContext(ctx context.Context, firewall_tag *FirewallTag) error {
 	log.Printf("[INFO] Setting Firewall Tag: %#v", firewall_tag)
-	resp, err := c.Post(c.baseURL, firewall_tag)
-	if err != nil {
-		return err
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return err
-	}
-	if !data.Return {
-		return errors.New(data.Reason)
-	}
-	return nil
+	return c.PostAPIContext(ctx, "add_policy_tag", firewall_tag, nil)
 }
 
 func (c *Client) UpdateFirewallTag(firewall_tag *FirewallTag) error {
-	firewall_tag.CID = c.CID
-	firewall_tag.Action = "update_policy_members"
-	verb := "POST"
-	body := fmt.Sprintf("CID=%s&action=%s&tag_name=%s", c.CID, firewall_tag.Action, firewall_tag.Name)
-	for i, cidr := range firewall_tag.CIDRList {
-		body = body + fmt.Sprintf("&new_policies[%d][name]=%s&new_policies[%d][cidr]=%s", i, cidr.CIDRTag, i, cidr.CIDR)
-	}
-	log.Printf("[TRACE] %s %s Body: %s", verb, c.baseURL, body)
-	req, err := http.NewRequest(verb, c.baseURL, strings.NewReader(body))
-	if err == nil {
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	} else {
-		return err
-	}
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return errors.New("HTTP Post update_policy_members failed: " + err.Error())
-	}
+	return c.UpdateFirewallTagContext(c.Context(), firewall_tag)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode update_policy_members failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API update_policy_members Post failed: " + data.Reason)
+func (c *Client) UpdateFirewallTagContext(ctx context.Context, firewall_tag *FirewallTag) error {
+	params := url.Values{}
+	params.Set("tag_name", firewall_tag.Name)
+	for i, cidr := range firewall_tag.CIDRList {
+		params.Set(fmt.Sprintf("new_policies[%d][name]", i), cidr.CIDRTag)
+		params.Set(fmt.Sprintf("new_policies[%d][cidr]", i), cidr.CIDR)
 	}
-	return nil
+	return c.PostAPIContext(ctx, "update_policy_members", params, nil)
 }
 
 func (c *Client) GetFirewallTag(firewall_tag *FirewallTag) (*FirewallTag, error) {
-	firewall_tag.CID = c.CID
-	firewall_tag.Action = "list_policy_members"
+	return c.GetFirewallTagContext(c.Context(), firewall_tag)
+}
 
+func (c *Client) GetFirewallTagContext(ctx context.Context, firewall_tag *FirewallTag) (*FirewallTag, error) {
 	log.Printf("[INFO] Getting Firewall Tag: %#v", firewall_tag)
-	resp, err := c.Post(c.baseURL, firewall_tag)
+	var data FirewallTag
+	err := c.PostAPIContext(ctx, "list_policy_members", firewall_tag, &data)
 	if err != nil {
-		return nil, errors.New("HTTP Post list_policy_members failed: " + err.Error())
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			log.Printf("[INFO] Couldn't find Aviatrix Firewall tag %s: %s", firewall_tag.Name, apiErr.Reason)
+			return nil, ErrNotFound
+		}
+		return nil, err
 	}
-	var data FirewallTagResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_policy_members failed: " + err.Error())
-	}
-	if !data.Return {
-		log.Printf("[INFO] Couldn't find Aviatrix Firewall tag %s: %s", firewall_tag.Name, data.Reason)
-		return nil, ErrNotFound
-	}
-	return &data.Results, nil
+	return &data, nil
 }
 
 func (c *Client) DeleteFirewallTag(firewall_tag *FirewallTag) error {
-	firewall_tag.CID = c.CID
-	firewall_tag.Action = "del_policy_tag"
+	return c.DeleteFirewallTagContext(c.Context(), firewall_tag)
+}
+
+func (c *Client) DeleteFirewallTagContext(ctx context.Context, firewall_tag *FirewallTag) error {
 	log.Printf("[INFO] Deleting Firewall Tag: %#v", firewall_tag)
-	resp, err := c.Post(c.baseURL, firewall_tag)
-	if err != nil {
-		return errors.New("HTTP Post del_policy_tag failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode del_policy_tag failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API del_policy_tag Post failed: " + data.Reason)
-	}
-	return nil
+	return c.PostAPIContext(ctx, "del_policy_tag", firewall_tag, nil)
 }
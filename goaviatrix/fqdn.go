@@ -1,11 +1,10 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -33,22 +32,9 @@ type GwFilterTag struct {
 	SourceIPList []string `json:"source_ip_list, omitempty"`
 }
 
-type ResultListResp struct {
-	Return  bool     `json:"return"`
-	Results []string `json:"results"`
-	Reason  string   `json:"reason"`
-}
-
-type GetFqdnExceptionRuleResp struct {
-	Return  bool   `json:"return"`
-	Results string `json:"results"`
-	Reason  string `json:"reason"`
-}
-
-type ResultListSourceIPResp struct {
-	Return  bool       `json:"return"`
-	Results GwSourceIP `json:"results"`
-	Reason  string     `json:"reason"`
+type fqdnTagState struct {
+	Mode  string `json:"wbmode"`
+	State string `json:"state"`
 }
 
 type GwSourceIP struct {
@@ -57,139 +43,68 @@ type GwSourceIP struct {
 }
 
 func (c *Client) CreateFQDN(fqdn *FQDN) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for add_fqdn_filter_tag " + err.Error())
-	}
-	addFQDNFilterTag := url.Values{}
-	addFQDNFilterTag.Add("CID", c.CID)
-	addFQDNFilterTag.Add("action", "add_fqdn_filter_tag")
-	addFQDNFilterTag.Add("tag_name", fqdn.FQDNTag)
-	Url.RawQuery = addFQDNFilterTag.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.CreateFQDNContext(c.Context(), fqdn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get add_fqdn_filter_tag failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_fqdn_filter_tag failed: " + err.Error())
+func (c *Client) CreateFQDNContext(ctx context.Context, fqdn *FQDN) error {
+	params := map[string]string{
+		"tag_name": fqdn.FQDNTag,
 	}
-	if !data.Return {
-		return errors.New("Rest API add_fqdn_filter_tag Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "add_fqdn_filter_tag", params, nil)
 }
 
 func (c *Client) DeleteFQDN(fqdn *FQDN) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for del_fqdn_filter_tag " + err.Error())
-	}
-	delFQDNFilterTag := url.Values{}
-	delFQDNFilterTag.Add("CID", c.CID)
-	delFQDNFilterTag.Add("action", "del_fqdn_filter_tag")
-	delFQDNFilterTag.Add("tag_name", fqdn.FQDNTag)
-	Url.RawQuery = delFQDNFilterTag.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteFQDNContext(c.Context(), fqdn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get del_fqdn_filter_tag failed: " + err.Error())
+func (c *Client) DeleteFQDNContext(ctx context.Context, fqdn *FQDN) error {
+	params := map[string]string{
+		"tag_name": fqdn.FQDNTag,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode del_fqdn_filter_tag failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API del_fqdn_filter_tag Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "del_fqdn_filter_tag", params, nil)
 }
 
 //change state to 'enabled' or 'disabled'
 func (c *Client) UpdateFQDNStatus(fqdn *FQDN) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for set_fqdn_filter_tag_state " + err.Error())
-	}
-	setFQDNFilterTagState := url.Values{}
-	setFQDNFilterTagState.Add("CID", c.CID)
-	setFQDNFilterTagState.Add("action", "set_fqdn_filter_tag_state")
-	setFQDNFilterTagState.Add("tag_name", fqdn.FQDNTag)
-	setFQDNFilterTagState.Add("status", fqdn.FQDNStatus)
-	Url.RawQuery = setFQDNFilterTagState.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.UpdateFQDNStatusContext(c.Context(), fqdn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get set_fqdn_filter_tag_state failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_fqdn_filter_tag_state failed: " + err.Error())
+func (c *Client) UpdateFQDNStatusContext(ctx context.Context, fqdn *FQDN) error {
+	params := map[string]string{
+		"tag_name": fqdn.FQDNTag,
+		"status":   fqdn.FQDNStatus,
 	}
-	if !data.Return {
-		return errors.New("Rest API set_fqdn_filter_tag_state Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "set_fqdn_filter_tag_state", params, nil)
 }
 
 //Change default mode to 'white' or 'black'
 func (c *Client) UpdateFQDNMode(fqdn *FQDN) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for set_fqdn_filter_tag_color " + err.Error())
-	}
-	setFQDNFilterTagColor := url.Values{}
-	setFQDNFilterTagColor.Add("CID", c.CID)
-	setFQDNFilterTagColor.Add("action", "set_fqdn_filter_tag_color")
-	setFQDNFilterTagColor.Add("tag_name", fqdn.FQDNTag)
-	setFQDNFilterTagColor.Add("color", fqdn.FQDNMode)
-	Url.RawQuery = setFQDNFilterTagColor.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.UpdateFQDNModeContext(c.Context(), fqdn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get set_fqdn_filter_tag_color failed: " + err.Error())
+func (c *Client) UpdateFQDNModeContext(ctx context.Context, fqdn *FQDN) error {
+	params := map[string]string{
+		"tag_name": fqdn.FQDNTag,
+		"color":    fqdn.FQDNMode,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_fqdn_filter_tag_color failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API set_fqdn_filter_tag_color Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "set_fqdn_filter_tag_color", params, nil)
 }
 
 func (c *Client) UpdateDomains(fqdn *FQDN) error {
-	fqdn.CID = c.CID
-	fqdn.Action = "set_fqdn_filter_tag_domain_names"
+	return c.UpdateDomainsContext(c.Context(), fqdn)
+}
+
+func (c *Client) UpdateDomainsContext(ctx context.Context, fqdn *FQDN) error {
 	log.Printf("[INFO] Update domains: %#v", fqdn)
 
-	verb := "POST"
-	body := fmt.Sprintf("CID=%s&action=%s&tag_name=%s", c.CID, fqdn.Action, fqdn.FQDNTag)
+	params := url.Values{}
+	params.Set("tag_name", fqdn.FQDNTag)
 	for i, dn := range fqdn.DomainList {
-		body = body + fmt.Sprintf("&domain_names[%d][fqdn]=%s&domain_names[%d]"+
-			"[proto]=%s&domain_names[%d][port]=%s", i, dn.FQDN, i, dn.Protocol, i, dn.Port)
+		params.Set(fmt.Sprintf("domain_names[%d][fqdn]", i), dn.FQDN)
+		params.Set(fmt.Sprintf("domain_names[%d][proto]", i), dn.Protocol)
+		params.Set(fmt.Sprintf("domain_names[%d][port]", i), dn.Port)
 	}
-	log.Printf("[TRACE] %s %s Body: %s", verb, c.baseURL, body)
-	req, err := http.NewRequest(verb, c.baseURL, strings.NewReader(body))
-	if err == nil {
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	} else {
-		return errors.New("HTTP NewRequest set_fqdn_filter_tag_domain_names failed: " + err.Error())
-	}
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return errors.New("HTTP Post set_fqdn_filter_tag_domain_names failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_fqdn_filter_tag_domain_names failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API set_fqdn_filter_tag_domain_names Post failed: " + data.Reason)
-	}
-	return nil
+	return c.PostAPIContext(ctx, "set_fqdn_filter_tag_domain_names", params, nil)
 }
 
 func (c *Client) AttachGws(fqdn *FQDN) error {
@@ -197,73 +112,56 @@ func (c *Client) AttachGws(fqdn *FQDN) error {
 }
 
 func (c *Client) DetachGws(fqdn *FQDN, gwList []string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for detach_fqdn_filter_tag_from_gw " + err.Error())
-	}
-	detachFQDNFilterTagToGw := url.Values{}
-	detachFQDNFilterTagToGw.Add("CID", c.CID)
-	detachFQDNFilterTagToGw.Add("action", "detach_fqdn_filter_tag_from_gw")
-	detachFQDNFilterTagToGw.Add("tag_name", fqdn.FQDNTag)
+	return c.DetachGwsContext(c.Context(), fqdn, gwList)
+}
 
+func (c *Client) DetachGwsContext(ctx context.Context, fqdn *FQDN, gwList []string) error {
 	for i := range gwList {
-		detachFQDNFilterTagToGw.Add("gw_name", gwList[i])
-		Url.RawQuery = detachFQDNFilterTagToGw.Encode()
-		resp, err := c.Get(Url.String(), nil)
-		if err != nil {
-			return errors.New("HTTP Get detach_fqdn_filter_tag_from_gw failed: " + err.Error())
+		params := map[string]string{
+			"tag_name": fqdn.FQDNTag,
+			"gw_name":  gwList[i],
 		}
-		var data APIResp
-		if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-			return errors.New("Json Decode detach_fqdn_filter_tag_from_gw failed: " + err.Error())
-		}
-		if !data.Return {
-			return errors.New("Rest API detach_fqdn_filter_tag_from_gw Get failed: " + data.Reason)
+		if err := c.GetAPIContext(ctx, "detach_fqdn_filter_tag_from_gw", params, nil); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
 func (c *Client) ListFQDNTags() ([]*FQDN, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New("url Parsing failed for list_fqdn_filter_tags " + err.Error())
-	}
-	listFQDNFilterTags := url.Values{}
-	listFQDNFilterTags.Add("CID", c.CID)
-	listFQDNFilterTags.Add("action", "list_fqdn_filter_tags")
-	Url.RawQuery = listFQDNFilterTags.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return nil, errors.New("HTTP Get list_fqdn_filter_tags failed: " + err.Error())
-	}
+	return c.ListFQDNTagsContext(c.Context())
+}
 
-	var data map[string]interface{}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_fqdn_filter_tags failed: " + err.Error())
-	}
-	if _, ok := data["reason"]; ok {
-		log.Printf("[INFO] Couldn't find Aviatrix FQDN tags: %s", data["reason"])
-		return nil, ErrNotFound
+func (c *Client) ListFQDNTagsContext(ctx context.Context) ([]*FQDN, error) {
+	var data map[string]fqdnTagState
+	err := c.GetAPIContext(ctx, "list_fqdn_filter_tags", nil, &data)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			log.Printf("[INFO] Couldn't find Aviatrix FQDN tags: %s", apiErr.Reason)
+			return nil, ErrNotFound
+		}
+		return nil, err
 	}
 	tags := make([]*FQDN, 0)
-	if val, ok := data["results"]; ok {
-		for tag, data := range val.(map[string]interface{}) {
-			tagData := data.(map[string]interface{})
-			fqdn := &FQDN{
-				FQDNTag:    tag,
-				FQDNMode:   tagData["wbmode"].(string),
-				FQDNStatus: tagData["state"].(string),
-			}
-			tags = append(tags, fqdn)
+	for tag, tagData := range data {
+		fqdn := &FQDN{
+			FQDNTag:    tag,
+			FQDNMode:   tagData.Mode,
+			FQDNStatus: tagData.State,
 		}
+		tags = append(tags, fqdn)
 	}
 
 	return tags, nil
 }
 
 func (c *Client) GetFQDNTag(fqdn *FQDN) (*FQDN, error) {
-	tags, err := c.ListFQDNTags()
+	return c.GetFQDNTagContext(c.Context(), fqdn)
+}
+
+func (c *Client) GetFQDNTagContext(ctx context.Context, fqdn *FQDN) (*FQDN, error) {
+	tags, err := c.ListFQDNTagsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -280,130 +178,71 @@ func (c *Client) GetFQDNTag(fqdn *FQDN) (*FQDN, error) {
 }
 
 func (c *Client) ListDomains(fqdn *FQDN) (*FQDN, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New("url Parsing failed for list_fqdn_filter_tag_domain_names " + err.Error())
-	}
-	listFQDNFilterTagDomainNames := url.Values{}
-	listFQDNFilterTagDomainNames.Add("CID", c.CID)
-	listFQDNFilterTagDomainNames.Add("action", "list_fqdn_filter_tag_domain_names")
-	listFQDNFilterTagDomainNames.Add("tag_name", fqdn.FQDNTag)
-	Url.RawQuery = listFQDNFilterTagDomainNames.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.ListDomainsContext(c.Context(), fqdn)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_fqdn_filter_tag_domain_names failed: " + err.Error())
+func (c *Client) ListDomainsContext(ctx context.Context, fqdn *FQDN) (*FQDN, error) {
+	params := map[string]string{
+		"tag_name": fqdn.FQDNTag,
 	}
-	var data map[string]interface{}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_fqdn_filter_tag_domain_names failed: " + err.Error())
-	}
-	dn := data
-	names := dn["results"].([]interface{})
-	for _, domain := range names {
-		dn := domain.(map[string]interface{})
-		fqdnFilter := Filters{
-			FQDN:     dn["fqdn"].(string),
-			Protocol: dn["proto"].(string),
-			Port:     dn["port"].(string),
-		}
-		fqdn.DomainList = append(fqdn.DomainList, &fqdnFilter)
+	var names []*Filters
+	if err := c.GetAPIContext(ctx, "list_fqdn_filter_tag_domain_names", params, &names); err != nil {
+		return nil, err
 	}
+	fqdn.DomainList = append(fqdn.DomainList, names...)
 
 	return fqdn, nil
 }
 
 func (c *Client) ListGws(fqdn *FQDN) ([]string, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New("url Parsing failed for list_fqdn_filter_tag_attached_gws " + err.Error())
-	}
-	listFQDNFilterTagAttachedGws := url.Values{}
-	listFQDNFilterTagAttachedGws.Add("CID", c.CID)
-	listFQDNFilterTagAttachedGws.Add("action", "list_fqdn_filter_tag_attached_gws")
-	listFQDNFilterTagAttachedGws.Add("tag_name", fqdn.FQDNTag)
-	Url.RawQuery = listFQDNFilterTagAttachedGws.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.ListGwsContext(c.Context(), fqdn)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_fqdn_filter_tag_attached_gws failed: " + err.Error())
-	}
-	var data ResultListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_fqdn_filter_tag_attached_gws failed: " + err.Error())
+func (c *Client) ListGwsContext(ctx context.Context, fqdn *FQDN) ([]string, error) {
+	params := map[string]string{
+		"tag_name": fqdn.FQDNTag,
 	}
-	if !data.Return {
-		log.Printf("[INFO] Couldn't find Aviatrix FQDN tag names: %s , Reason: %s", fqdn.FQDNTag,
-			data.Reason)
-		return nil, errors.New("Rest API list_fqdn_filter_tag_attached_gws Get failed: " + data.Reason)
+	var gws []string
+	if err := c.GetAPIContext(ctx, "list_fqdn_filter_tag_attached_gws", params, &gws); err != nil {
+		log.Printf("[INFO] Couldn't find Aviatrix FQDN tag names: %s , Reason: %s", fqdn.FQDNTag, err)
+		return nil, err
 	}
 
-	return data.Results, nil
+	return gws, nil
 }
 
 func (c *Client) AttachTagToGw(fqdn *FQDN, gateway *Gateway) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for attach_fqdn_filter_tag_to_gw " + err.Error())
-	}
-	attachFQDNFilterTagToGw := url.Values{}
-	attachFQDNFilterTagToGw.Add("CID", c.CID)
-	attachFQDNFilterTagToGw.Add("action", "attach_fqdn_filter_tag_to_gw")
-	attachFQDNFilterTagToGw.Add("tag_name", fqdn.FQDNTag)
-	attachFQDNFilterTagToGw.Add("gw_name", gateway.GwName)
-	Url.RawQuery = attachFQDNFilterTagToGw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get attach_fqdn_filter_tag_to_gw failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode attach_fqdn_filter_tag_to_gw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API attach_fqdn_filter_tag_to_gw Get failed: " + data.Reason)
+	return c.AttachTagToGwContext(c.Context(), fqdn, gateway)
+}
+
+func (c *Client) AttachTagToGwContext(ctx context.Context, fqdn *FQDN, gateway *Gateway) error {
+	params := map[string]string{
+		"tag_name": fqdn.FQDNTag,
+		"gw_name":  gateway.GwName,
 	}
-	return nil
+	return c.GetAPIContext(ctx, "attach_fqdn_filter_tag_to_gw", params, nil)
 }
 
 func (c *Client) UpdateSourceIPFilters(fqdn *FQDN, gateway *Gateway, sourceIPs []string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for update_fqdn_filter_tag_source_ip_filters " + err.Error())
-	}
-	attachFQDNFilterTagToGw := url.Values{}
-	attachFQDNFilterTagToGw.Add("CID", c.CID)
-	attachFQDNFilterTagToGw.Add("action", "update_fqdn_filter_tag_source_ip_filters")
-	attachFQDNFilterTagToGw.Add("tag_name", fqdn.FQDNTag)
-	attachFQDNFilterTagToGw.Add("gateway_name", gateway.GwName)
-	if len(sourceIPs) != 0 {
-		for i := range sourceIPs {
-			attachFQDNFilterTagToGw.Add("source_ips["+strconv.Itoa(i)+"]", sourceIPs[i])
-		}
-	}
+	return c.UpdateSourceIPFiltersContext(c.Context(), fqdn, gateway, sourceIPs)
+}
 
-	Url.RawQuery = attachFQDNFilterTagToGw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get update_fqdn_filter_tag_source_ip_filters failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode update_fqdn_filter_tag_source_ip_filters failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API update_fqdn_filter_tag_source_ip_filters Get failed: " + data.Reason)
+func (c *Client) UpdateSourceIPFiltersContext(ctx context.Context, fqdn *FQDN, gateway *Gateway, sourceIPs []string) error {
+	params := url.Values{}
+	params.Set("tag_name", fqdn.FQDNTag)
+	params.Set("gateway_name", gateway.GwName)
+	for i := range sourceIPs {
+		params.Set("source_ips["+strconv.Itoa(i)+"]", sourceIPs[i])
 	}
-	return nil
+	return c.GetAPIContext(ctx, "update_fqdn_filter_tag_source_ip_filters", params, nil)
 }
 
 func (c *Client) GetGwFilterTagList(fqdn *FQDN) (*FQDN, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New("url Parsing failed for list_fqdn_filter_tag_source_ip_filters: " + err.Error())
-	}
+	return c.GetGwFilterTagListContext(c.Context(), fqdn)
+}
 
-	listGws, err := c.ListGws(fqdn)
+func (c *Client) GetGwFilterTagListContext(ctx context.Context, fqdn *FQDN) (*FQDN, error) {
+	listGws, err := c.ListGwsContext(ctx, fqdn)
 	if err != nil {
 		return nil, errors.New("failed for list_fqdn_filter_tag_source_ip_filters: " + err.Error())
 	}
@@ -411,30 +250,20 @@ func (c *Client) GetGwFilterTagList(fqdn *FQDN) (*FQDN, error) {
 	var gwFilterTagList []GwFilterTag
 
 	for i := range listGws {
-		listFQDNFilterTagSourceIpFilters := url.Values{}
-		listFQDNFilterTagSourceIpFilters.Add("CID", c.CID)
-		listFQDNFilterTagSourceIpFilters.Add("action", "list_fqdn_filter_tag_source_ip_filters")
-		listFQDNFilterTagSourceIpFilters.Add("tag_name", fqdn.FQDNTag)
-		listFQDNFilterTagSourceIpFilters.Add("gateway_name", listGws[i])
-
-		Url.RawQuery = listFQDNFilterTagSourceIpFilters.Encode()
-		resp, err := c.Get(Url.String(), nil)
-		if err != nil {
-			return nil, errors.New("HTTP Get list_fqdn_filter_tag_source_ip_filters failed: " + err.Error())
-		}
-		var data ResultListSourceIPResp
-		if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-			return nil, errors.New("Json Decode list_fqdn_filter_tag_source_ip_filters failed: " + err.Error())
+		params := map[string]string{
+			"tag_name":     fqdn.FQDNTag,
+			"gateway_name": listGws[i],
 		}
-		if !data.Return {
-			return nil, errors.New("Rest API list_fqdn_filter_tag_source_ip_filters Get failed: " + data.Reason)
+		var data GwSourceIP
+		if err := c.GetAPIContext(ctx, "list_fqdn_filter_tag_source_ip_filters", params, &data); err != nil {
+			return nil, err
 		}
 
 		var gwFilterTag GwFilterTag
 		gwFilterTag.Name = listGws[i]
 		sourceIPs := make([]string, 0)
-		for j := range data.Results.ConfiguredIPs {
-			sourceIPs = append(sourceIPs, strings.Split(data.Results.ConfiguredIPs[j], "~~")[0])
+		for j := range data.ConfiguredIPs {
+			sourceIPs = append(sourceIPs, strings.Split(data.ConfiguredIPs[j], "~~")[0])
 		}
 		gwFilterTag.SourceIPList = sourceIPs
 		gwFilterTagList = append(gwFilterTagList, gwFilterTag)
@@ -1,11 +1,9 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
+	"context"
 	"log"
-	"net/url"
+	"strconv"
 )
 
 // Gateway simple struct to hold gateway details
@@ -140,124 +138,53 @@ type VpnGatewayAuth struct { // Used for set_vpn_gateway_authentication rest api
 	VpcID              string `form:"vpc_id,omitempty" json:"vpc_id,omitempty"`
 }
 
-type GatewayListResp struct {
-	Return  bool      `json:"return"`
-	Results []Gateway `json:"results"`
-	Reason  string    `json:"reason"`
+func (c *Client) CreateGateway(gateway *Gateway) error {
+	return c.CreateGatewayContext(c.Context(), gateway)
 }
 
-type GatewayDetailApiResp struct {
-	Return  bool          `json:"return"`
-	Results GatewayDetail `json:"results"`
-	Reason  string        `json:"reason"`
+func (c *Client) CreateGatewayContext(ctx context.Context, gateway *Gateway) error {
+	return c.PostAPIContext(ctx, "connect_container", gateway, nil)
 }
 
-func (c *Client) CreateGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "connect_container"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post connect_container failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode connect_container failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API connect_container Post failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) EnableNatGateway(gateway *Gateway) error {
+	return c.EnableNatGatewayContext(c.Context(), gateway)
 }
 
-func (c *Client) EnableNatGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "enable_nat"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post enable_nat failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_nat failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_nat Post failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) EnableNatGatewayContext(ctx context.Context, gateway *Gateway) error {
+	return c.PostAPIContext(ctx, "enable_nat", gateway, nil)
 }
 func (c *Client) EnableSingleAZGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "enable_single_az_ha"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post enable_single_az_ha failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_single_az_ha failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_single_az_ha Post failed: " + data.Reason)
-	}
-	return nil
+	return c.EnableSingleAZGatewayContext(c.Context(), gateway)
+}
+
+func (c *Client) EnableSingleAZGatewayContext(ctx context.Context, gateway *Gateway) error {
+	return c.PostAPIContext(ctx, "enable_single_az_ha", gateway, nil)
 }
 func (c *Client) EnablePeeringHaGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "create_peering_ha_gateway"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post create_peering_ha_gateway failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode create_peering_ha_gateway failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API create_peering_ha_gateway Post failed: " + data.Reason)
-	}
-	return nil
+	return c.EnablePeeringHaGatewayContext(c.Context(), gateway)
+}
+
+func (c *Client) EnablePeeringHaGatewayContext(ctx context.Context, gateway *Gateway) error {
+	return c.PostAPIContext(ctx, "create_peering_ha_gateway", gateway, nil)
 }
 
 func (c *Client) DisableSingleAZGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "disable_single_az_ha"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post disable_single_az_ha failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_single_az_ha failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_single_az_ha Post failed: " + data.Reason)
-	}
-	return nil
+	return c.DisableSingleAZGatewayContext(c.Context(), gateway)
+}
+
+func (c *Client) DisableSingleAZGatewayContext(ctx context.Context, gateway *Gateway) error {
+	return c.PostAPIContext(ctx, "disable_single_az_ha", gateway, nil)
 }
 
 func (c *Client) GetGateway(gateway *Gateway) (*Gateway, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_vpcs_summary") + err.Error())
-	}
-	listVpcSummary := url.Values{}
-	listVpcSummary.Add("CID", c.CID)
-	listVpcSummary.Add("action", "list_vpcs_summary")
-	Url.RawQuery = listVpcSummary.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetGatewayContext(c.Context(), gateway)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_vpcs_summary failed: " + err.Error())
-	}
-	var data GatewayListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_vpcs_summary failed: " + err.Error())
+func (c *Client) GetGatewayContext(ctx context.Context, gateway *Gateway) (*Gateway, error) {
+	var gwList []Gateway
+	if err := c.GetAPIContext(ctx, "list_vpcs_summary", nil, &gwList); err != nil {
+		return nil, err
 	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_vpcs_summary Get failed: " + data.Reason)
-	}
-
-	gwList := data.Results
 	for i := range gwList {
 		if gwList[i].GwName == gateway.GwName {
 			return &gwList[i], nil
@@ -268,30 +195,19 @@ func (c *Client) GetGateway(gateway *Gateway) (*Gateway, error) {
 }
 
 func (c *Client) GetGatewayDetail(gateway *Gateway) (*GatewayDetail, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_vpc_by_name") + err.Error())
-	}
-	listVpcByName := url.Values{}
-	listVpcByName.Add("CID", c.CID)
-	listVpcByName.Add("action", "list_vpc_by_name")
-	listVpcByName.Add("vpc_name", gateway.GwName)
-
-	Url.RawQuery = listVpcByName.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetGatewayDetailContext(c.Context(), gateway)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_vpc_by_name failed: " + err.Error())
+func (c *Client) GetGatewayDetailContext(ctx context.Context, gateway *Gateway) (*GatewayDetail, error) {
+	params := map[string]string{
+		"vpc_name": gateway.GwName,
 	}
-	var data GatewayDetailApiResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_vpc_by_name failed: " + err.Error())
+	var data GatewayDetail
+	if err := c.GetAPIContext(ctx, "list_vpc_by_name", params, &data); err != nil {
+		return nil, err
 	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_vpc_by_name Get failed: " + data.Reason)
-	}
-	if data.Results.GwName == gateway.GwName {
-		return &data.Results, nil
+	if data.GwName == gateway.GwName {
+		return &data, nil
 	}
 
 	log.Printf("Couldn't find Aviatrix gateway %s", gateway.GwName)
@@ -299,153 +215,72 @@ func (c *Client) GetGatewayDetail(gateway *Gateway) (*GatewayDetail, error) {
 }
 
 func (c *Client) UpdateGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "edit_gw_config"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post edit_gw_config failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode edit_gw_config failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API edit_gw_config Post failed: " + data.Reason)
-	}
-	return nil
+	return c.UpdateGatewayContext(c.Context(), gateway)
+}
+
+func (c *Client) UpdateGatewayContext(ctx context.Context, gateway *Gateway) error {
+	return c.PostAPIContext(ctx, "edit_gw_config", gateway, nil)
 }
 
 func (c *Client) DeleteGateway(gateway *Gateway) error {
-	path := c.baseURL + fmt.Sprintf("?action=delete_container&CID=%s&cloud_type=%d&gw_name=%s",
-		c.CID, gateway.CloudType, gateway.GwName)
-	resp, err := c.Delete(path, nil)
+	return c.DeleteGatewayContext(c.Context(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get delete_container failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_container failed: " + err.Error())
+func (c *Client) DeleteGatewayContext(ctx context.Context, gateway *Gateway) error {
+	params := map[string]string{
+		"cloud_type": strconv.Itoa(gateway.CloudType),
+		"gw_name":    gateway.GwName,
 	}
-	if !data.Return {
-		return errors.New("Rest API delete_container Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "delete_container", params, nil)
 }
 func (c *Client) EnableSNat(gateway *Gateway) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for enable_snat") + err.Error())
-	}
-	enableSNat := url.Values{}
-	enableSNat.Add("CID", c.CID)
-	enableSNat.Add("action", "enable_snat")
-	enableSNat.Add("gateway_name", gateway.GwName)
-	Url.RawQuery = enableSNat.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.EnableSNatContext(c.Context(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_snat failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_snat failed: " + err.Error())
+func (c *Client) EnableSNatContext(ctx context.Context, gateway *Gateway) error {
+	params := map[string]string{
+		"gateway_name": gateway.GwName,
 	}
-	if !data.Return {
-		return errors.New("Rest API enable_snat Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "enable_snat", params, nil)
 }
 func (c *Client) DisableSNat(gateway *Gateway) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disable_snat") + err.Error())
-	}
-	disableSNat := url.Values{}
-	disableSNat.Add("CID", c.CID)
-	disableSNat.Add("action", "disable_snat")
-	disableSNat.Add("gateway_name", gateway.GwName)
-	Url.RawQuery = disableSNat.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DisableSNatContext(c.Context(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_snat failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_snat failed: " + err.Error())
+func (c *Client) DisableSNatContext(ctx context.Context, gateway *Gateway) error {
+	params := map[string]string{
+		"gateway_name": gateway.GwName,
 	}
-	if !data.Return {
-		return errors.New("Rest API disable_snat Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "disable_snat", params, nil)
 }
 func (c *Client) UpdateVpnCidr(gateway *Gateway) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for set_vpn_client_cidr") + err.Error())
-	}
-	setVpnClientCIDR := url.Values{}
-	setVpnClientCIDR.Add("CID", c.CID)
-	setVpnClientCIDR.Add("action", "set_vpn_client_cidr")
-	setVpnClientCIDR.Add("cidr", gateway.VpnCidr)
-	setVpnClientCIDR.Add("vpc_id", gateway.VpcID)
-	setVpnClientCIDR.Add("lb_or_gateway_name", gateway.ElbName)
-	Url.RawQuery = setVpnClientCIDR.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.UpdateVpnCidrContext(c.Context(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get set_vpn_client_cidr failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_vpn_client_cidr failed: " + err.Error())
+func (c *Client) UpdateVpnCidrContext(ctx context.Context, gateway *Gateway) error {
+	params := map[string]string{
+		"cidr":               gateway.VpnCidr,
+		"vpc_id":             gateway.VpcID,
+		"lb_or_gateway_name": gateway.ElbName,
 	}
-	if !data.Return {
-		return errors.New("Rest API set_vpn_client_cidr Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "set_vpn_client_cidr", params, nil)
 }
 func (c *Client) UpdateMaxVpnConn(gateway *Gateway) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for set_vpn_client_cidr") + err.Error())
-	}
-	setMaxVpnConn := url.Values{}
-	setMaxVpnConn.Add("CID", c.CID)
-	setMaxVpnConn.Add("action", "set_vpn_max_connection")
-	setMaxVpnConn.Add("max_connections", gateway.MaxConn)
-	setMaxVpnConn.Add("vpc_id", gateway.VpcID)
-	setMaxVpnConn.Add("lb_or_gateway_name", gateway.ElbName)
-	Url.RawQuery = setMaxVpnConn.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.UpdateMaxVpnConnContext(c.Context(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get set_vpn_max_connection failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_vpn_max_connection failed: " + err.Error())
+func (c *Client) UpdateMaxVpnConnContext(ctx context.Context, gateway *Gateway) error {
+	params := map[string]string{
+		"max_connections":    gateway.MaxConn,
+		"vpc_id":             gateway.VpcID,
+		"lb_or_gateway_name": gateway.ElbName,
 	}
-	if !data.Return {
-		return errors.New("Rest API set_vpn_max_connection Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "set_vpn_max_connection", params, nil)
 }
 func (c *Client) SetVpnGatewayAuthentication(gateway *VpnGatewayAuth) error {
-	gateway.CID = c.CID
-	gateway.Action = "set_vpn_gateway_authentication"
-	resp, err := c.Post(c.baseURL, gateway)
+	return c.SetVpnGatewayAuthenticationContext(c.Context(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Post set_vpn_gateway_authentication failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_vpn_gateway_authentication failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API set_vpn_gateway_authentication Get failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) SetVpnGatewayAuthenticationContext(ctx context.Context, gateway *VpnGatewayAuth) error {
+	return c.PostAPIContext(ctx, "set_vpn_gateway_authentication", gateway, nil)
 }
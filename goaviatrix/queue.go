@@ -0,0 +1,96 @@
+package goaviatrix
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// readActionPrefixes are the prefixes of actions that only read controller
+// state.
+var readActionPrefixes = []string{"list_", "get_", "view_"}
+
+// readActions are the read-only actions not covered by readActionPrefixes.
+var readActions = map[string]bool{
+	"vpc_access_policy": true,
+}
+
+// IsReadAction reports whether the action with the given parameters only
+// reads controller state, and so does not need to wait in the operation
+// queue.
+func IsReadAction(action string, params url.Values) bool {
+	for _, prefix := range readActionPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	if readActions[action] {
+		return true
+	}
+	// Some actions multiplex reads and writes, e.g. config_http_access and
+	// modify_split_tunnel.
+	for _, key := range []string{"operation", "command"} {
+		if params.Get(key) == "get" {
+			return true
+		}
+	}
+	return false
+}
+
+// OperationQueue bounds the number of mutating actions a client sends to
+// the controller at the same time. Reads are not queued.
+type OperationQueue struct {
+	slots chan struct{}
+}
+
+// NewOperationQueue creates an OperationQueue letting at most limit mutating
+// actions run at once. A limit of 1 serializes them.
+func NewOperationQueue(limit int) *OperationQueue {
+	if limit < 1 {
+		limit = 1
+	}
+	return &OperationQueue{slots: make(chan struct{}, limit)}
+}
+
+// Limit returns the number of mutating actions allowed to run at once.
+func (q *OperationQueue) Limit() int {
+	return cap(q.slots)
+}
+
+// acquire waits for a free slot or until the context is done.
+func (q *OperationQueue) acquire(ctx context.Context) error {
+	select {
+	case q.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *OperationQueue) release() {
+	<-q.slots
+}
+
+// SetMaxConcurrentOperations limits the number of mutating actions the
+// client sends to the controller at the same time. Zero or less removes the
+// limit.
+func (c *Client) SetMaxConcurrentOperations(limit int) {
+	if limit < 1 {
+		c.queue = nil
+		return
+	}
+	c.queue = NewOperationQueue(limit)
+}
+
+// enqueue waits until the action may be sent and returns the function
+// releasing its slot.
+func (c *Client) enqueue(ctx context.Context, action string, params url.Values) (func(), error) {
+	q := c.queue
+	if q == nil || IsReadAction(action, params) {
+		return func() {}, nil
+	}
+	if err := q.acquire(ctx); err != nil {
+		return nil, err
+	}
+	return q.release, nil
+}
@@ -0,0 +1,157 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsReadAction(t *testing.T) {
+	for _, tc := range []struct {
+		action string
+		params url.Values
+		want   bool
+	}{
+		{"list_vpcs_summary", nil, true},
+		{"get_gateway_info", nil, true},
+		{"view_route_domain_details", nil, true},
+		{"vpc_access_policy", nil, true},
+		{"config_http_access", url.Values{"operation": {"get"}}, true},
+		{"modify_split_tunnel", url.Values{"command": {"get"}}, true},
+		{"config_http_access", url.Values{"operation": {"enable"}}, false},
+		{"modify_split_tunnel", url.Values{"command": {"modify"}}, false},
+		{"connect_container", nil, false},
+		{"add_connection_between_route_domains", nil, false},
+	} {
+		if got := IsReadAction(tc.action, tc.params); got != tc.want {
+			t.Errorf("IsReadAction(%s, %v) = %t, want %t", tc.action, tc.params, got, tc.want)
+		}
+	}
+}
+
+// blockingServer is a controller stub holding mutating actions until
+// released, and recording how many of them ran at once.
+type blockingServer struct {
+	mu      sync.Mutex
+	running int
+	peak    int
+	calls   map[string]int
+	release chan struct{}
+}
+
+func (s *blockingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	action := r.Form.Get("action")
+	if action == "login" {
+		fmt.Fprint(w, `{"return":true,"CID":"cid"}`)
+		return
+	}
+	read := IsReadAction(action, r.Form)
+	s.mu.Lock()
+	s.calls[action]++
+	if !read {
+		s.running++
+		if s.running > s.peak {
+			s.peak = s.running
+		}
+	}
+	s.mu.Unlock()
+	if !read {
+		select {
+		case <-s.release:
+		case <-r.Context().Done():
+		}
+		s.mu.Lock()
+		s.running--
+		s.mu.Unlock()
+	}
+	fmt.Fprint(w, `{"return":true,"results":"done"}`)
+}
+
+func (s *blockingServer) state() (running int, peak int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running, s.peak
+}
+
+func TestOperationQueue(t *testing.T) {
+	stub := &blockingServer{calls: make(map[string]int), release: make(chan struct{})}
+	srv := httptest.NewTLSServer(stub)
+	defer srv.Close()
+
+	client, err := NewClient("admin", "password", strings.TrimPrefix(srv.URL, "https://"), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.SetMaxConcurrentOperations(2)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.PostAPI("enable_snat", nil, nil)
+		}()
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for running, _ := stub.state(); running < 2; running, _ = stub.state() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the first actions to reach the controller")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Reads do not wait for the queue, even when it is full.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.GetAPIContext(ctx, "list_vpcs_summary", nil, nil); err != nil {
+		t.Errorf("expected the read to bypass the queue, got %v", err)
+	}
+	if err := client.GetAPIContext(ctx, "config_http_access", map[string]string{"operation": "get"}, nil); err != nil {
+		t.Errorf("expected the read to bypass the queue, got %v", err)
+	}
+
+	// A mutating action gives up waiting for a slot once its context is done.
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer shortCancel()
+	if err := client.PostAPIContext(shortCtx, "disable_snat", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the queued action to time out, got %v", err)
+	}
+
+	close(stub.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("expected the queued actions to succeed, got %v", err)
+		}
+	}
+	if _, peak := stub.state(); peak != 2 {
+		t.Errorf("expected at most 2 actions at once, got %d", peak)
+	}
+	if got := stub.calls["enable_snat"]; got != 5 {
+		t.Errorf("expected 5 enable_snat calls, got %d", got)
+	}
+	if got := stub.calls["disable_snat"]; got != 0 {
+		t.Errorf("expected the timed out action not to be sent, got %d calls", got)
+	}
+}
+
+func TestNewOperationQueueLimit(t *testing.T) {
+	for limit, want := range map[int]int{-1: 1, 0: 1, 1: 1, 3: 3} {
+		if got := NewOperationQueue(limit).Limit(); got != want {
+			t.Errorf("NewOperationQueue(%d).Limit() = %d, want %d", limit, got, want)
+		}
+	}
+}
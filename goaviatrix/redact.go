@@ -0,0 +1,150 @@
+package goaviatrix
+
+import (
+	"io"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// redacted replaces the value of secrets in log output.
+const redacted = "<redacted>"
+
+// secretParams are the names of the controller parameters and reply fields
+// holding credentials or session tokens.
+var secretParams = []string{
+	"CID",
+	"password",
+	"old_password",
+	"new_password",
+	"aws_secret_key",
+	"account_secret_access_key",
+	"awsgov_secret_key",
+	"awschina_secret_key",
+	"awschinacloud_secret_key",
+	"arm_application_client_secret",
+	"arm_ad_client_secret",
+	"arm_china_application_client_secret",
+	"contents",
+	"duo_secret_key",
+	"ldap_password",
+	"okta_token",
+	"pre_shared_key",
+	"backup_pre_shared_key",
+	"pre_shared_key_tun_1",
+	"pre_shared_key_tun_2",
+}
+
+// secretTypes are the structs whose fields are mapped to secretParams through
+// their form and json tags, so that the secrets are also found in their %#v
+// representation.
+var secretTypes = []interface{}{
+	(*Account)(nil),
+	(*AccountUser)(nil),
+	(*AccountUserEdit)(nil),
+	(*AwsTgwVpnConn)(nil),
+	(*AwsTgwVpnConnEdit)(nil),
+	(*Gateway)(nil),
+	(*VpnGatewayAuth)(nil),
+	(*Site2Cloud)(nil),
+}
+
+type redactRule struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+var (
+	redactOnce  sync.Once
+	redactRules []redactRule
+)
+
+// secretFieldNames returns the names of the fields of secretTypes tagged
+// with one of secretParams, plus the fields named like one, e.g. CID.
+func secretFieldNames() []string {
+	params := make(map[string]bool)
+	for _, p := range secretParams {
+		params[p] = true
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, v := range secretTypes {
+		t := reflect.TypeOf(v).Elem()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			secret := params[f.Name]
+			for _, key := range []string{"form", "json"} {
+				name := strings.Split(f.Tag.Get(key), ",")[0]
+				if params[name] {
+					secret = true
+				}
+			}
+			if secret && !seen[f.Name] {
+				seen[f.Name] = true
+				names = append(names, f.Name)
+			}
+		}
+	}
+	return names
+}
+
+func initRedactRules() {
+	quote := func(names []string) string {
+		q := make([]string, len(names))
+		for i, name := range names {
+			q[i] = regexp.QuoteMeta(name)
+		}
+		return strings.Join(q, "|")
+	}
+	params := quote(secretParams)
+	fields := quote(secretFieldNames())
+	redactRules = []redactRule{
+		// JSON and Go maps: "password":"secret"
+		{regexp.MustCompile(`("(?:` + params + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`), `$1"` + redacted + `"`},
+		// Go structs printed with %#v: Password:"secret"
+		{regexp.MustCompile(`(\b(?:` + fields + `):)"(?:[^"\\]|\\.)*"`), `$1"` + redacted + `"`},
+		// form bodies and query strings: password=secret
+		{regexp.MustCompile(`((?:^|[?&\s])(?:` + params + `)=)[^&\s]*`), `$1` + redacted},
+		// free text: CID is 'secret'
+		{regexp.MustCompile(`(\bCID is )'[^']*'`), `$1'` + redacted + `'`},
+	}
+}
+
+// Redact masks the values of credentials and session tokens in s, which may
+// be a form body, a URL, JSON or the %#v representation of a request struct.
+func Redact(s string) string {
+	redactOnce.Do(initRedactRules)
+	for _, rule := range redactRules {
+		s = rule.re.ReplaceAllString(s, rule.repl)
+	}
+	return s
+}
+
+type redactingWriter struct {
+	w io.Writer
+}
+
+// NewRedactingWriter returns a writer masking credentials and session
+// tokens, as Redact does, before writing to w. It is meant to wrap the
+// output of the log package, which writes each entry in a single call.
+func NewRedactingWriter(w io.Writer) io.Writer {
+	return &redactingWriter{w: w}
+}
+
+func (r *redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// RedactLogOutput makes the log package mask credentials and session tokens
+// in everything it writes from now on. Calling it again has no effect.
+func RedactLogOutput() {
+	w := log.Writer()
+	if _, ok := w.(*redactingWriter); !ok {
+		log.SetOutput(NewRedactingWriter(w))
+	}
+}
@@ -0,0 +1,28 @@
+package goaviatrix
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	cases := []string{
+		"POST https://ctrl/v1/api Body: CID=s3cret&action=login&password=s3cret&username=admin",
+		"GET https://ctrl/v1/api?CID=s3cret&action=list_accounts",
+		`{"return":true,"CID":"s3cret"}`,
+		fmt.Sprintf("%#v", map[string]interface{}{"password": "s3cret", "username": "admin"}),
+		fmt.Sprintf("%#v", &Account{AccountName: "admin", AwsSecretKey: `s3cret"quoted`}),
+		fmt.Sprintf("%#v", Gateway{GwName: "admin", LdapPassword: "s3cret", DuoSecretKey: "s3cret", OktaToken: "s3cret"}),
+		fmt.Sprintf("%#v", Site2Cloud{TunnelName: "admin", PreSharedKey: "s3cret", BackupPreSharedKey: "s3cret"}),
+	}
+	for _, c := range cases {
+		got := Redact(c)
+		if strings.Contains(got, "s3cret") {
+			t.Errorf("secret not redacted in %q", got)
+		}
+		if !strings.Contains(got, "admin") && !strings.Contains(got, "list_accounts") && !strings.Contains(got, `"return":true`) {
+			t.Errorf("too much redacted in %q", got)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// RetryRule selects failed actions that are worth retrying. A rule matches
+// an APIError whose reason contains Reason (case insensitive) or, when
+// Reason is empty, whose Kind equals Kind.
+type RetryRule struct {
+	Kind   ErrorKind
+	Reason string
+	// MinBackoff overrides the policy's MinBackoff for matching errors.
+	MinBackoff time.Duration
+	// MaxAttempts overrides the policy's MaxAttempts for matching errors.
+	MaxAttempts int
+}
+
+// RetryPolicy controls how often and how fast the client retries actions
+// the controller could not complete. The n-th retry waits MinBackoff*2^(n-1)
+// capped at MaxBackoff, spread by +/- Jitter (a fraction of the delay).
+// Transport errors and errors carrying only an HTTP status, e.g. a gateway
+// timeout reported by a proxy, are only retried for actions which read
+// controller state, since the controller may already have acted on any other
+// action that failed to return.
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	Jitter      float64
+	Rules       []RetryRule
+}
+
+// DefaultRetryPolicy returns the policy used by clients that were not given
+// one: 4 attempts, backing off from 5 seconds to 1 minute, retrying when the
+// controller is busy and waiting a full minute while an upgrade or another
+// long running operation is in progress.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  5 * time.Second,
+		MaxBackoff:  60 * time.Second,
+		Jitter:      0.2,
+		Rules: []RetryRule{
+			{Reason: "in progress", MinBackoff: 60 * time.Second},
+			{Kind: ErrorKindBusy},
+		},
+	}
+}
+
+// match returns the first rule matching err, or nil.
+func (p *RetryPolicy) match(err error) *RetryRule {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+	reason := strings.ToLower(apiErr.Reason)
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Reason != "" {
+			if strings.Contains(reason, strings.ToLower(rule.Reason)) {
+				return rule
+			}
+		} else if rule.Kind == apiErr.Kind {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Backoff returns how long to wait before the given retry (1 for the first
+// one), using minBackoff instead of the policy's MinBackoff when non-zero.
+func (p *RetryPolicy) Backoff(retry int, minBackoff time.Duration) time.Duration {
+	if minBackoff <= 0 {
+		minBackoff = p.MinBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+	d := minBackoff
+	for i := 1; i < retry && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	if p.Jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// delay reports whether attempt (counting from 1) failing with err should be
+// retried and how long to wait first. Errors for which final reports true are
+// never retried, whatever the rules.
+func (p *RetryPolicy) delay(attempt int, err error, retryable func(error) bool, final func(error) bool) (time.Duration, bool) {
+	if final != nil && final(err) {
+		return 0, false
+	}
+	maxAttempts := p.MaxAttempts
+	var minBackoff time.Duration
+	if rule := p.match(err); rule != nil {
+		if rule.MaxAttempts > 0 {
+			maxAttempts = rule.MaxAttempts
+		}
+		minBackoff = rule.MinBackoff
+	} else if retryable == nil || !retryable(err) {
+		return 0, false
+	}
+	if attempt >= maxAttempts {
+		return 0, false
+	}
+	return p.Backoff(attempt, minBackoff), true
+}
+
+// SetRetryPolicy sets the policy used to retry failed actions. A nil policy
+// restores DefaultRetryPolicy().
+func (c *Client) SetRetryPolicy(p *RetryPolicy) {
+	c.retryPolicy = p
+}
+
+// RetryPolicy returns the policy used to retry failed actions.
+func (c *Client) RetryPolicy() *RetryPolicy {
+	if c.retryPolicy == nil {
+		return DefaultRetryPolicy()
+	}
+	return c.retryPolicy
+}
+
+// Retry calls fn until it succeeds, backing off between attempts as the
+// client's RetryPolicy prescribes.
+// Arguments:
+//    retryable - reports whether an error not covered by the policy's rules
+//                should be retried; nil retries any APIError
+//    fn - the operation to retry
+// Returns:
+//    error - the last error returned by fn, if any
+func (c *Client) Retry(retryable func(error) bool, fn func() error) error {
+	return c.RetryContext(c.Context(), retryable, fn)
+}
+
+// RetryContext is the same as Retry but waits using the given context.
+func (c *Client) RetryContext(ctx context.Context, retryable func(error) bool, fn func() error) error {
+	return c.retry(ctx, retryable, nil, fn)
+}
+
+// retryAction retries an action sent by dispatch. Only actions reading
+// controller state are sent again when the reply may have been lost: after a
+// transport error or an error without a reason from the controller.
+func (c *Client) retryAction(ctx context.Context, read bool, fn func() error) error {
+	retryable := func(err error) bool {
+		return read && ctx.Err() == nil && !isAPIError(err)
+	}
+	var final func(error) bool
+	if !read {
+		final = isBareStatusError
+	}
+	return c.retry(ctx, retryable, final, fn)
+}
+
+func (c *Client) retry(ctx context.Context, retryable func(error) bool, final func(error) bool, fn func() error) error {
+	if retryable == nil {
+		retryable = isAPIError
+	}
+	policy := c.RetryPolicy()
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		d, ok := policy.delay(attempt, err, retryable, final)
+		if !ok {
+			return err
+		}
+		log.Printf("[INFO] Retrying in %s after attempt %d failed: %s", d.Round(time.Second), attempt, err)
+		if err := SleepContext(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+func isAPIError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr)
+}
+
+// isBareStatusError reports whether err is an APIError without a reason from
+// the controller, only an HTTP status, as a proxy in front of it replies.
+func isBareStatusError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Reason == ""
+}
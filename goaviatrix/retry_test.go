@@ -0,0 +1,179 @@
+package goaviatrix
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyServer is a controller stub answering every action but login with a
+// bare HTTP status, as a proxy in front of an overloaded controller does, or
+// with the reason given for the action.
+type flakyServer struct {
+	mu      sync.Mutex
+	calls   map[string]int
+	reasons map[string]string
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	action := r.Form.Get("action")
+	if action == "login" {
+		fmt.Fprint(w, `{"return":true,"CID":"cid"}`)
+		return
+	}
+	s.mu.Lock()
+	s.calls[action]++
+	reason, ok := s.reasons[action]
+	s.mu.Unlock()
+	if ok {
+		fmt.Fprintf(w, `{"return":false,"reason":%q}`, reason)
+		return
+	}
+	w.WriteHeader(http.StatusGatewayTimeout)
+}
+
+func TestRetryOnlyRepeatsSafeActions(t *testing.T) {
+	stub := &flakyServer{
+		calls:   make(map[string]int),
+		reasons: map[string]string{"enable_snat": "The controller is busy, try again later."},
+	}
+	srv := httptest.NewTLSServer(stub)
+	defer srv.Close()
+
+	client, err := NewClient("admin", "password", strings.TrimPrefix(srv.URL, "https://"), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 3
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = time.Millisecond
+	policy.Jitter = 0
+	client.SetRetryPolicy(policy)
+
+	for _, tc := range []struct {
+		verb   string
+		action string
+		calls  int
+	}{
+		// The reply of a mutating action may have been lost behind the
+		// gateway timeout, whatever the verb.
+		{"POST", "create_transit_gw", 1},
+		{"GET", "add_connection_between_route_domains", 1},
+		// Reads can always be sent again.
+		{"GET", "list_vpcs_summary", 3},
+		// The controller itself reports that it did not act.
+		{"GET", "enable_snat", 3},
+	} {
+		if tc.verb == "POST" {
+			err = client.PostAPI(tc.action, nil, nil)
+		} else {
+			err = client.GetAPI(tc.action, nil, nil)
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.action)
+		}
+		if got := stub.calls[tc.action]; got != tc.calls {
+			t.Errorf("%s: expected %d calls, got %d", tc.action, tc.calls, got)
+		}
+	}
+}
+
+func TestRetryPolicyMatch(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	for _, tc := range []struct {
+		err  error
+		want *RetryRule
+	}{
+		// The reason rule comes first, although the error is also Busy.
+		{NewAPIError("upgrade", "Post", http.StatusOK, "Upgrade IN PROGRESS"), &policy.Rules[0]},
+		{fmt.Errorf("wrapped: %w", NewAPIError("enable_snat", "Get", http.StatusOK, "Controller is busy")), &policy.Rules[1]},
+		{NewAPIError("list_vpcs_summary", "Get", http.StatusServiceUnavailable, ""), &policy.Rules[1]},
+		{NewAPIError("connect_container", "Post", http.StatusOK, "Invalid gw_size"), nil},
+		{fmt.Errorf("Upgrade in progress"), nil},
+	} {
+		if got := policy.match(tc.err); got != tc.want {
+			t.Errorf("match(%q) = %+v, want %+v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	for _, tc := range []struct {
+		retry      int
+		minBackoff time.Duration
+		want       time.Duration
+	}{
+		{1, 0, time.Second},
+		{2, 0, 2 * time.Second},
+		{4, 0, 8 * time.Second},
+		{5, 0, 10 * time.Second},
+		{100, 0, 10 * time.Second},
+		{1, 3 * time.Second, 3 * time.Second},
+		{2, 3 * time.Second, 6 * time.Second},
+		// A rule's MinBackoff above MaxBackoff is still honoured.
+		{1, time.Minute, time.Minute},
+		{3, time.Minute, time.Minute},
+	} {
+		if got := policy.Backoff(tc.retry, tc.minBackoff); got != tc.want {
+			t.Errorf("Backoff(%d, %s) = %s, want %s", tc.retry, tc.minBackoff, got, tc.want)
+		}
+	}
+
+	policy.Jitter = 0.2
+	for i := 0; i < 100; i++ {
+		if got := policy.Backoff(2, 0); got < 1600*time.Millisecond || got > 2400*time.Millisecond {
+			t.Fatalf("Backoff(2, 0) = %s, want 2s +/- 20%%", got)
+		}
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Second,
+		MaxBackoff:  time.Minute,
+		Rules: []RetryRule{
+			{Reason: "in progress", MinBackoff: 30 * time.Second, MaxAttempts: 4},
+			{Kind: ErrorKindBusy},
+		},
+	}
+	inProgress := NewAPIError("upgrade", "Post", http.StatusOK, "Upgrade in progress")
+	busy := NewAPIError("enable_snat", "Get", http.StatusOK, "Controller is busy")
+	invalid := NewAPIError("connect_container", "Post", http.StatusOK, "Invalid gw_size")
+	bare := NewAPIError("list_vpcs_summary", "Get", http.StatusGatewayTimeout, "")
+	always := func(error) bool { return true }
+
+	for _, tc := range []struct {
+		name      string
+		attempt   int
+		err       error
+		retryable func(error) bool
+		final     func(error) bool
+		want      time.Duration
+		ok        bool
+	}{
+		{"rule backoff", 1, inProgress, nil, nil, 30 * time.Second, true},
+		{"rule attempts", 3, inProgress, nil, nil, time.Minute, true},
+		{"rule attempts exhausted", 4, inProgress, nil, nil, 0, false},
+		{"policy attempts", 1, busy, nil, nil, time.Second, true},
+		{"policy attempts exhausted", 2, busy, nil, nil, 0, false},
+		{"no rule", 1, invalid, nil, nil, 0, false},
+		{"no rule but retryable", 1, invalid, always, nil, time.Second, true},
+		{"final", 1, bare, always, isBareStatusError, 0, false},
+	} {
+		got, ok := policy.delay(tc.attempt, tc.err, tc.retryable, tc.final)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%s: delay = %s, %t, want %s, %t", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -1,13 +1,12 @@
 package goaviatrix
 
 import (
+	"context"
 	"crypto/tls"
-	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -31,62 +30,29 @@ type SamlList struct {
 	SpMetadataUrl   string `json:"sp_metadata_url"`
 }
 
-type SamlListResp struct {
-	Return  bool       `json:"return"`
-	Results []SamlList `json:"results"`
-	Reason  string     `json:"reason"`
-}
-
 func (c *Client) CreateSamlEndpoint(samlEndpoint *SamlEndpoint) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for create_saml_endpoint ") + err.Error())
-	}
-	saml := url.Values{}
-	saml.Add("CID", c.CID)
-	saml.Add("action", "create_saml_endpoint")
-	saml.Add("endpoint_name", samlEndpoint.EndPointName)
-	saml.Add("idp_metadata_type", samlEndpoint.IdpMetadataType)
-	saml.Add("idp_metadata", samlEndpoint.IdpMetadata)
-	saml.Add("entity_id", samlEndpoint.EntityIdType)
-	Url.RawQuery = saml.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.CreateSamlEndpointContext(c.Context(), samlEndpoint)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get create_saml_endpoint failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode create_saml_endpoint failed: " + err.Error())
+func (c *Client) CreateSamlEndpointContext(ctx context.Context, samlEndpoint *SamlEndpoint) error {
+	params := map[string]string{
+		"endpoint_name":     samlEndpoint.EndPointName,
+		"idp_metadata_type": samlEndpoint.IdpMetadataType,
+		"idp_metadata":      samlEndpoint.IdpMetadata,
+		"entity_id":         samlEndpoint.EntityIdType,
 	}
-	if !data.Return {
-		return errors.New("Rest API create_saml_endpoint Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "create_saml_endpoint", params, nil)
 }
 
 func (c *Client) GetSamlEndpoint(samlEndpoint *SamlEndpoint) (*SamlEndpoint, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_saml_endpoints ") + err.Error())
-	}
-	listSamlEndpoints := url.Values{}
-	listSamlEndpoints.Add("CID", c.CID)
-	listSamlEndpoints.Add("action", "list_saml_endpoints")
-	Url.RawQuery = listSamlEndpoints.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetSamlEndpointContext(c.Context(), samlEndpoint)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_saml_endpoints failed: " + err.Error())
-	}
-	var data SamlListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_saml_endpoints failed: " + err.Error())
+func (c *Client) GetSamlEndpointContext(ctx context.Context, samlEndpoint *SamlEndpoint) (*SamlEndpoint, error) {
+	var samlList []SamlList
+	if err := c.GetAPIContext(ctx, "list_saml_endpoints", nil, &samlList); err != nil {
+		return nil, err
 	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_saml_endpoints Get failed: " + data.Reason)
-	}
-	samlList := data.Results
 	for i := range samlList {
 		if samlList[i].Name == samlEndpoint.EndPointName {
 			log.Printf("[DEBUG] Found SAML endpoint %s: %#v", samlEndpoint.EndPointName, samlList[i])
@@ -94,8 +60,14 @@ func (c *Client) GetSamlEndpoint(samlEndpoint *SamlEndpoint) (*SamlEndpoint, err
 				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 			}
 			client := &http.Client{Transport: tr}
-			time.Sleep(5 * time.Second)
-			resp, err := client.Get(samlList[i].IdpMetadataUrl)
+			if err := SleepContext(ctx, 5*time.Second); err != nil {
+				return nil, err
+			}
+			req, err := http.NewRequestWithContext(ctx, "GET", samlList[i].IdpMetadataUrl, nil)
+			if err != nil {
+				return nil, errors.New("Cannot get IDP Metadata: " + err.Error())
+			}
+			resp, err := client.Do(req)
 			if err != nil {
 				return nil, errors.New("Cannot get IDP Metadata: " + err.Error())
 			}
@@ -123,26 +95,12 @@ func (c *Client) GetSamlEndpoint(samlEndpoint *SamlEndpoint) (*SamlEndpoint, err
 }
 
 func (c *Client) DeleteSamlEndpoint(samlEndpoint *SamlEndpoint) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for delete_saml_endpoint ") + err.Error())
-	}
-	deleteSaml := url.Values{}
-	deleteSaml.Add("CID", c.CID)
-	deleteSaml.Add("action", "delete_saml_endpoint")
-	deleteSaml.Add("endpoint_name", samlEndpoint.EndPointName)
-	Url.RawQuery = deleteSaml.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteSamlEndpointContext(c.Context(), samlEndpoint)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get delete_saml_endpoint failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_saml_endpoint failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_saml_endpoint Get failed: " + data.Reason)
+func (c *Client) DeleteSamlEndpointContext(ctx context.Context, samlEndpoint *SamlEndpoint) error {
+	params := map[string]string{
+		"endpoint_name": samlEndpoint.EndPointName,
 	}
-	return nil
+	return c.GetAPIContext(ctx, "delete_saml_endpoint", params, nil)
 }
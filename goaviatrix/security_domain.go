@@ -1,9 +1,7 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"net/url"
+	"context"
 )
 
 // AwsTGW simple struct to hold aws_tgw details
@@ -16,18 +14,6 @@ type SecurityDomain struct {
 	AwsTgwName  string `form:"tgw_name, omitempty"`
 }
 
-type SecurityDomainAPIResp struct {
-	Return  bool     `json:"return"`
-	Results []string `json:"results"`
-	Reason  string   `json:"reason"`
-}
-
-type SecurityDomainList struct {
-	Return  bool     `json:"return"`
-	Results []string `json:"results"`
-	Reason  string   `json:"reason"`
-}
-
 type SecurityDomainRule struct {
 	Name            string    `json:"security_domain_name, omitempty"`
 	ConnectedDomain []string  `json:"connected_domains, omitempty"`
@@ -41,44 +27,22 @@ type VPCSolo struct {
 }
 
 func (c *Client) CreateSecurityDomain(securityDomain *SecurityDomain) error {
-	securityDomain.CID = c.CID
-	securityDomain.Action = "add_route_domain"
-	resp, err := c.Post(c.baseURL, securityDomain)
-	if err != nil {
-		return errors.New("HTTP Post add_route_domain failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_route_domain failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_route_domain Post failed: " + data.Reason)
-	}
+	return c.CreateSecurityDomainContext(c.Context(), securityDomain)
+}
 
-	return nil
+func (c *Client) CreateSecurityDomainContext(ctx context.Context, securityDomain *SecurityDomain) error {
+	return c.PostAPIContext(ctx, "add_route_domain", securityDomain, nil)
 }
 
 func (c *Client) GetSecurityDomain(securityDomain *SecurityDomain) (string, error) {
-	securityDomain.CID = c.CID
-	securityDomain.Action = "list_route_domain_names"
-	resp, err := c.Post(c.baseURL, securityDomain)
-	if err != nil {
-		return "", errors.New("HTTP Post list_route_domain_names failed: " + err.Error())
-	}
+	return c.GetSecurityDomainContext(c.Context(), securityDomain)
+}
 
-	data := SecurityDomainAPIResp{
-		Return:  false,
-		Results: make([]string, 0),
-		Reason:  "",
-	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", errors.New("Json Decode list_route_domain_names failed: " + err.Error())
+func (c *Client) GetSecurityDomainContext(ctx context.Context, securityDomain *SecurityDomain) (string, error) {
+	var securityDomainList []string
+	if err := c.PostAPIContext(ctx, "list_route_domain_names", securityDomain, &securityDomainList); err != nil {
+		return "", err
 	}
-	if !data.Return {
-		return "", errors.New("Rest API list_route_domain_names Post failed: " + data.Reason)
-	}
-
-	securityDomainList := data.Results
 	for i := range securityDomainList {
 		if securityDomainList[i] == securityDomain.Name {
 			return securityDomainList[i], nil
@@ -93,82 +57,59 @@ func (c *Client) UpdateSecurityDomain(securityDomain *SecurityDomain) error {
 }
 
 func (c *Client) DeleteSecurityDomain(securityDomain *SecurityDomain) error {
-	securityDomain.CID = c.CID
-	securityDomain.Action = "delete_route_domain"
-	resp, err := c.Post(c.baseURL, securityDomain)
-	if err != nil {
-		return errors.New("HTTP Post delete_route_domain failed: " + err.Error())
-	}
-
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_route_domain failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_route_domain Post failed: " + data.Reason)
-	}
+	return c.DeleteSecurityDomainContext(c.Context(), securityDomain)
+}
 
-	return nil
+func (c *Client) DeleteSecurityDomainContext(ctx context.Context, securityDomain *SecurityDomain) error {
+	return c.PostAPIContext(ctx, "delete_route_domain", securityDomain, nil)
 }
 
 func (c *Client) CreateDomainConnection(awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for add_connection_between_route_domains") + err.Error())
-	}
-	addConnectionBetweenRouteDomains := url.Values{}
-	addConnectionBetweenRouteDomains.Add("CID", c.CID)
-	addConnectionBetweenRouteDomains.Add("action", "add_connection_between_route_domains")
-	addConnectionBetweenRouteDomains.Add("account_name", awsTgw.AccountName)
-	addConnectionBetweenRouteDomains.Add("region", awsTgw.Region)
-	addConnectionBetweenRouteDomains.Add("tgw_name", awsTgw.Name)
-	addConnectionBetweenRouteDomains.Add("source_route_domain_name", sourceDomain)
-	addConnectionBetweenRouteDomains.Add("destination_route_domain_name", destinationDomain)
-	Url.RawQuery = addConnectionBetweenRouteDomains.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get add_connection_between_route_domains failed: " + err.Error())
-	}
+	return c.CreateDomainConnectionContext(c.Context(), awsTgw, sourceDomain, destinationDomain)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_connection_between_route_domains failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_connection_between_route_domains Get failed: " + data.Reason)
+func (c *Client) CreateDomainConnectionContext(ctx context.Context, awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
+	params := map[string]string{
+		"account_name":                  awsTgw.AccountName,
+		"region":                        awsTgw.Region,
+		"tgw_name":                      awsTgw.Name,
+		"source_route_domain_name":      sourceDomain,
+		"destination_route_domain_name": destinationDomain,
 	}
-
-	return nil
+	return c.GetAPIContext(ctx, "add_connection_between_route_domains", params, nil)
 }
 
 func (c *Client) DeleteDomainConnection(awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for delete_connection_between_route_domains") + err.Error())
-	}
-	deleteConnectionBetweenRouteDomains := url.Values{}
-	deleteConnectionBetweenRouteDomains.Add("CID", c.CID)
-	deleteConnectionBetweenRouteDomains.Add("action", "delete_connection_between_route_domains")
-	deleteConnectionBetweenRouteDomains.Add("account_name", awsTgw.AccountName)
-	deleteConnectionBetweenRouteDomains.Add("region", awsTgw.Region)
-	deleteConnectionBetweenRouteDomains.Add("tgw_name", awsTgw.Name)
-	deleteConnectionBetweenRouteDomains.Add("source_route_domain_name", sourceDomain)
-	deleteConnectionBetweenRouteDomains.Add("destination_route_domain_name", destinationDomain)
-	Url.RawQuery = deleteConnectionBetweenRouteDomains.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteDomainConnectionContext(c.Context(), awsTgw, sourceDomain, destinationDomain)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get delete_connection_between_route_domains failed: " + err.Error())
+func (c *Client) DeleteDomainConnectionContext(ctx context.Context, awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
+	params := map[string]string{
+		"account_name":                  awsTgw.AccountName,
+		"region":                        awsTgw.Region,
+		"tgw_name":                      awsTgw.Name,
+		"source_route_domain_name":      sourceDomain,
+		"destination_route_domain_name": destinationDomain,
 	}
+	return c.GetAPIContext(ctx, "delete_connection_between_route_domains", params, nil)
+}
+
+// GetDomainConnection returns ErrNotFound unless the two security domains of
+// the TGW are connected. Connections are symmetric, so the order of the
+// domains doesn't matter.
+func (c *Client) GetDomainConnection(awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
+	return c.GetDomainConnectionContext(c.Context(), awsTgw, sourceDomain, destinationDomain)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_connection_between_route_domains failed: " + err.Error())
+func (c *Client) GetDomainConnectionContext(ctx context.Context, awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
+	routeDomainDetail, err := c.getRouteDomainDetails(ctx, awsTgw.Name, sourceDomain)
+	if err != nil {
+		return err
 	}
-	if !data.Return {
-		return errors.New("Rest API delete_connection_between_route_domains Get failed: " + data.Reason)
+	for _, connectedDomain := range routeDomainDetail[0].ConnectedRouteDomain {
+		if connectedDomain == destinationDomain {
+			return nil
+		}
 	}
-
-	return nil
+	return ErrNotFound
 }
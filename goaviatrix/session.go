@@ -0,0 +1,76 @@
+package goaviatrix
+
+import (
+	"context"
+	"sync"
+)
+
+// session holds the CID of the client's login and the controller it was
+// issued by. It is shared by all the goroutines using the client, so access
+// goes through its mutex, and concurrent re-logins are collapsed into a
+// single one.
+type session struct {
+	mu       sync.Mutex
+	cid      string
+	endpoint int
+	login    *loginCall
+}
+
+// loginCall is a login in progress. done is closed once err is set.
+type loginCall struct {
+	done chan struct{}
+	err  error
+}
+
+// CID returns the session ID of the current login to the controller.
+func (c *Client) CID() string {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+	return c.session.cid
+}
+
+// sessionState returns the CID and the controller it is valid for.
+func (c *Client) sessionState() (string, string) {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+	return c.session.cid, c.endpoints[c.session.endpoint]
+}
+
+// refreshSession logs in again unless the session stale belongs to was
+// already replaced. With failover, the login goes to the controllers after
+// the current one. Goroutines calling it while a login is in progress wait
+// for that login and share its result instead of starting their own.
+func (c *Client) refreshSession(ctx context.Context, stale string, failover bool) error {
+	s := &c.session
+	s.mu.Lock()
+	if s.cid != stale {
+		s.mu.Unlock()
+		return nil
+	}
+	if call := s.login; call != nil {
+		s.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	call := &loginCall{done: make(chan struct{})}
+	s.login = call
+	current := s.endpoint
+	s.mu.Unlock()
+
+	cid, endpoint, err := c.connect(ctx, current, failover)
+
+	s.mu.Lock()
+	if err == nil {
+		s.cid = cid
+		s.endpoint = endpoint
+	}
+	call.err = err
+	s.login = nil
+	s.mu.Unlock()
+	close(call.done)
+	return err
+}
@@ -0,0 +1,81 @@
+package goaviatrix
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// sessionServer is a controller stub issuing a new CID on every login and
+// accepting only the latest one.
+type sessionServer struct {
+	logins int32
+	mu     sync.Mutex
+	valid  string
+}
+
+func (s *sessionServer) expire() {
+	s.mu.Lock()
+	s.valid = ""
+	s.mu.Unlock()
+}
+
+func (s *sessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Form.Get("action") == "login" {
+		s.valid = fmt.Sprintf("cid%d", atomic.AddInt32(&s.logins, 1))
+		fmt.Fprintf(w, `{"return":true,"CID":%q}`, s.valid)
+		return
+	}
+	if r.Form.Get("CID") != s.valid {
+		fmt.Fprint(w, `{"return":false,"reason":"CID is invalid or expired."}`)
+		return
+	}
+	fmt.Fprint(w, `{"return":true,"results":{"account_list":[{"account_name":"test"}]}}`)
+}
+
+func TestConcurrentReloginIsSingleFlight(t *testing.T) {
+	stub := &sessionServer{}
+	srv := httptest.NewTLSServer(stub)
+	defer srv.Close()
+
+	client, err := NewClient("admin", "password", strings.TrimPrefix(srv.URL, "https://"), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	stub.expire()
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetAccount(&Account{AccountName: "test"}); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("GetAccount: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&stub.logins); got != 2 {
+		t.Errorf("expected 2 logins (initial and one re-login), got %d", got)
+	}
+	if got := client.CID(); got != "cid2" {
+		t.Errorf("expected CID cid2, got %q", got)
+	}
+}
@@ -0,0 +1,175 @@
+package goaviatrix
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DefaultProfile is the profile used when none is selected.
+const DefaultProfile = "default"
+
+// SharedProfile is a named set of controller settings read from the shared
+// config file, ~/.aviatrix/config by default. The file holds one section
+// per profile:
+//
+//    [default]
+//    controller_ip = 10.0.0.1
+//    username      = admin
+//    credential_process = vault read -field=password secret/aviatrix
+//
+//    [profile dr]
+//    controller_ips = ctrl.example.com, standby.example.com
+//    username       = admin
+//    password       = ...
+//    ca_file        = /etc/aviatrix/ca.pem
+//
+// Lines starting with # or ; are comments.
+type SharedProfile struct {
+	Name              string
+	ControllerIP      string
+	ControllerIPs     []string
+	Username          string
+	Password          string
+	CredentialProcess string
+	CAFile            string
+}
+
+// DefaultSharedConfigFile returns the path of the shared config file: the
+// value of AVIATRIX_CONFIG_FILE, or .aviatrix/config in the home directory.
+func DefaultSharedConfigFile() string {
+	if path := os.Getenv("AVIATRIX_CONFIG_FILE"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".aviatrix", "config")
+}
+
+// LoadSharedProfile reads the named profile from the shared config file.
+// Arguments:
+//    path - the shared config file
+//    name - the profile, DefaultProfile if empty
+// Returns:
+//    SharedProfile - the settings of the profile
+//    error - ErrNotFound if there is no such profile, an error satisfying
+//            os.IsNotExist if the file does not exist
+func LoadSharedProfile(path string, name string) (*SharedProfile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var profile *SharedProfile
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			section = strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+			if section == name {
+				profile = &SharedProfile{Name: name}
+			}
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || section == "" {
+			return nil, fmt.Errorf("%s:%d: expected a [profile] header or a key = value setting", path, n)
+		}
+		if section != name {
+			continue
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch key {
+		case "controller_ip":
+			profile.ControllerIP = value
+		case "controller_ips":
+			profile.ControllerIPs = nil
+			for _, ip := range strings.Split(value, ",") {
+				if ip = strings.TrimSpace(ip); ip != "" {
+					profile.ControllerIPs = append(profile.ControllerIPs, ip)
+				}
+			}
+		case "username":
+			profile.Username = value
+		case "password":
+			profile.Password = value
+		case "credential_process":
+			profile.CredentialProcess = value
+		case "ca_file":
+			profile.CAFile = value
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown setting %q", path, n, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("profile %q not found in %s: %w", name, path, ErrNotFound)
+	}
+	return profile, nil
+}
+
+// processCredentials is the output of a credential process printing JSON.
+type processCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// RunCredentialProcess runs the credential_process command of the profile
+// and sets the password it prints, and the username if it prints one. The
+// command is run by the shell. It either prints the password alone or a
+// JSON object such as {"username": "admin", "password": "..."}.
+func (p *SharedProfile) RunCredentialProcess(ctx context.Context) error {
+	if p.CredentialProcess == "" {
+		return nil
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", p.CredentialProcess)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.CredentialProcess)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("credential_process of profile %q failed: %v: %s", p.Name, err,
+			strings.TrimSpace(stderr.String()))
+	}
+
+	out := strings.TrimSpace(stdout.String())
+	if strings.HasPrefix(out, "{") {
+		var creds processCredentials
+		if err := json.Unmarshal([]byte(out), &creds); err != nil {
+			return fmt.Errorf("credential_process of profile %q printed invalid JSON: %v", p.Name, err)
+		}
+		if creds.Username != "" {
+			p.Username = creds.Username
+		}
+		out = creds.Password
+	}
+	if out == "" {
+		return fmt.Errorf("credential_process of profile %q printed no password", p.Name)
+	}
+	p.Password = out
+	return nil
+}
@@ -0,0 +1,92 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSharedConfig = `
+# Controllers of the team
+[default]
+controller_ip = 10.0.0.1
+username      = admin
+password      = secret
+
+; disaster recovery
+[profile dr]
+controller_ips     = ctrl.example.com, standby.example.com
+username           = operator
+credential_process = echo '{"username": "ops", "password": "from-process"}'
+ca_file            = /etc/aviatrix/ca.pem
+
+[plain]
+controller_ip      = 10.0.0.2
+username           = admin
+credential_process = echo from-process
+`
+
+func TestLoadSharedProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := ioutil.WriteFile(path, []byte(testSharedConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := LoadSharedProfile(path, "")
+	if err != nil {
+		t.Fatalf("LoadSharedProfile: %v", err)
+	}
+	want := &SharedProfile{Name: "default", ControllerIP: "10.0.0.1", Username: "admin", Password: "secret"}
+	if !reflect.DeepEqual(profile, want) {
+		t.Errorf("expected %#v, got %#v", want, profile)
+	}
+
+	profile, err = LoadSharedProfile(path, "dr")
+	if err != nil {
+		t.Fatalf("LoadSharedProfile: %v", err)
+	}
+	if got := profile.ControllerIPs; !reflect.DeepEqual(got, []string{"ctrl.example.com", "standby.example.com"}) {
+		t.Errorf("unexpected controller_ips %q", got)
+	}
+	if err := profile.RunCredentialProcess(context.Background()); err != nil {
+		t.Fatalf("RunCredentialProcess: %v", err)
+	}
+	if profile.Username != "ops" || profile.Password != "from-process" {
+		t.Errorf("expected the credentials printed by the process, got %q/%q", profile.Username, profile.Password)
+	}
+
+	profile, err = LoadSharedProfile(path, "plain")
+	if err != nil {
+		t.Fatalf("LoadSharedProfile: %v", err)
+	}
+	if err := profile.RunCredentialProcess(context.Background()); err != nil {
+		t.Fatalf("RunCredentialProcess: %v", err)
+	}
+	if profile.Username != "admin" || profile.Password != "from-process" {
+		t.Errorf("expected the password printed by the process, got %q/%q", profile.Username, profile.Password)
+	}
+
+	profile.CredentialProcess = "echo oops >&2; exit 3"
+	if err := profile.RunCredentialProcess(context.Background()); err == nil || !strings.Contains(err.Error(), "oops") {
+		t.Errorf("expected the failure of the process, got %v", err)
+	}
+
+	if _, err := LoadSharedProfile(path, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := LoadSharedProfile(filepath.Join(t.TempDir(), "none"), ""); !os.IsNotExist(err) {
+		t.Errorf("expected a missing file error, got %v", err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("[default]\ncontroller = 10.0.0.1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSharedProfile(path, ""); err == nil || !strings.Contains(err.Error(), `unknown setting "controller"`) {
+		t.Errorf("expected an unknown setting error, got %v", err)
+	}
+}
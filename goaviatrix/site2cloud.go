@@ -1,14 +1,11 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"log"
-	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
 )
 
 const Phase1AuthDefault = "SHA-1"
@@ -70,12 +67,6 @@ type EditSite2Cloud struct {
 	CloudSubnetCidr string `form:"cloud_subnet_cidr,omitempty"`
 }
 
-type Site2CloudResp struct {
-	Return  bool               `json:"return"`
-	Results Site2CloudConnList `json:"results"`
-	Reason  string             `json:"reason"`
-}
-
 type Site2CloudConnList struct {
 	Connections []Site2Cloud `json:"connections"`
 }
@@ -104,12 +95,6 @@ type EditSite2CloudConnDetail struct {
 	DeadPeerDetectionConfig string        `json:"dpd_config,omitempty"`
 }
 
-type Site2CloudConnDetailResp struct {
-	Return  bool                     `json:"return"`
-	Results Site2CloudConnDetailList `json:"results"`
-	Reason  string                   `json:"reason"`
-}
-
 type Site2CloudConnDetailList struct {
 	Connections EditSite2CloudConnDetail `json:"connections"`
 }
@@ -133,13 +118,11 @@ type AlgorithmInfo struct {
 }
 
 func (c *Client) CreateSite2Cloud(site2cloud *Site2Cloud) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for add_site2cloud " + err.Error())
-	}
+	return c.CreateSite2CloudContext(c.Context(), site2cloud)
+}
+
+func (c *Client) CreateSite2CloudContext(ctx context.Context, site2cloud *Site2Cloud) error {
 	addSite2cloud := url.Values{}
-	addSite2cloud.Add("CID", c.CID)
-	addSite2cloud.Add("action", "add_site2cloud")
 	addSite2cloud.Add("vpc_id", site2cloud.VpcID)
 	addSite2cloud.Add("connection_name", site2cloud.TunnelName)
 	addSite2cloud.Add("connection_type", site2cloud.ConnType)
@@ -190,45 +173,23 @@ func (c *Client) CreateSite2Cloud(site2cloud *Site2Cloud) error {
 	addSite2cloud.Add("pre_shared_key", site2cloud.PreSharedKey)
 	addSite2cloud.Add("backup_pre_shared_key", site2cloud.BackupPreSharedKey)
 
-	Url.RawQuery = addSite2cloud.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get add_site2cloud failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_site2cloud failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_site2cloud Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "add_site2cloud", addSite2cloud, nil)
 }
 
 func (c *Client) GetSite2Cloud(site2cloud *Site2Cloud) (*Site2Cloud, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_site2cloud_conn") + err.Error())
-	}
-	listSite2CloudConn := url.Values{}
-	listSite2CloudConn.Add("CID", c.CID)
-	listSite2CloudConn.Add("action", "list_site2cloud_conn")
-	listSite2CloudConn.Add("connection_name", site2cloud.TunnelName)
-	Url.RawQuery = listSite2CloudConn.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return nil, errors.New("HTTP Get list_site2cloud_conn failed: " + err.Error())
-	}
-	var data Site2CloudResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_site2cloud_conn failed: " + err.Error())
+	return c.GetSite2CloudContext(c.Context(), site2cloud)
+}
+
+func (c *Client) GetSite2CloudContext(ctx context.Context, site2cloud *Site2Cloud) (*Site2Cloud, error) {
+	params := map[string]string{
+		"connection_name": site2cloud.TunnelName,
 	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_site2cloud_conn Get failed: " + data.Reason)
+	var data Site2CloudConnList
+	if err := c.GetAPIContext(ctx, "list_site2cloud_conn", params, &data); err != nil {
+		return nil, err
 	}
-	for i := 0; i < len(data.Results.Connections); i++ {
-		conn := data.Results.Connections[i]
+	for i := 0; i < len(data.Connections); i++ {
+		conn := data.Connections[i]
 		if site2cloud.VpcID == conn.VpcID && site2cloud.TunnelName == conn.TunnelName {
 			return &conn, nil
 		}
@@ -237,33 +198,23 @@ func (c *Client) GetSite2Cloud(site2cloud *Site2Cloud) (*Site2Cloud, error) {
 }
 
 func (c *Client) GetSite2CloudConnDetail(site2cloud *Site2Cloud) (*Site2Cloud, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for get_site2cloud_conn_detail") + err.Error())
-	}
-	getSite2CloudConnDetail := url.Values{}
-	getSite2CloudConnDetail.Add("CID", c.CID)
-	getSite2CloudConnDetail.Add("action", "get_site2cloud_conn_detail")
-	getSite2CloudConnDetail.Add("conn_name", site2cloud.TunnelName)
-	getSite2CloudConnDetail.Add("vpc_id", site2cloud.VpcID)
-	Url.RawQuery = getSite2CloudConnDetail.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return nil, errors.New("HTTP Get get_site2cloud_conn_detail failed: " + err.Error())
-	}
-	var data Site2CloudConnDetailResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode get_site2cloud_conn_detail failed: " + err.Error())
+	return c.GetSite2CloudConnDetailContext(c.Context(), site2cloud)
+}
+
+func (c *Client) GetSite2CloudConnDetailContext(ctx context.Context, site2cloud *Site2Cloud) (*Site2Cloud, error) {
+	params := map[string]string{
+		"conn_name": site2cloud.TunnelName,
+		"vpc_id":    site2cloud.VpcID,
 	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "does not exist") {
+	var data Site2CloudConnDetailList
+	if err := c.GetAPIContext(ctx, "get_site2cloud_conn_detail", params, &data); err != nil {
+		if errors.Is(err, ErrNotFound) {
 			return nil, ErrNotFound
 		}
-		return nil, errors.New("Rest API get_site2cloud_conn_detail Get failed: " + data.Reason)
+		return nil, err
 	}
 
-	s2cConnDetail := data.Results.Connections
+	s2cConnDetail := data.Connections
 	if len(s2cConnDetail.TunnelName) != 0 {
 		site2cloud.GwName = s2cConnDetail.GwName[0]
 		site2cloud.ConnType = s2cConnDetail.ConnType
@@ -331,49 +282,23 @@ func (c *Client) GetSite2CloudConnDetail(site2cloud *Site2Cloud) (*Site2Cloud, e
 }
 
 func (c *Client) UpdateSite2Cloud(site2cloud *EditSite2Cloud) error {
-	site2cloud.CID = c.CID
-	site2cloud.Action = "edit_site2cloud_conn"
-	resp, err := c.Post(c.baseURL, site2cloud)
-	if err != nil {
-		return errors.New("HTTP Post edit_site2cloud_conn failed: " + err.Error())
-	}
+	return c.UpdateSite2CloudContext(c.Context(), site2cloud)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode edit_site2cloud_conn failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API edit_site2cloud_conn Post failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) UpdateSite2CloudContext(ctx context.Context, site2cloud *EditSite2Cloud) error {
+	return c.PostAPIContext(ctx, "edit_site2cloud_conn", site2cloud, nil)
 }
 
 func (c *Client) DeleteSite2Cloud(site2cloud *Site2Cloud) error {
-	site2cloud.CID = c.CID
-	site2cloud.Action = "delete_site2cloud_connection"
-	verb := "POST"
-	body := fmt.Sprintf("CID=%s&action=%s&vpc_id=%s&connection_name=%s", c.CID, site2cloud.Action,
-		site2cloud.VpcID, site2cloud.TunnelName)
-
-	log.Printf("[TRACE] %s %s Body: %s", verb, c.baseURL, body)
-	req, err := http.NewRequest(verb, c.baseURL, strings.NewReader(body))
-	if err == nil {
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	} else {
-		return errors.New("HTTP Post NewRequest delete_site2cloud_connection failed: " + err.Error())
-	}
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return errors.New("HTTP Post delete_site2cloud_connection failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_site2cloud_connection failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_site2cloud_connection Post failed: " + data.Reason)
+	return c.DeleteSite2CloudContext(c.Context(), site2cloud)
+}
+
+func (c *Client) DeleteSite2CloudContext(ctx context.Context, site2cloud *Site2Cloud) error {
+	params := map[string]string{
+		"vpc_id":          site2cloud.VpcID,
+		"connection_name": site2cloud.TunnelName,
 	}
-	return nil
+	return c.PostAPIContext(ctx, "delete_site2cloud_connection", params, nil)
 }
 
 func (c *Client) Site2CloudAlgorithmCheck(site2cloud *Site2Cloud) error {
@@ -407,55 +332,25 @@ func (c *Client) Site2CloudAlgorithmCheck(site2cloud *Site2Cloud) error {
 }
 
 func (c *Client) EnableDeadPeerDetection(site2cloud *Site2Cloud) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for enable_dpd_config") + err.Error())
-	}
-	enableDPDConfig := url.Values{}
-	enableDPDConfig.Add("CID", c.CID)
-	enableDPDConfig.Add("action", "enable_dpd_config")
-	enableDPDConfig.Add("vpc_id", site2cloud.VpcID)
-	enableDPDConfig.Add("connection_name", site2cloud.TunnelName)
-
-	Url.RawQuery = enableDPDConfig.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.EnableDeadPeerDetectionContext(c.Context(), site2cloud)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_dpd_config failed: " + err.Error())
-	}
-	var data VGWConnEnableAdvertiseTransitCidrResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_dpd_config failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_dpd_config Get failed: " + data.Reason)
+func (c *Client) EnableDeadPeerDetectionContext(ctx context.Context, site2cloud *Site2Cloud) error {
+	params := map[string]string{
+		"vpc_id":          site2cloud.VpcID,
+		"connection_name": site2cloud.TunnelName,
 	}
-	return nil
+	return c.GetAPIContext(ctx, "enable_dpd_config", params, nil)
 }
 
 func (c *Client) DisableDeadPeerDetection(site2cloud *Site2Cloud) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disable_dpd_config") + err.Error())
-	}
-	disableDPDConfig := url.Values{}
-	disableDPDConfig.Add("CID", c.CID)
-	disableDPDConfig.Add("action", "disable_dpd_config")
-	disableDPDConfig.Add("vpc_id", site2cloud.VpcID)
-	disableDPDConfig.Add("connection_name", site2cloud.TunnelName)
-
-	Url.RawQuery = disableDPDConfig.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DisableDeadPeerDetectionContext(c.Context(), site2cloud)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_dpd_config failed: " + err.Error())
-	}
-	var data VGWConnEnableAdvertiseTransitCidrResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_dpd_config failed: " + err.Error())
+func (c *Client) DisableDeadPeerDetectionContext(ctx context.Context, site2cloud *Site2Cloud) error {
+	params := map[string]string{
+		"vpc_id":          site2cloud.VpcID,
+		"connection_name": site2cloud.TunnelName,
 	}
-	if !data.Return {
-		return errors.New("Rest API disable_dpd_config Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "disable_dpd_config", params, nil)
 }
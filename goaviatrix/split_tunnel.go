@@ -1,10 +1,7 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"net/url"
-	"strings"
+	"context"
 )
 
 type SplitTunnel struct {
@@ -20,12 +17,6 @@ type SplitTunnel struct {
 	SaveTemplate    string `form:"save_template,omitempty"`
 }
 
-type SplitTunnelResp struct {
-	Return  bool            `json:"return"`
-	Results SplitTunnelUnit `json:"results"`
-	Reason  string          `json:"reason"`
-}
-
 type SplitTunnelUnit struct {
 	NameServers     string `json:"name_servers"`
 	SplitTunnel     string `json:"split_tunnel"`
@@ -34,62 +25,39 @@ type SplitTunnelUnit struct {
 }
 
 func (c *Client) GetSplitTunnel(splitTunnel *SplitTunnel) (*SplitTunnelUnit, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for modify_split_tunnel") + err.Error())
-	}
-	modifySplitTunnel := url.Values{}
-	modifySplitTunnel.Add("CID", c.CID)
-	modifySplitTunnel.Add("action", "modify_split_tunnel")
-	modifySplitTunnel.Add("command", "get")
-	modifySplitTunnel.Add("vpc_id", splitTunnel.VpcID)
-	modifySplitTunnel.Add("lb_name", splitTunnel.ElbName)
-	Url.RawQuery = modifySplitTunnel.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetSplitTunnelContext(c.Context(), splitTunnel)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get modify_split_tunnel(get) failed: " + err.Error())
-	}
-	var data SplitTunnelResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode modify_split_tunnel(get) failed: " + err.Error())
+func (c *Client) GetSplitTunnelContext(ctx context.Context, splitTunnel *SplitTunnel) (*SplitTunnelUnit, error) {
+	params := map[string]string{
+		"command": "get",
+		"vpc_id":  splitTunnel.VpcID,
+		"lb_name": splitTunnel.ElbName,
 	}
-	if !data.Return {
-		return nil, errors.New("Rest API modify_split_tunnel(get) Get failed: " + data.Reason)
+	var data SplitTunnelUnit
+	if err := c.GetAPIContext(ctx, "modify_split_tunnel", params, &data); err != nil {
+		return nil, err
 	}
-	return &data.Results, nil
+	return &data, nil
 }
 
 func (c *Client) ModifySplitTunnel(splitTunnel *SplitTunnel) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for modify_split_tunnel") + err.Error())
-	}
-	modifySplitTunnel := url.Values{}
-	modifySplitTunnel.Add("CID", c.CID)
-	modifySplitTunnel.Add("action", "modify_split_tunnel")
-	modifySplitTunnel.Add("command", "modify")
-	modifySplitTunnel.Add("vpc_id", splitTunnel.VpcID)
-	modifySplitTunnel.Add("lb_name", splitTunnel.ElbName)
-	modifySplitTunnel.Add("split_tunnel", splitTunnel.SplitTunnel)
-	modifySplitTunnel.Add("additional_cidrs", splitTunnel.AdditionalCidrs)
-	modifySplitTunnel.Add("nameservers", splitTunnel.NameServers)
-	modifySplitTunnel.Add("search_domains", splitTunnel.SearchDomains)
-	Url.RawQuery = modifySplitTunnel.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.ModifySplitTunnelContext(c.Context(), splitTunnel)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get modify_split_tunnel(modify) failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode modify_split_tunnel(modify) failed: " + err.Error())
-	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "Nothing to modify") {
-			return nil
-		}
-		return errors.New("Rest API modify_split_tunnel(modify) Get failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) ModifySplitTunnelContext(ctx context.Context, splitTunnel *SplitTunnel) error {
+	params := map[string]string{
+		"command":          "modify",
+		"vpc_id":           splitTunnel.VpcID,
+		"lb_name":          splitTunnel.ElbName,
+		"split_tunnel":     splitTunnel.SplitTunnel,
+		"additional_cidrs": splitTunnel.AdditionalCidrs,
+		"nameservers":      splitTunnel.NameServers,
+		"search_domains":   splitTunnel.SearchDomains,
+	}
+	err := c.GetAPIContext(ctx, "modify_split_tunnel", params, nil)
+	if ReasonContains(err, "Nothing to modify") {
+		return nil
+	}
+	return err
 }
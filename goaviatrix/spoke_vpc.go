@@ -1,11 +1,10 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"log"
 	"net/url"
-	"strings"
 )
 
 // Spoke gateway simple struct to hold spoke details
@@ -34,85 +33,47 @@ type SpokeVpc struct {
 }
 
 func (c *Client) LaunchSpokeVpc(spoke *SpokeVpc) error {
-	spoke.CID = c.CID
-	spoke.Action = "create_spoke_gw"
-	resp, err := c.Post(c.baseURL, spoke)
-	if err != nil {
-		return errors.New("HTTP Post create_spoke_gw failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode create_spoke_gw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API create_spoke_gw Post failed: " + data.Reason)
-	}
-	return nil
+	return c.LaunchSpokeVpcContext(c.Context(), spoke)
+}
+
+func (c *Client) LaunchSpokeVpcContext(ctx context.Context, spoke *SpokeVpc) error {
+	return c.PostAPIContext(ctx, "create_spoke_gw", spoke, nil)
 }
 
 func (c *Client) SpokeJoinTransit(spoke *SpokeVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for attach_spoke_to_transit_gw") + err.Error())
-	}
-	attachSpokeToTransitGw := url.Values{}
-	attachSpokeToTransitGw.Add("CID", c.CID)
-	attachSpokeToTransitGw.Add("action", "attach_spoke_to_transit_gw")
-	attachSpokeToTransitGw.Add("spoke_gw", spoke.GwName)
-	attachSpokeToTransitGw.Add("transit_gw", spoke.TransitGateway)
-	Url.RawQuery = attachSpokeToTransitGw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.SpokeJoinTransitContext(c.Context(), spoke)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get attach_spoke_to_transit_gw failed: " + err.Error())
+func (c *Client) SpokeJoinTransitContext(ctx context.Context, spoke *SpokeVpc) error {
+	params := map[string]string{
+		"spoke_gw":   spoke.GwName,
+		"transit_gw": spoke.TransitGateway,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode attach_spoke_to_transit_gw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API attach_spoke_to_transit_gw Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "attach_spoke_to_transit_gw", params, nil)
 }
 
 func (c *Client) SpokeLeaveTransit(spoke *SpokeVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for detach_spoke_from_transit_gw") + err.Error())
-	}
-	detachSpokeFromTransitGw := url.Values{}
-	detachSpokeFromTransitGw.Add("CID", c.CID)
-	detachSpokeFromTransitGw.Add("action", "detach_spoke_from_transit_gw")
-	detachSpokeFromTransitGw.Add("spoke_gw", spoke.GwName)
-	Url.RawQuery = detachSpokeFromTransitGw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.SpokeLeaveTransitContext(c.Context(), spoke)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get detach_spoke_from_transit_gw failed: " + err.Error())
+func (c *Client) SpokeLeaveTransitContext(ctx context.Context, spoke *SpokeVpc) error {
+	params := map[string]string{
+		"spoke_gw": spoke.GwName,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_spoke_from_transit_gw failed: " + err.Error())
+	err := c.GetAPIContext(ctx, "detach_spoke_from_transit_gw", params, nil)
+	if ReasonContains(err, "has not joined to any transit") {
+		log.Printf("[INFO] spoke VPC is already left from transit VPC %s", err)
+		return nil
 	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "has not joined to any transit") {
-			log.Printf("[INFO] spoke VPC is already left from transit VPC %s", data.Reason)
-			return nil
-		}
-		return errors.New("Rest API detach_spoke_from_transit_gw Get failed: " + data.Reason)
-	}
-	return nil
+	return err
 }
 
 func (c *Client) EnableHaSpokeVpc(spoke *SpokeVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for enable_spoke_ha") + err.Error())
-	}
+	return c.EnableHaSpokeVpcContext(c.Context(), spoke)
+}
+
+func (c *Client) EnableHaSpokeVpcContext(ctx context.Context, spoke *SpokeVpc) error {
 	enableSpokeHa := url.Values{}
-	enableSpokeHa.Add("CID", c.CID)
-	enableSpokeHa.Add("action", "enable_spoke_ha")
 	enableSpokeHa.Add("gw_name", spoke.GwName)
 	enableSpokeHa.Add("eip", spoke.Eip)
 
@@ -123,23 +84,13 @@ func (c *Client) EnableHaSpokeVpc(spoke *SpokeVpc) error {
 	} else {
 		return errors.New("invalid cloud type")
 	}
-	Url.RawQuery = enableSpokeHa.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get enable_spoke_ha failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_spoke_ha failed: " + err.Error())
+	err := c.GetAPIContext(ctx, "enable_spoke_ha", enableSpokeHa, nil)
+	if ReasonContains(err, "HA GW already exists") {
+		log.Printf("[INFO] HA is already enabled %s", err)
+		return nil
 	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "HA GW already exists") {
-			log.Printf("[INFO] HA is already enabled %s", data.Reason)
-			return nil
-		}
-		log.Printf("[ERROR] Enabling HA failed with error %s", data.Reason)
-		return errors.New("Rest API enable_spoke_ha Get failed: " + data.Reason)
+	if err != nil {
+		log.Printf("[ERROR] Enabling HA failed with error %s", err)
 	}
-	return nil
+	return err
 }
@@ -1,111 +1,168 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"log"
-	"net/http"
-	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 // Tags simple struct to hold tag details
 type Tags struct {
-	Action       string `form:"action,omitempty"`
-	CID          string `form:"CID,omitempty"`
-	CloudType    int    `form:"cloud_type,omitempty"`
-	ResourceType string `form:"resource_type,omitempty"`
-	ResourceName string `form:"resource_name,omitempty"`
-	TagList      string `form:"new_tag_list,omitempty"`
+	CloudType    int
+	ResourceType string
+	ResourceName string
+	Tags         map[string]string
 }
 
-type TagAPIResp struct {
-	Return  bool                         `json:"return"`
-	Results map[string]map[string]string `json:"results"`
-	Reason  string                       `json:"reason"`
+// params returns the parameters identifying the resource and, unless
+// listName is empty, its encoded tags.
+func (tags *Tags) params(listName string) map[string]string {
+	params := map[string]string{
+		"cloud_type":    strconv.Itoa(tags.CloudType),
+		"resource_type": tags.ResourceType,
+		"resource_name": tags.ResourceName,
+	}
+	if listName != "" {
+		params[listName] = EncodeTags(tags.Tags)
+	}
+	return params
 }
 
-func (c *Client) AddTags(tags *Tags) error {
-	tags.CID = c.CID
-	tags.Action = "add_resource_tags"
-	resp, err := c.Post(c.baseURL, tags)
-	if err != nil {
-		return errors.New("HTTP Post add_resource_tags failed: " + err.Error())
+// EncodeTags writes tags as the list of key:value pairs separated by commas
+// the controller takes, sorted by key. The first colon of a pair separates
+// the key from the value; colons in values are escaped with two backslashes,
+// as the controller expects. Keys cannot contain colons or commas, nor values
+// commas, see ValidateTag.
+func EncodeTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_resource_tags failed: " + err.Error())
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + ":" + strings.Replace(tags[k], ":", `\\:`, -1)
 	}
-	if !data.Return {
-		return errors.New("Rest API add_resource_tags Post failed: " + data.Reason)
+	return strings.Join(pairs, ",")
+}
+
+// ValidateTag returns an error if the tag cannot be written in the
+// controller's tag list format.
+func ValidateTag(key string, value string) error {
+	switch {
+	case key == "":
+		return errors.New("tag keys must not be empty")
+	case strings.ContainsAny(key, ":,"):
+		return fmt.Errorf("tag key %q must not contain ':' or ','", key)
+	case strings.Contains(value, ","):
+		return fmt.Errorf("value of tag %q must not contain ','", key)
 	}
 	return nil
 }
 
-func (c *Client) GetTags(tags *Tags) ([]string, error) {
-	tags.CID = c.CID
-	tags.Action = "list_resource_tags"
-	resp, err := c.Post(c.baseURL, tags)
-	if err != nil {
-		return nil, errors.New("HTTP Post list_resource_tags failed: " + err.Error())
+func validateTags(tags map[string]string) error {
+	for k, v := range tags {
+		if err := ValidateTag(k, v); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	var data TagAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_resource_tags failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_resource_tags Post failed: " + data.Reason)
+// AddTags adds tags.Tags to the resource, replacing the values of the keys
+// it already has.
+func (c *Client) AddTags(tags *Tags) error {
+	return c.AddTagsContext(c.Context(), tags)
+}
+
+func (c *Client) AddTagsContext(ctx context.Context, tags *Tags) error {
+	if err := validateTags(tags.Tags); err != nil {
+		return err
 	}
+	return c.PostAPIContext(ctx, "add_resource_tags", tags.params("new_tag_list"), nil)
+}
 
-	var tagList []string
-	keys := reflect.ValueOf(data.Results).MapKeys()
-	strKeys := make([]string, len(keys))
-	for i := 0; i < len(keys); i++ {
-		strKeys[i] = keys[i].String()
+// GetTags returns the tags of the resource, but the one the controller adds
+// to the resources it creates.
+func (c *Client) GetTags(tags *Tags) (map[string]string, error) {
+	return c.GetTagsContext(c.Context(), tags)
+}
+
+func (c *Client) GetTagsContext(ctx context.Context, tags *Tags) (map[string]string, error) {
+	var data struct {
+		Tags map[string]string `json:"tags"`
 	}
-	for i := 0; i < len(keys); i++ {
-		if strKeys[i] == "tags" {
-			allKeys := reflect.ValueOf(data.Results["tags"]).MapKeys()
-			for i := 0; i < len(allKeys); i++ {
-				if allKeys[i].String() == "Aviatrix-Created-Resource" &&
-					data.Results["tags"][allKeys[i].String()] == "Do-Not-Delete-Aviatrix-Created-Resource" {
-					continue
-				}
-				str := allKeys[i].String() + ":" + data.Results["tags"][allKeys[i].String()]
-				tagList = append(tagList, str)
-			}
-			return tagList, nil
-		}
+	if err := c.PostAPIContext(ctx, "list_resource_tags", tags.params(""), &data); err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	tagMap := make(map[string]string, len(data.Tags))
+	for k, v := range data.Tags {
+		if k == "Aviatrix-Created-Resource" && v == "Do-Not-Delete-Aviatrix-Created-Resource" {
+			continue
+		}
+		tagMap[k] = v
+	}
+	return tagMap, nil
 }
 
+// DeleteTags deletes the tags of the resource with the keys of tags.Tags;
+// their values are ignored.
 func (c *Client) DeleteTags(tags *Tags) error {
-	tags.CID = c.CID
-	tags.Action = "delete_resource_tags"
-	verb := "POST"
-	body := fmt.Sprintf("CID=%s&action=%s&cloud_type=%d&resource_type=%s&resource_name=%s&del_tag_list=%s",
-		c.CID, tags.Action, tags.CloudType, tags.ResourceType, tags.ResourceName, tags.TagList)
-	log.Printf("[TRACE] %s %s Body: %s", verb, c.baseURL, body)
-	req, err := http.NewRequest(verb, c.baseURL, strings.NewReader(body))
-	if err == nil {
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	} else {
-		return errors.New("HTTP New Request Post delete_resource_tags failed: " + err.Error())
+	return c.DeleteTagsContext(c.Context(), tags)
+}
+
+func (c *Client) DeleteTagsContext(ctx context.Context, tags *Tags) error {
+	if err := validateTags(tags.Tags); err != nil {
+		return err
+	}
+	return c.PostAPIContext(ctx, "delete_resource_tags", tags.params("del_tag_list"), nil)
+}
+
+// SetDefaultTags sets the tags added to every resource tagged through the
+// client, e.g. the cost center and owner required by a governance policy.
+// A tag set on the resource takes precedence over a default tag with the
+// same key.
+func (c *Client) SetDefaultTags(tags map[string]string) {
+	c.defaultTags = tags
+}
+
+// DefaultTags returns the tags set with SetDefaultTags.
+func (c *Client) DefaultTags() map[string]string {
+	return c.defaultTags
+}
+
+// MergeDefaultTags returns tags and the default tags whose key tags does
+// not set.
+func (c *Client) MergeDefaultTags(tags map[string]string) map[string]string {
+	merged := make(map[string]string, len(tags)+len(c.defaultTags))
+	for k, v := range c.defaultTags {
+		merged[k] = v
 	}
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return errors.New("HTTP Post delete_resource_tags failed: " + err.Error())
+	for k, v := range tags {
+		merged[k] = v
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_resource_tags failed: " + err.Error())
+	return merged
+}
+
+// WithoutDefaultTags returns tags, as returned by GetTags, without the
+// default tags unless configured sets the same value, so that only the tags
+// set on the resource are compared with its configuration.
+func (c *Client) WithoutDefaultTags(tags map[string]string, configured map[string]string) map[string]string {
+	if len(c.defaultTags) == 0 {
+		return tags
 	}
-	if !data.Return {
-		return errors.New("Rest API delete_resource_tags Post failed: " + data.Reason)
+	own := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if _, ok := c.defaultTags[k]; ok {
+			if cv, ok := configured[k]; !ok || cv != v {
+				continue
+			}
+		}
+		own[k] = v
 	}
-	return nil
+	return own
 }
@@ -0,0 +1,81 @@
+package goaviatrix
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/fakecontroller"
+)
+
+func TestEncodeTags(t *testing.T) {
+	for _, tc := range []struct {
+		tags map[string]string
+		want string
+	}{
+		{nil, ""},
+		{map[string]string{"Owner": "me"}, "Owner:me"},
+		{map[string]string{"b": "2", "a": "1", "c": ""}, "a:1,b:2,c:"},
+		{map[string]string{"Env": "test a:b:c"}, `Env:test a\\:b\\:c`},
+	} {
+		if got := EncodeTags(tc.tags); got != tc.want {
+			t.Errorf("EncodeTags(%v) = %q, want %q", tc.tags, got, tc.want)
+		}
+	}
+}
+
+func TestValidateTag(t *testing.T) {
+	for _, tc := range []struct {
+		key, value string
+		valid      bool
+	}{
+		{"Owner", "me", true},
+		{"Owner", "a:b", true},
+		{"Owner", "", true},
+		{"", "me", false},
+		{"Env:Name", "test", false},
+		{"Env,Name", "test", false},
+		{"Owner", "me, you", false},
+	} {
+		if err := ValidateTag(tc.key, tc.value); (err == nil) != tc.valid {
+			t.Errorf("ValidateTag(%q, %q) = %v, want valid %t", tc.key, tc.value, err, tc.valid)
+		}
+	}
+}
+
+func TestTagsRoundTrip(t *testing.T) {
+	srv := fakecontroller.New()
+	defer srv.Close()
+	client, err := NewClient(srv.Username, srv.Password, srv.Host(), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	want := map[string]string{"Owner": "me", "Env": "test a:b", "Empty": ""}
+	tags := &Tags{CloudType: 1, ResourceType: "vpc", ResourceName: "vpc-1", Tags: want}
+	if err := client.AddTags(tags); err != nil {
+		t.Fatalf("AddTags: %v", err)
+	}
+	got, err := client.GetTags(tags)
+	if err != nil {
+		t.Fatalf("GetTags: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected tags %v, got %v", want, got)
+	}
+
+	tags.Tags = map[string]string{"Env": "test a:b"}
+	if err := client.DeleteTags(tags); err != nil {
+		t.Fatalf("DeleteTags: %v", err)
+	}
+	if got, _ := client.GetTags(tags); !reflect.DeepEqual(got, map[string]string{"Owner": "me", "Empty": ""}) {
+		t.Errorf("expected Env to be deleted, got %v", got)
+	}
+
+	tags.Tags = map[string]string{"Env:Name": "test"}
+	if err := client.AddTags(tags); err == nil {
+		t.Error("expected AddTags to reject a key with a colon")
+	}
+	if got := srv.Calls("add_resource_tags"); got != 1 {
+		t.Errorf("expected the invalid tag not to be sent, got %d add_resource_tags calls", got)
+	}
+}
@@ -0,0 +1,130 @@
+package goaviatrix
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// TLSOptions holds the settings used to verify the certificate presented by
+// the controller.
+type TLSOptions struct {
+	// CAFile is the path to a PEM encoded CA bundle used instead of the
+	// system roots.
+	CAFile string
+	// CAPEM is a PEM encoded CA bundle used instead of the system roots.
+	CAPEM string
+	// CertFingerprints is a list of hex encoded SHA-256 fingerprints. When
+	// set, the controller's leaf certificate must match one of them.
+	CertFingerprints []string
+	// Insecure disables chain and hostname verification. Pinned
+	// fingerprints are still enforced.
+	Insecure bool
+}
+
+// CertificateError is returned when the certificate presented by the
+// controller could not be verified.
+type CertificateError struct {
+	Host string
+	Err  error
+}
+
+func (e *CertificateError) Error() string {
+	return fmt.Sprintf("TLS certificate verification failed for controller %s: %v", e.Host, e.Err)
+}
+
+func (e *CertificateError) Unwrap() error {
+	return e.Err
+}
+
+// ErrFingerprintMismatch is returned when the controller's certificate does
+// not match any of the pinned fingerprints.
+var ErrFingerprintMismatch = errors.New("certificate does not match any pinned SHA-256 fingerprint")
+
+// NewTLSConfig builds a tls.Config from the given options.
+// Arguments:
+//    opts - the TLS options, nil means default verification
+// Returns:
+//    tls.Config - the TLS configuration
+//    error - if the CA bundle or fingerprints could not be parsed
+func NewTLSConfig(opts *TLSOptions) (*tls.Config, error) {
+	config := &tls.Config{}
+	if opts == nil {
+		return config, nil
+	}
+
+	if opts.CAFile != "" || opts.CAPEM != "" {
+		pool := x509.NewCertPool()
+		if opts.CAFile != "" {
+			pem, err := ioutil.ReadFile(opts.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA bundle %s: %v", opts.CAFile, err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in CA bundle %s", opts.CAFile)
+			}
+		}
+		if opts.CAPEM != "" && !pool.AppendCertsFromPEM([]byte(opts.CAPEM)) {
+			return nil, errors.New("no certificates found in CA bundle PEM")
+		}
+		config.RootCAs = pool
+	}
+
+	var pins [][]byte
+	for _, fp := range opts.CertFingerprints {
+		pin, err := hex.DecodeString(strings.Replace(strings.TrimSpace(fp), ":", "", -1))
+		if err != nil || len(pin) != sha256.Size {
+			return nil, fmt.Errorf("invalid SHA-256 certificate fingerprint %q", fp)
+		}
+		pins = append(pins, pin)
+	}
+	if len(pins) != 0 {
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return ErrFingerprintMismatch
+			}
+			sum := sha256.Sum256(rawCerts[0])
+			for _, pin := range pins {
+				if string(pin) == string(sum[:]) {
+					return nil
+				}
+			}
+			return ErrFingerprintMismatch
+		}
+	}
+
+	config.InsecureSkipVerify = opts.Insecure
+	return config, nil
+}
+
+// NewTransport creates an http.Transport that verifies the controller
+// certificate according to the given options.
+func NewTransport(opts *TLSOptions) (*http.Transport, error) {
+	config, err := NewTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = config
+	return tr, nil
+}
+
+// isCertificateError reports whether err was caused by the controller's
+// certificate failing verification.
+func isCertificateError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.Is(err, ErrFingerprintMismatch) ||
+		errors.As(err, &verificationErr) ||
+		errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
@@ -0,0 +1,124 @@
+package goaviatrix
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// loginServer is a controller stub accepting the login of admin/password.
+func loginServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Form.Get("username") != "admin" || r.Form.Get("password") != "password" {
+			fmt.Fprint(w, `{"return":false,"reason":"Invalid username or password"}`)
+			return
+		}
+		fmt.Fprint(w, `{"return":true,"CID":"cid"}`)
+	}))
+}
+
+func TestTLSOptions(t *testing.T) {
+	srv := loginServer()
+	defer srv.Close()
+	controllerIP := strings.TrimPrefix(srv.URL, "https://")
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(caFile, []byte(caPEM), 0600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(srv.Certificate().Raw)
+	goodPin := hex.EncodeToString(sum[:])
+	badPin := strings.Repeat("00", sha256.Size)
+
+	for _, tc := range []struct {
+		name    string
+		opts    *TLSOptions
+		wantErr error
+	}{
+		{name: "system roots", opts: nil, wantErr: &CertificateError{}},
+		{name: "CA file", opts: &TLSOptions{CAFile: caFile}},
+		{name: "CA PEM", opts: &TLSOptions{CAPEM: caPEM}},
+		{name: "good pin", opts: &TLSOptions{CAPEM: caPEM, CertFingerprints: []string{badPin, goodPin}}},
+		{name: "bad pin", opts: &TLSOptions{CAPEM: caPEM, CertFingerprints: []string{badPin}}, wantErr: ErrFingerprintMismatch},
+		{name: "insecure", opts: &TLSOptions{Insecure: true}},
+		{name: "insecure with good pin", opts: &TLSOptions{Insecure: true, CertFingerprints: []string{goodPin}}},
+		{name: "insecure with bad pin", opts: &TLSOptions{Insecure: true, CertFingerprints: []string{badPin}}, wantErr: ErrFingerprintMismatch},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr, err := NewTransport(tc.opts)
+			if err != nil {
+				t.Fatalf("NewTransport: %v", err)
+			}
+			_, err = NewClient("admin", "password", controllerIP, &http.Client{Transport: tr})
+			switch want := tc.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Errorf("expected the login to succeed, got %v", err)
+				}
+			case *CertificateError:
+				if !errors.As(err, &want) {
+					t.Errorf("expected a CertificateError, got %v", err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Errorf("expected %v, got %v", want, err)
+				}
+				var certErr *CertificateError
+				if !errors.As(err, &certErr) || certErr.Host != controllerIP {
+					t.Errorf("expected a CertificateError for %s, got %v", controllerIP, err)
+				}
+			}
+		})
+	}
+}
+
+func TestWrongPasswordIsNotCertificateError(t *testing.T) {
+	srv := loginServer()
+	defer srv.Close()
+
+	_, err := NewClient("admin", "wrong", strings.TrimPrefix(srv.URL, "https://"), srv.Client())
+	if err == nil {
+		t.Fatal("expected the login to fail")
+	}
+	if isCertificateError(err) {
+		t.Errorf("expected a login failure, got certificate error %v", err)
+	}
+	var certErr *CertificateError
+	if errors.As(err, &certErr) {
+		t.Errorf("expected a login failure, got %v", err)
+	}
+}
+
+func TestNewTLSConfigErrors(t *testing.T) {
+	for _, opts := range []*TLSOptions{
+		{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+		{CAPEM: "not a certificate"},
+		{CertFingerprints: []string{"abcd"}},
+		{CertFingerprints: []string{strings.Repeat("zz", sha256.Size)}},
+	} {
+		if _, err := NewTLSConfig(opts); err == nil {
+			t.Errorf("NewTLSConfig(%+v): expected an error", opts)
+		}
+	}
+
+	config, err := NewTLSConfig(&TLSOptions{CertFingerprints: []string{strings.Repeat("AB:", sha256.Size-1) + "AB"}})
+	if err != nil {
+		t.Fatalf("expected colon separated fingerprints to be accepted, got %v", err)
+	}
+	if config.VerifyPeerCertificate == nil {
+		t.Error("expected the fingerprint to be pinned")
+	}
+}
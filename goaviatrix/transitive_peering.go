@@ -1,10 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	//"github.com/davecgh/go-spew/spew"
 )
 
 // TransPeer simple struct to hold transitive peering details
@@ -17,44 +15,24 @@ type TransPeer struct {
 	ReachableCidr string `form:"reachable_cidr" json:"reachable_cidr"`
 }
 
-type TransPeerListResp struct {
-	Return  bool        `json:"return"`
-	Results []TransPeer `json:"results"`
-	Reason  string      `json:"reason"`
+func (c *Client) CreateTransPeer(transPeer *TransPeer) error {
+	return c.CreateTransPeerContext(c.Context(), transPeer)
 }
 
-func (c *Client) CreateTransPeer(transPeer *TransPeer) error {
-	transPeer.CID = c.CID
-	transPeer.Action = "add_extended_vpc_peer"
-	resp, err := c.Post(c.baseURL, transPeer)
-	if err != nil {
-		return errors.New("HTTP Post add_extended_vpc_peer failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_extended_vpc_peer failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_extended_vpc_peer Post failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) CreateTransPeerContext(ctx context.Context, transPeer *TransPeer) error {
+	return c.PostAPIContext(ctx, "add_extended_vpc_peer", transPeer, nil)
 }
 
 func (c *Client) GetTransPeer(transPeer *TransPeer) (*TransPeer, error) {
-	transPeer.CID = c.CID
-	transPeer.Action = "list_extended_vpc_peer"
-	resp, err := c.Post(c.baseURL, transPeer)
-	if err != nil {
-		return nil, errors.New("HTTP Post list_extended_vpc_peer failed: " + err.Error())
-	}
-	var data TransPeerListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_extended_vpc_peer failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_extended_vpc_peer Post failed: " + data.Reason)
+	return c.GetTransPeerContext(c.Context(), transPeer)
+}
+
+func (c *Client) GetTransPeerContext(ctx context.Context, transPeer *TransPeer) (*TransPeer, error) {
+	var data []TransPeer
+	if err := c.PostAPIContext(ctx, "list_extended_vpc_peer", transPeer, &data); err != nil {
+		return nil, err
 	}
-	transPeerList := data.Results
+	transPeerList := data
 	for i := range transPeerList {
 		if transPeerList[i].Source == transPeer.Source && transPeerList[i].Nexthop == transPeer.Nexthop {
 			return &transPeerList[i], nil
@@ -70,18 +48,9 @@ func (c *Client) UpdateTransPeer(transPeer *TransPeer) error {
 }
 
 func (c *Client) DeleteTransPeer(transPeer *TransPeer) error {
-	transPeer.CID = c.CID
-	transPeer.Action = "delete_extended_vpc_peer"
-	resp, err := c.Post(c.baseURL, transPeer)
-	if err != nil {
-		return errors.New("HTTP Post delete_extended_vpc_peer failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_extended_vpc_peer failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_extended_vpc_peer Post failed: " + data.Reason)
-	}
-	return nil
+	return c.DeleteTransPeerContext(c.Context(), transPeer)
+}
+
+func (c *Client) DeleteTransPeerContext(ctx context.Context, transPeer *TransPeer) error {
+	return c.PostAPIContext(ctx, "delete_extended_vpc_peer", transPeer, nil)
 }
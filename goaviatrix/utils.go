@@ -6,7 +6,6 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 )
 
 var ErrNotFound = fmt.Errorf("ErrNotFound")
@@ -102,14 +101,3 @@ func Contains(slice []string, item string) bool {
 	_, ok := set[item]
 	return ok
 }
-
-func TagListStrColon(tagListStr []string) []string {
-	if tagListStr != nil {
-		for i := range tagListStr {
-			tagListStr[i] = strings.ReplaceAll(tagListStr[i], ":", "\\\\:")
-			tagListStr[i] = strings.Replace(tagListStr[i], "\\\\:", ":", 1)
-		}
-		return tagListStr
-	}
-	return nil
-}
@@ -1,16 +1,15 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
-	"time"
 )
 
 type Version struct {
@@ -20,23 +19,11 @@ type Version struct {
 	Version       string `json:"version,omitempty"`
 }
 
-type UpgradeResp struct {
-	Return  bool   `json:"return"`
-	Results string `json:"results"`
-	Reason  string `json:"reason"`
-}
-
 type VersionInfo struct {
 	CurrentVersion string `json:"current_version"`
 	LatestVersion  string `json:"latest_version"`
 }
 
-type VersionInfoResp struct {
-	Return  bool        `json:"return"`
-	Results VersionInfo `json:"results"`
-	Reason  string      `json:"reason"`
-}
-
 type AviatrixVersion struct {
 	Major int64
 	Minor int64
@@ -44,65 +31,31 @@ type AviatrixVersion struct {
 }
 
 func (c *Client) Upgrade(version *Version) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for upgrade") + err.Error())
-	}
-	attachSpokeToTransitGw := url.Values{}
-	attachSpokeToTransitGw.Add("CID", c.CID)
-	attachSpokeToTransitGw.Add("action", "upgrade")
+	return c.UpgradeContext(c.Context(), version)
+}
 
+func (c *Client) UpgradeContext(ctx context.Context, version *Version) error {
+	params := map[string]string{}
 	if version.Version == "" {
 		return errors.New("no target version is set")
 	} else if version.Version != "latest" {
-		attachSpokeToTransitGw.Add("version", version.Version)
+		params["version"] = version.Version
 	}
-	for i := 0; ; i++ {
-		Url.RawQuery = attachSpokeToTransitGw.Encode()
-		resp, err := c.Get(Url.String(), nil)
-		if err != nil {
-			return errors.New("HTTP Get upgrade failed: " + err.Error())
-		}
-		var data UpgradeResp
-		if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-			return errors.New("Json Decode upgrade failed: " + err.Error())
-		}
-		if !data.Return {
-			if strings.Contains(data.Reason, "Active upgrade in progress.") && i < 3 {
-				log.Printf("[INFO] Active upgrade is in progress. Retry after 60 secs...")
-				time.Sleep(60 * time.Second)
-				continue
-			}
-			return errors.New("Rest API upgrade Get failed: " + data.Reason)
-		}
-		break
-	}
-	return nil
+	defer c.forgetControllerVersion()
+	return c.GetAPIContext(ctx, "upgrade", params, nil)
 }
 
 func (c *Client) GetCurrentVersion() (string, *AviatrixVersion, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return "", nil, errors.New(("url Parsing failed for list_version_info") + err.Error())
-	}
-	listVersionInfo := url.Values{}
-	listVersionInfo.Add("CID", c.CID)
-	listVersionInfo.Add("action", "list_version_info")
-	Url.RawQuery = listVersionInfo.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetCurrentVersionContext(c.Context())
+}
 
-	if err != nil {
-		return "", nil, errors.New("HTTP Get list_version_info failed: " + err.Error())
-	}
-	var data VersionInfoResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", nil, errors.New("Json Decode list_version_info failed: " + err.Error())
-	}
-	if !data.Return {
-		return "", nil, errors.New("Rest API list_version_info Get failed: " + data.Reason)
+func (c *Client) GetCurrentVersionContext(ctx context.Context) (string, *AviatrixVersion, error) {
+	var data VersionInfo
+	if err := c.GetAPIContext(ctx, "list_version_info", nil, &data); err != nil {
+		return "", nil, err
 	}
 
-	curVersion, aVer, err := ParseVersion(data.Results.CurrentVersion)
+	curVersion, aVer, err := ParseVersion(data.CurrentVersion)
 	if err != nil {
 		return "", aVer, err
 	}
@@ -111,57 +64,47 @@ func (c *Client) GetCurrentVersion() (string, *AviatrixVersion, error) {
 }
 
 func (c *Client) Pre32Upgrade() error {
-	privateBaseURL := strings.Replace(c.baseURL, "/v1/api", "/v1/backend1", 1)
+	return c.Pre32UpgradeContext(c.Context())
+}
+
+func (c *Client) Pre32UpgradeContext(ctx context.Context) error {
+	defer c.forgetControllerVersion()
+	privateBaseURL := strings.Replace(apiURL(c.Endpoint()), "/v1/api", "/v1/backend1", 1)
 	params := &Version{
 		Action: "userconnect_release",
-		CID:    c.CID,
+		CID:    c.CID(),
 	}
 	path := privateBaseURL
-	for i := 0; ; i++ {
-		resp, err := c.Post(path, params)
+	// Only retry while the controller is busy, e.g. with the upgrade.
+	return c.RetryContext(ctx, func(err error) bool { return errors.Is(err, ErrBusy) }, func() error {
+		resp, err := c.PostContext(ctx, path, params)
 		if err != nil {
 			return errors.New("HTTP Post userconnect_release failed: " + err.Error())
 		}
 		defer resp.Body.Close()
-		if resp.StatusCode == http.StatusOK {
-			body, _ := ioutil.ReadAll(resp.Body)
-			log.Printf("[TRACE] response %s", body)
-			if strings.Contains(string(body), "in progress") && i < 3 {
-				log.Printf("[INFO] Active upgrade is in progress. Retry after 60 secs...")
-				time.Sleep(60 * time.Second)
-			} else {
-				break
-			}
-		} else {
+		if resp.StatusCode != http.StatusOK {
 			return fmt.Errorf("status code %d", resp.StatusCode)
 		}
-	}
-	return nil
+		body, _ := ioutil.ReadAll(resp.Body)
+		log.Printf("[TRACE] response %s", Redact(string(body)))
+		if strings.Contains(string(body), "in progress") {
+			return NewAPIError(params.Action, "Post", resp.StatusCode, "upgrade in progress")
+		}
+		return nil
+	})
 }
 
 func (c *Client) GetLatestVersion() (string, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return "", errors.New(("url Parsing failed for list_version_info") + err.Error())
-	}
-	listVersionInfo := url.Values{}
-	listVersionInfo.Add("CID", c.CID)
-	listVersionInfo.Add("action", "list_version_info")
-	Url.RawQuery = listVersionInfo.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetLatestVersionContext(c.Context())
+}
 
-	if err != nil {
-		return "", errors.New("HTTP Get list_version_info failed: " + err.Error())
-	}
-	var data VersionInfoResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", errors.New("Json Decode list_version_info failed: " + err.Error())
-	}
-	if !data.Return {
-		return "", errors.New("Rest API list_version_info Get failed: " + data.Reason)
+func (c *Client) GetLatestVersionContext(ctx context.Context) (string, error) {
+	var data VersionInfo
+	if err := c.GetAPIContext(ctx, "list_version_info", nil, &data); err != nil {
+		return "", err
 	}
 
-	latestVersion, _, err := ParseVersion(data.Results.LatestVersion)
+	latestVersion, _, err := ParseVersion(data.LatestVersion)
 	if err != nil {
 		return "", err
 	}
@@ -198,3 +141,96 @@ func ParseVersion(version string) (string, *AviatrixVersion, error) {
 	}
 	return strconv.FormatInt(aver.Major, 10) + "." + strconv.FormatInt(aver.Minor, 10), aver, nil
 }
+
+// String returns the version as major.minor.build, or major.minor if the
+// build is unknown.
+func (v *AviatrixVersion) String() string {
+	s := strconv.FormatInt(v.Major, 10) + "." + strconv.FormatInt(v.Minor, 10)
+	if v.Build != 0 {
+		s += "." + strconv.FormatInt(v.Build, 10)
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if v is older than, the same as or newer than
+// other.
+func (v *AviatrixVersion) Compare(other *AviatrixVersion) int {
+	for _, d := range []int64{v.Major - other.Major, v.Minor - other.Minor, v.Build - other.Build} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// VersionRange is a range of controller versions, bounds included. A bound
+// without build number covers all the builds of its release, and a bound
+// with an x as minor version all the releases of its major version, so that
+// "4.7 - 5.0" contains 4.7.520 and 5.0.2000, and "4.7 - 5.x" 5.2.100.
+type VersionRange struct {
+	Min *AviatrixVersion
+	Max *AviatrixVersion
+}
+
+// ParseVersionRange parses a range written "min - max", e.g. "4.7 - 5.0" or
+// "4.7 - 5.x", or a single version such as "4.7", which is the range of its
+// builds.
+func ParseVersionRange(versions string) (*VersionRange, error) {
+	bounds := strings.SplitN(versions, "-", 2)
+	if strings.HasPrefix(versions, "UserConnect-") {
+		bounds = []string{versions}
+	}
+	if len(bounds) == 1 {
+		bounds = append(bounds, bounds[0])
+	}
+	vr := &VersionRange{}
+	var err error
+	if vr.Min, err = parseVersionBound(strings.TrimSpace(bounds[0]), 0); err != nil {
+		return nil, fmt.Errorf("invalid version range %q: %v", versions, err)
+	}
+	if vr.Max, err = parseVersionBound(strings.TrimSpace(bounds[1]), math.MaxInt64); err != nil {
+		return nil, fmt.Errorf("invalid version range %q: %v", versions, err)
+	}
+	if vr.Min.Compare(vr.Max) > 0 {
+		return nil, fmt.Errorf("invalid version range %q: %s is newer than %s", versions, vr.Min, vr.Max)
+	}
+	return vr, nil
+}
+
+// parseVersionBound parses a bound of a VersionRange, using minor as the
+// minor version of a bound such as "5.x".
+func parseVersionBound(bound string, minor int64) (*AviatrixVersion, error) {
+	if strings.HasSuffix(bound, ".x") {
+		_, v, err := ParseVersion(strings.TrimSuffix(bound, "x") + "0")
+		if err != nil || v.Build != 0 {
+			return nil, fmt.Errorf("unable to parse version bound %q", bound)
+		}
+		v.Minor = minor
+		return v, nil
+	}
+	_, v, err := ParseVersion(bound)
+	return v, err
+}
+
+// Contains reports whether v is in the range.
+func (vr *VersionRange) Contains(v *AviatrixVersion) bool {
+	max := *vr.Max
+	if max.Build == 0 {
+		max.Build = math.MaxInt64
+	}
+	return v.Compare(vr.Min) >= 0 && v.Compare(&max) <= 0
+}
+
+func (vr *VersionRange) String() string {
+	if vr.Min.Compare(vr.Max) == 0 {
+		return vr.Min.String()
+	}
+	max := vr.Max.String()
+	if vr.Max.Minor == math.MaxInt64 {
+		max = strconv.FormatInt(vr.Max.Major, 10) + ".x"
+	}
+	return vr.Min.String() + " - " + max
+}
@@ -0,0 +1,88 @@
+package goaviatrix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/fakecontroller"
+)
+
+func TestVersionRange(t *testing.T) {
+	for _, tc := range []struct {
+		versions string
+		version  string
+		want     bool
+	}{
+		{"4.7", "4.7.520", true},
+		{"4.7", "4.6.100", false},
+		{"4.7", "5.0.2000", false},
+		{"4.7 - 5.0", "4.7.0", true},
+		{"4.7 - 5.0", "5.0.2000", true},
+		{"4.7 - 5.0", "5.1.10", false},
+		{"4.7.520-5.0.2000", "4.7.474", false},
+		{"4.7.520-5.0.2000", "5.0.2001", false},
+		{"UserConnect-4.7", "UserConnect-4.7.585", true},
+		{"4.7 - 5.x", "4.6.100", false},
+		{"4.7 - 5.x", "5.2.100", true},
+		{"4.7 - 5.x", "6.0.10", false},
+		{"4.x - 5.x", "4.0.1", true},
+	} {
+		vr, err := ParseVersionRange(tc.versions)
+		if err != nil {
+			t.Fatalf("ParseVersionRange(%q): %v", tc.versions, err)
+		}
+		_, v, err := ParseVersion(tc.version)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", tc.version, err)
+		}
+		if got := vr.Contains(v); got != tc.want {
+			t.Errorf("expected %s in %s to be %v", tc.version, vr, tc.want)
+		}
+	}
+
+	for _, versions := range []string{"4.7", "4.7 - 5.0", "4.7 - 5.x"} {
+		vr, err := ParseVersionRange(versions)
+		if err != nil || vr.String() != versions {
+			t.Errorf("expected %q to parse and print back, got %v, %v", versions, vr, err)
+		}
+	}
+
+	for _, versions := range []string{"", "4", "5.0 - 4.7", "4.7 - x", "4.7 - 5.x.1", "4.7 - 4.x - 5.x"} {
+		if _, err := ParseVersionRange(versions); err == nil {
+			t.Errorf("expected %q to be invalid", versions)
+		}
+	}
+}
+
+func TestRequireVersion(t *testing.T) {
+	srv := fakecontroller.New()
+	defer srv.Close()
+	client, err := NewClient(srv.Username, srv.Password, srv.Host(), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if err := client.ControllerVersionValidation("4.7 - 5.0"); err != nil {
+		t.Errorf("ControllerVersionValidation: %v", err)
+	}
+	err = client.ControllerVersionValidation("5.0 - 5.1")
+	if err == nil || !strings.Contains(err.Error(), "supports controller version: UserConnect-5.0 - 5.1, controller runs UserConnect-4.7.520") {
+		t.Errorf("expected the version to be out of range, got %v", err)
+	}
+
+	if err := client.RequireVersion("feature", "4.7.520"); err != nil {
+		t.Errorf("RequireVersion: %v", err)
+	}
+	err = client.RequireVersion("feature", "4.7.585")
+	if err == nil || err.Error() != "feature requires controller version UserConnect-4.7.585 or later, controller runs UserConnect-4.7.520" {
+		t.Errorf("expected the feature to be refused, got %v", err)
+	}
+	if got := srv.Calls("list_version_info"); got != 1 {
+		t.Errorf("expected the version to be read once, got %d calls", got)
+	}
+
+	client.SkipVersionChecks(true)
+	if err := client.RequireVersion("feature", "4.7.585"); err != nil {
+		t.Errorf("expected the check to be skipped, got %v", err)
+	}
+}
@@ -1,10 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	"net/url"
 	"strconv"
 )
 
@@ -32,12 +30,6 @@ type VpcEdit struct {
 	Subnets            []SubnetInfo `json:"subnets,omitempty"`
 }
 
-type VpcResp struct {
-	Return  bool                  `json:"return"`
-	Results AllVpcPoolVpcListResp `json:"results"`
-	Reason  string                `json:"reason"`
-}
-
 type AllVpcPoolVpcListResp struct {
 	AllVpcPoolVpcList []VpcEdit `json:"all_vpc_pool_vpc_list, omitempty"`
 }
@@ -48,59 +40,32 @@ type SubnetInfo struct {
 }
 
 func (c *Client) CreateVpc(vpc *Vpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for create_custom_vpc ") + err.Error())
-	}
-	createCustomVpc := url.Values{}
-	createCustomVpc.Add("CID", c.CID)
-	createCustomVpc.Add("action", "create_custom_vpc")
-	createCustomVpc.Add("cloud_type", strconv.Itoa(vpc.CloudType))
-	createCustomVpc.Add("account_name", vpc.AccountName)
-	createCustomVpc.Add("region", vpc.Region)
-	createCustomVpc.Add("pool_name", vpc.Name)
-	createCustomVpc.Add("vpc_cidr", vpc.Cidr)
-	createCustomVpc.Add("aviatrix_transit_vpc", vpc.AviatrixTransitVpc)
-	createCustomVpc.Add("aviatrix_firenet_vpc", vpc.AviatrixFireNetVpc)
-
-	Url.RawQuery = createCustomVpc.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.CreateVpcContext(c.Context(), vpc)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get create_custom_vpc failed: " + err.Error())
+func (c *Client) CreateVpcContext(ctx context.Context, vpc *Vpc) error {
+	params := map[string]string{
+		"cloud_type":           strconv.Itoa(vpc.CloudType),
+		"account_name":         vpc.AccountName,
+		"region":               vpc.Region,
+		"pool_name":            vpc.Name,
+		"vpc_cidr":             vpc.Cidr,
+		"aviatrix_transit_vpc": vpc.AviatrixTransitVpc,
+		"aviatrix_firenet_vpc": vpc.AviatrixFireNetVpc,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode create_custom_vpc failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API create_custom_vpc Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "create_custom_vpc", params, nil)
 }
 
 func (c *Client) GetVpc(vpc *Vpc) (*Vpc, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_custom_vpcs ") + err.Error())
-	}
-	listPeerVpcPairs := url.Values{}
-	listPeerVpcPairs.Add("CID", c.CID)
-	listPeerVpcPairs.Add("action", "list_custom_vpcs")
-	Url.RawQuery = listPeerVpcPairs.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetVpcContext(c.Context(), vpc)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_custom_vpcs failed: " + err.Error())
-	}
-	var data VpcResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_custom_vpcs failed: " + err.Error())
+func (c *Client) GetVpcContext(ctx context.Context, vpc *Vpc) (*Vpc, error) {
+	var data AllVpcPoolVpcListResp
+	if err := c.GetAPIContext(ctx, "list_custom_vpcs", nil, &data); err != nil {
+		return nil, err
 	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_custom_vpcs Get failed: " + data.Reason)
-	}
-	allVpcPoolVpcListResp := data.Results.AllVpcPoolVpcList
+	allVpcPoolVpcListResp := data.AllVpcPoolVpcList
 	for i := range allVpcPoolVpcListResp {
 		if allVpcPoolVpcListResp[i].Name == vpc.Name {
 			log.Printf("[DEBUG] Found VPC: %#v", allVpcPoolVpcListResp[i])
@@ -134,27 +99,13 @@ func (c *Client) UpdateVpc(vpc *Vpc) error {
 }
 
 func (c *Client) DeleteVpc(vpc *Vpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for delete_custom_vpc ") + err.Error())
-	}
-	createCustomVpc := url.Values{}
-	createCustomVpc.Add("CID", c.CID)
-	createCustomVpc.Add("action", "delete_custom_vpc")
-	createCustomVpc.Add("account_name", vpc.AccountName)
-	createCustomVpc.Add("pool_name", vpc.Name)
-	Url.RawQuery = createCustomVpc.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteVpcContext(c.Context(), vpc)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get delete_custom_vpc failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_custom_vpc failed: " + err.Error())
+func (c *Client) DeleteVpcContext(ctx context.Context, vpc *Vpc) error {
+	params := map[string]string{
+		"account_name": vpc.AccountName,
+		"pool_name":    vpc.Name,
 	}
-	if !data.Return {
-		return errors.New("Rest API delete_custom_vpc Get failed: " + data.Reason)
-	}
-	return nil
+	return c.GetAPIContext(ctx, "delete_custom_vpc", params, nil)
 }
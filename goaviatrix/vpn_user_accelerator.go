@@ -1,8 +1,7 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 )
 
 type VpnUserXlr struct {
@@ -14,48 +13,25 @@ type VpnUserXlr struct {
 	InUseEndpoints string `json:"inuse,omitempty"`
 }
 
-type VpnUserXlrAPIResp struct {
-	Return  bool                `json:"return"`
-	Results map[string][]string `json:"results"`
-	Reason  string              `json:"reason"`
-}
-
 func (c *Client) GetVpnUserAccelerator() ([]string, error) {
-	xlr := VpnUserXlr{}
-	xlr.CID = c.CID
-	xlr.Action = "list_vpn_user_xlr"
-	resp, err := c.Post(c.baseURL, xlr)
+	return c.GetVpnUserAcceleratorContext(c.Context())
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_vpn_user_xlr failed: " + err.Error())
-	}
-	var data VpnUserXlrAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_vpn_user_xlr failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_vpn_user_xlr Get failed: " + data.Reason)
+func (c *Client) GetVpnUserAcceleratorContext(ctx context.Context) ([]string, error) {
+	var data map[string][]string
+	if err := c.PostAPIContext(ctx, "list_vpn_user_xlr", nil, &data); err != nil {
+		return nil, err
 	}
 
 	elbList := make([]string, 0)
-	elbList = data.Results["inuse"]
+	elbList = data["inuse"]
 	return elbList, nil
 }
 
 func (c *Client) UpdateVpnUserAccelerator(xlr *VpnUserXlr) error {
-	xlr.CID = c.CID
-	xlr.Action = "update_vpn_user_xlr"
-	resp, err := c.Post(c.baseURL, xlr)
+	return c.UpdateVpnUserAcceleratorContext(c.Context(), xlr)
+}
 
-	if err != nil {
-		return errors.New("HTTP Post update_vpn_user_xlr failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode update_vpn_user_xlr failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API update_vpn_user_xlr Get failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) UpdateVpnUserAcceleratorContext(ctx context.Context, xlr *VpnUserXlr) error {
+	return c.PostAPIContext(ctx, "update_vpn_user_xlr", xlr, nil)
 }
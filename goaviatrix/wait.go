@@ -0,0 +1,121 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// pollInterval is how long the WaitFor functions wait between two reads.
+var pollInterval = 10 * time.Second
+
+// pollSleep waits between two reads of PollContext. Tests replace it to
+// simulate the passing of time.
+var pollSleep = SleepContext
+
+// PollContext calls check until it reports that what it waits for is done,
+// it fails, or ctx is done, e.g. because the timeout of the Terraform
+// operation expired.
+func (c *Client) PollContext(ctx context.Context, what string, check func() (bool, error)) error {
+	for {
+		done, err := check()
+		if err != nil && ctx.Err() != nil {
+			// The read was cut short by ctx rather than failing.
+			return fmt.Errorf("gave up waiting for %s: %w", what, ctx.Err())
+		}
+		if err != nil || done {
+			return err
+		}
+		log.Printf("[DEBUG] Waiting %s for %s", pollInterval, what)
+		if err := pollSleep(ctx, pollInterval); err != nil {
+			return fmt.Errorf("gave up waiting for %s: %w", what, err)
+		}
+		// Read the inventory again rather than the reply kept by the cache.
+		c.InvalidateReadCache()
+	}
+}
+
+// WaitForFoundContext calls fn until it does not fail with ErrNotFound,
+// e.g. because the controller does not know yet of a resource just created
+// in the cloud, or ctx is done.
+func (c *Client) WaitForFoundContext(ctx context.Context, what string, fn func() error) error {
+	return c.PollContext(ctx, what+" to be found", func() (bool, error) {
+		err := fn()
+		if errors.Is(err, ErrNotFound) {
+			log.Printf("[DEBUG] %s not found yet: %s", what, err)
+			return false, nil
+		}
+		return err == nil, err
+	})
+}
+
+// WaitForGateway waits until the controller reports the gateway up.
+func (c *Client) WaitForGateway(gateway *Gateway) (*Gateway, error) {
+	return c.WaitForGatewayContext(c.Context(), gateway)
+}
+
+func (c *Client) WaitForGatewayContext(ctx context.Context, gateway *Gateway) (*Gateway, error) {
+	var gw *Gateway
+	err := c.PollContext(ctx, "gateway "+gateway.GwName+" to be up", func() (bool, error) {
+		var err error
+		gw, err = c.GetGatewayContext(ctx, gateway)
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return gw.VpcState == "up", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gw, nil
+}
+
+// WaitForController waits until the controller answers again after an
+// upgrade, and returns the version it runs.
+func (c *Client) WaitForController() (string, error) {
+	return c.WaitForControllerContext(c.Context())
+}
+
+func (c *Client) WaitForControllerContext(ctx context.Context) (string, error) {
+	var version string
+	err := c.PollContext(ctx, "the controller to be ready", func() (bool, error) {
+		var err error
+		version, _, err = c.GetCurrentVersionContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, err
+			}
+			log.Printf("[DEBUG] Controller not ready: %s", err)
+			return false, nil
+		}
+		return true, nil
+	})
+	return version, err
+}
+
+// WaitForHttpAccessContext waits until the controller reports HTTP access
+// enabled or disabled, as the web server of the controller restarts after
+// the setting changed.
+func (c *Client) WaitForHttpAccessContext(ctx context.Context, enabled bool) error {
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+	return c.PollContext(ctx, "http access to be "+state, func() (bool, error) {
+		result, err := c.GetHttpAccessEnabledContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, err
+			}
+			log.Printf("[DEBUG] Controller not ready: %s", err)
+			return false, nil
+		}
+		return strings.Contains(result, "True") == enabled, nil
+	})
+}
@@ -0,0 +1,136 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/fakecontroller"
+)
+
+func TestWaitForGateway(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	srv := fakecontroller.New()
+	defer srv.Close()
+	client, err := NewClient(srv.Username, srv.Password, srv.Host(), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.SetReadCacheTTL(time.Minute)
+
+	reads := 0
+	srv.Handle("list_vpcs_summary", func(params url.Values) (interface{}, error) {
+		reads++
+		switch {
+		case reads == 1:
+			return []interface{}{}, nil
+		case reads < 4:
+			return []interface{}{map[string]interface{}{"vpc_name": "gw", "vpc_state": "creating"}}, nil
+		}
+		return []interface{}{map[string]interface{}{"vpc_name": "gw", "vpc_state": "up"}}, nil
+	})
+
+	gw, err := client.WaitForGateway(&Gateway{GwName: "gw"})
+	if err != nil {
+		t.Fatalf("WaitForGateway: %v", err)
+	}
+	if gw.VpcState != "up" || reads != 4 {
+		t.Errorf("expected the gateway to be up after 4 reads, got %q after %d", gw.VpcState, reads)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.WaitForGatewayContext(ctx, &Gateway{GwName: "missing"})
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "gave up waiting for gateway missing to be up") {
+		t.Errorf("expected the wait to time out, got %v", err)
+	}
+}
+
+func TestWaitForFound(t *testing.T) {
+	defer func(sleep func(context.Context, time.Duration) error) { pollSleep = sleep }(pollSleep)
+	var elapsed time.Duration
+	pollSleep = func(ctx context.Context, d time.Duration) error {
+		elapsed += d
+		return ctx.Err()
+	}
+
+	srv := fakecontroller.New()
+	defer srv.Close()
+	client, err := NewClient(srv.Username, srv.Password, srv.Host(), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	// The controller finds the ELB a minute after it was created, longer
+	// than the retry policy would wait.
+	srv.Handle("update_vpn_user_xlr", func(params url.Values) (interface{}, error) {
+		if elapsed < time.Minute {
+			return nil, errors.New("ELB elb1 does not exist")
+		}
+		return "updated", nil
+	})
+
+	xlr := &VpnUserXlr{Endpoints: `["elb1"]`}
+	err = client.WaitForFoundContext(context.Background(), "elb elb1", func() error {
+		return client.UpdateVpnUserAcceleratorContext(context.Background(), xlr)
+	})
+	if err != nil {
+		t.Fatalf("WaitForFoundContext: %v", err)
+	}
+	if elapsed < time.Minute {
+		t.Errorf("expected to wait a minute, waited %s", elapsed)
+	}
+	if got, want := srv.Calls("update_vpn_user_xlr"), int(time.Minute/pollInterval)+1; got != want {
+		t.Errorf("expected %d calls, got %d", want, got)
+	}
+
+	// Other errors are returned at once.
+	calls := srv.Calls("update_vpn_user_xlr")
+	srv.Handle("update_vpn_user_xlr", func(params url.Values) (interface{}, error) {
+		return nil, errors.New("Invalid endpoints")
+	})
+	err = client.WaitForFoundContext(context.Background(), "elb elb1", func() error {
+		return client.UpdateVpnUserAcceleratorContext(context.Background(), xlr)
+	})
+	if !errors.Is(err, ErrValidation) || srv.Calls("update_vpn_user_xlr") != calls+1 {
+		t.Errorf("expected a single failed call, got %v after %d calls", err, srv.Calls("update_vpn_user_xlr")-calls)
+	}
+}
+
+func TestWaitForHttpAccess(t *testing.T) {
+	defer func(sleep func(context.Context, time.Duration) error) { pollSleep = sleep }(pollSleep)
+	pollSleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
+
+	srv := fakecontroller.New()
+	defer srv.Close()
+	client, err := NewClient(srv.Username, srv.Password, srv.Host(), srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	// The web server restarts: the setting is first unknown, then still the
+	// old one.
+	reads := 0
+	srv.Handle("config_http_access", func(params url.Values) (interface{}, error) {
+		reads++
+		switch {
+		case reads == 1:
+			return nil, errors.New("Web server is restarting")
+		case reads < 4:
+			return `"False"`, nil
+		}
+		return `"True"`, nil
+	})
+
+	if err := client.WaitForHttpAccessContext(context.Background(), true); err != nil {
+		t.Fatalf("WaitForHttpAccessContext: %v", err)
+	}
+	if reads != 4 {
+		t.Errorf("expected http access to be enabled after 4 reads, got %d", reads)
+	}
+}